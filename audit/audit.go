@@ -0,0 +1,18 @@
+// Package audit holds the audit log entries shared by the authorization
+// middleware and the stores keeping them.
+package audit
+
+import "context"
+
+// Entry records a call the authorization middleware denied.
+type Entry struct {
+	UserID   string // Empty for unauthenticated callers
+	Roles    []string
+	Method   string
+	Required string // Role the method needs
+}
+
+// Log keeps the entries of denied calls.
+type Log interface {
+	Record(ctx context.Context, e *Entry) error
+}
@@ -1,48 +1,265 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"net"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/Forum-service/Forum-Service/config"
+	"github.com/Forum-service/Forum-Service/events"
+	"github.com/Forum-service/Forum-Service/genproto/automod"
 	"github.com/Forum-service/Forum-Service/genproto/category"
 	"github.com/Forum-service/Forum-Service/genproto/comment"
+	"github.com/Forum-service/Forum-Service/genproto/notification"
 	"github.com/Forum-service/Forum-Service/genproto/post"
 	"github.com/Forum-service/Forum-Service/genproto/posttag"
+	"github.com/Forum-service/Forum-Service/genproto/report"
 	"github.com/Forum-service/Forum-Service/genproto/tag"
+	"github.com/Forum-service/Forum-Service/middleware"
+	"github.com/Forum-service/Forum-Service/ratelimit"
 	"github.com/Forum-service/Forum-Service/service"
 	"github.com/Forum-service/Forum-Service/storage/postgres"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 )
 
 func main() {
-	cfg := config.Load()
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// run serves until SIGINT or SIGTERM, then shuts down in order: stop
+// accepting RPCs, drain in-flight ones, stop background workers and finally
+// close the publisher and storage, which the workers still use.
+func run() error {
+	flags := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	printConfig := flags.Bool("print-config", false, "print the effective configuration with secrets redacted and exit")
+	cfg, err := config.Load(flags, os.Args[1:])
+	if err != nil {
+		return fmt.Errorf("error loading configuration:\n%w", err)
+	}
+	if *printConfig {
+		fmt.Print(cfg.Redacted())
+		return nil
+	}
+	postgres.DefaultPageSize = int32(cfg.DefaultPageSize)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	pgStorage, err := postgres.NewStorage(&cfg)
+	pgStorage, err := postgres.NewStorage(ctx, &cfg)
 	if err != nil {
-		panic(fmt.Sprintf("Error connecting to postgres: %v", err))
+		return fmt.Errorf("error connecting to postgres: %w", err)
 	}
 	defer pgStorage.Close()
 
-	lis, err := net.Listen("tcp", ":8082")
+	publisher, err := newPublisher(&cfg)
+	if err != nil {
+		return fmt.Errorf("error creating event publisher: %w", err)
+	}
+	defer publisher.Close()
+
+	// Workers get their own context so they keep running while requests drain
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
+	defer func() {
+		stopWorkers()
+		workers.Wait()
+	}()
+
+	relay := events.NewRelay(pgStorage.Outbox(), publisher, events.RelayConfig{
+		PollInterval: cfg.OutboxPollInterval,
+		BatchSize:    cfg.OutboxBatchSize,
+	})
+	commentFeed := service.NewCommentFeed(pgStorage)
+	for _, worker := range []func(context.Context){relay.Run, commentFeed.Run} {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			worker(workerCtx)
+		}()
+	}
+
+	filters, err := service.NewContentFilters(service.FilterConfig{
+		BannedWords:       cfg.FilterBannedWords,
+		BannedWordsAction: cfg.FilterBannedWordsAction,
+		MaxLinks:          cfg.FilterMaxLinks,
+		LinksAction:       cfg.FilterLinksAction,
+		CapsRatio:         cfg.FilterCapsRatio,
+		CapsMinLetters:    cfg.FilterCapsMinLetters,
+		CapsAction:        cfg.FilterCapsAction,
+		RepeatWindow:      cfg.FilterRepeatWindow,
+		RepeatAction:      cfg.FilterRepeatAction,
+	})
 	if err != nil {
-		panic(fmt.Sprintf("Error listening on port %s: %v", "8082", err))
+		return fmt.Errorf("error configuring content filters: %w", err)
 	}
 
-	s := grpc.NewServer()
+	limits, err := middleware.ParseLimits(cfg.RateLimits)
+	if err != nil {
+		return fmt.Errorf("error parsing rate limits: %w", err)
+	}
+	limitStore, err := newRateLimitStore(&cfg, pgStorage)
+	if err != nil {
+		return fmt.Errorf("error creating rate limit store: %w", err)
+	}
+	limiter := middleware.NewRateLimiter(limitStore, limits)
+
+	validator, err := middleware.NewValidator(service.Validation, cfg.MaxPageSize)
+	if err != nil {
+		return fmt.Errorf("error configuring request validation: %w", err)
+	}
+
+	// Map errors outermost so clients get meaningful codes from every layer
+	errorMapper := middleware.ErrorMapper(service.StatusError)
+	unary := []grpc.UnaryServerInterceptor{errorMapper.UnaryInterceptor()}
+	stream := []grpc.StreamServerInterceptor{errorMapper.StreamInterceptor()}
+	authenticator, err := newAuthenticator(&cfg)
+	if err != nil {
+		return fmt.Errorf("error configuring authentication: %w", err)
+	}
+	if authenticator != nil {
+		authorizer, err := middleware.NewAuthorizer(service.Policy, pgStorage.Audit())
+		if err != nil {
+			return fmt.Errorf("error configuring authorization: %w", err)
+		}
+		// Authenticate first so roles can be checked and limits apply to the
+		// authenticated user
+		unary = append(unary, authenticator.UnaryInterceptor(), authorizer.UnaryInterceptor())
+		stream = append(stream, authenticator.StreamInterceptor(), authorizer.StreamInterceptor())
+	} else {
+		log.Warn().Msg("Authentication is disabled, every method including admin and moderator ones is open to anyone")
+	}
+	unary = append(unary, validator.UnaryInterceptor())
+	if cfg.RateLimitEnabled {
+		unary = append(unary, limiter.UnaryInterceptor())
+	}
+	stream = append(stream, validator.StreamInterceptor())
+
+	lis, err := net.Listen("tcp", cfg.ListenAddr)
+	if err != nil {
+		return fmt.Errorf("error listening on %s: %w", cfg.ListenAddr, err)
+	}
+
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	)
 
 	// Register your gRPC services here
 	category.RegisterCategoryServiceServer(s, service.NewCategoryService(pgStorage))
 	tag.RegisterTagServiceServer(s, service.NewTagService(pgStorage))
-	post.RegisterPostServiceServer(s, service.NewPostService(pgStorage))
-	comment.RegisterCommentServiceServer(s, service.NewCommentService(pgStorage))
+	post.RegisterPostServiceServer(s, service.NewPostService(pgStorage, filters))
+	comment.RegisterCommentServiceServer(s, service.NewCommentService(pgStorage, commentFeed, filters, cfg.CommentEditWindow))
 	posttag.RegisterPostTagServiceServer(s, service.NewPostTagService(pgStorage))
+	notification.RegisterNotificationServiceServer(s, service.NewNotificationService(pgStorage))
+	report.RegisterReportServiceServer(s, service.NewReportService(pgStorage))
+	automod.RegisterAutomodServiceServer(s, service.NewAutomodService(pgStorage))
+
+	if cfg.ReflectionEnabled {
+		reflection.Register(s) // Enable reflection for debugging
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- s.Serve(lis)
+	}()
+	fmt.Println("gRPC Server listening on", cfg.ListenAddr)
+
+	select {
+	case err := <-serveErr:
+		return fmt.Errorf("error serving gRPC: %w", err)
+	case <-ctx.Done():
+	}
+	stop() // A second signal kills the process right away
+
+	fmt.Println("Shutting down, draining in-flight requests")
+	gracefulStop(s, cfg.ShutdownTimeout)
+	return nil
+}
+
+// gracefulStop stops accepting RPCs and waits for in-flight ones to finish.
+// Once timeout passes the remaining ones, such as long lived comment watch
+// streams, are cancelled.
+func gracefulStop(s *grpc.Server, timeout time.Duration) {
+	stopped := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(stopped)
+	}()
 
-	reflection.Register(s) // Enable reflection for debugging
+	select {
+	case <-stopped:
+	case <-time.After(timeout):
+		fmt.Println("Shutdown timeout exceeded, cancelling remaining requests")
+		s.Stop()
+		<-stopped
+	}
+}
+
+// newPublisher picks the outbox publisher configured for this deployment.
+func newPublisher(cfg *config.Config) (events.Publisher, error) {
+	switch cfg.EventPublisher {
+	case "kafka":
+		return events.NewKafkaPublisher(cfg.KafkaBrokers, cfg.KafkaTopic), nil
+	case "file":
+		return events.NewFilePublisher(cfg.EventFilePath)
+	case "memory":
+		return events.NewMemoryPublisher(), nil
+	}
+	return nil, fmt.Errorf("unknown event publisher %q", cfg.EventPublisher)
+}
+
+// newRateLimitStore picks where rate limit buckets are kept: in memory for a
+// single instance or in Postgres when several replicas share the limits.
+func newRateLimitStore(cfg *config.Config, pgStorage *postgres.Storage) (ratelimit.Store, error) {
+	switch cfg.RateLimitStore {
+	case "memory":
+		return middleware.NewMemoryStore(), nil
+	case "postgres":
+		return pgStorage.RateLimit(), nil
+	}
+	return nil, fmt.Errorf("unknown rate limit store %q", cfg.RateLimitStore)
+}
+
+// newAuthenticator builds the token validator configured for this deployment.
+// Without a key it fails unless authentication is explicitly disabled, in
+// which case it returns nil.
+func newAuthenticator(cfg *config.Config) (*middleware.Authenticator, error) {
+	if cfg.AuthHMACSecret == "" && cfg.AuthRSAPublicKeyFile == "" {
+		if !cfg.AuthDisabled {
+			return nil, errors.New("set AUTH_HMAC_SECRET or AUTH_RSA_PUBLIC_KEY_FILE, or AUTH_DISABLED=true to run without authentication")
+		}
+		return nil, nil
+	}
 
-	fmt.Println("gRPC Server listening on", "8082")
-	if err := s.Serve(lis); err != nil {
-		panic(fmt.Sprintf("Failed to start gRPC server: %v", err))
+	authCfg := middleware.AuthConfig{
+		Issuer:        cfg.AuthIssuer,
+		Audience:      cfg.AuthAudience,
+		PublicMethods: cfg.AuthPublicMethods,
+	}
+	if cfg.AuthHMACSecret != "" {
+		authCfg.HMACSecret = []byte(cfg.AuthHMACSecret)
+	}
+	if cfg.AuthRSAPublicKeyFile != "" {
+		pem, err := os.ReadFile(cfg.AuthRSAPublicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		if authCfg.RSAPublicKey, err = jwt.ParseRSAPublicKeyFromPEM(pem); err != nil {
+			return nil, err
+		}
 	}
+	return middleware.NewAuthenticator(authCfg)
 }
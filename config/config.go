@@ -1,54 +1,287 @@
 package config
 
 import (
+	"errors"
+	"flag"
 	"fmt"
 	"os"
+	"reflect"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/spf13/cast"
+	"gopkg.in/yaml.v3"
 )
 
+// Config holds every tunable of the service. Each field is a setting named by
+// its env tag: the same name in lower case is its key in the config file and,
+// with dashes instead of underscores, its command-line flag, so POSTGRES_HOST
+// is also postgres_host and -postgres-host. Lists are comma separated in the
+// environment and on the command line.
 type Config struct {
-	HTTPPort string
+	ListenAddr string `env:"LISTEN_ADDR" default:":8082" desc:"address the gRPC server listens on"`
 
-	PostgresHost     string
-	PostgresPort     int
-	PostgresUser     string
-	PostgresPassword string
-	PostgresDatabase string
+	PostgresHost            string        `env:"POSTGRES_HOST" default:"postgres_dock" desc:"Postgres host"`
+	PostgresPort            int           `env:"POSTGRES_PORT" default:"5432" desc:"Postgres port"`
+	PostgresUser            string        `env:"POSTGRES_USER" default:"postgres" desc:"Postgres user"`
+	PostgresPassword        string        `env:"POSTGRES_PASSWORD" default:"root" secret:"true" desc:"Postgres password"`
+	PostgresDatabase        string        `env:"POSTGRES_DATABASE" default:"forum" desc:"Postgres database"`
+	PostgresMaxConns        int           `env:"POSTGRES_MAX_CONNS" default:"10" desc:"most connections in the pool"`
+	PostgresMinConns        int           `env:"POSTGRES_MIN_CONNS" default:"0" desc:"connections the pool keeps open when idle"`
+	PostgresMaxConnLifetime time.Duration `env:"POSTGRES_MAX_CONN_LIFETIME" default:"1h" desc:"age at which pooled connections are replaced"`
+	PostgresMaxConnIdleTime time.Duration `env:"POSTGRES_MAX_CONN_IDLE_TIME" default:"30m" desc:"idle time after which pooled connections are closed"`
+	PostgresConnectAttempts int           `env:"POSTGRES_CONNECT_ATTEMPTS" default:"10" desc:"tries before startup gives up on Postgres"`
+	PostgresConnectBackoff  time.Duration `env:"POSTGRES_CONNECT_BACKOFF" default:"1s" desc:"delay after the first failed try, doubled after each one"`
 
-	DefaultOffset string
-	DefaultLimit  string
+	DefaultPageSize int `env:"DEFAULT_PAGE_SIZE" default:"10" desc:"limit of list RPCs that do not set one"`
+	MaxPageSize     int `env:"MAX_PAGE_SIZE" default:"100" desc:"largest limit list RPCs accept"`
+
+	ShutdownTimeout time.Duration `env:"SHUTDOWN_TIMEOUT" default:"30s" desc:"how long in-flight RPCs may take to finish on shutdown"`
+
+	ReflectionEnabled bool `env:"REFLECTION_ENABLED" default:"true" desc:"serve gRPC reflection for debugging clients"`
+
+	EventPublisher     string        `env:"EVENT_PUBLISHER" default:"file" desc:"where outbox events go: kafka, file or memory"`
+	KafkaBrokers       []string      `env:"KAFKA_BROKERS" default:"localhost:9092" desc:"Kafka broker addresses"`
+	KafkaTopic         string        `env:"KAFKA_TOPIC" default:"forum.events" desc:"Kafka topic events are published to"`
+	EventFilePath      string        `env:"EVENT_FILE_PATH" default:"events.jsonl" desc:"file events are appended to"`
+	OutboxPollInterval time.Duration `env:"OUTBOX_POLL_INTERVAL" default:"1s" desc:"how often the outbox is drained"`
+	OutboxBatchSize    int           `env:"OUTBOX_BATCH_SIZE" default:"100" desc:"most events published per batch"`
+
+	CommentEditWindow time.Duration `env:"COMMENT_EDIT_WINDOW" default:"15m" desc:"how long authors may edit comments, 0 for forever"`
+
+	// Content filters, each disabled when its action is empty, otherwise reject, mask or flag
+	FilterBannedWords       []string      `env:"FILTER_BANNED_WORDS" desc:"words the banned words filter matches"`
+	FilterBannedWordsAction string        `env:"FILTER_BANNED_WORDS_ACTION" default:"mask" desc:"action of the banned words filter"`
+	FilterMaxLinks          int           `env:"FILTER_MAX_LINKS" default:"5" desc:"most links a post may hold"`
+	FilterLinksAction       string        `env:"FILTER_LINKS_ACTION" default:"flag" desc:"action of the links filter"`
+	FilterCapsRatio         float64       `env:"FILTER_CAPS_RATIO" default:"0.7" desc:"share of capital letters the caps filter matches"`
+	FilterCapsMinLetters    int           `env:"FILTER_CAPS_MIN_LETTERS" default:"20" desc:"letters below which the caps filter ignores text"`
+	FilterCapsAction        string        `env:"FILTER_CAPS_ACTION" default:"flag" desc:"action of the caps filter"`
+	FilterRepeatWindow      time.Duration `env:"FILTER_REPEAT_WINDOW" default:"10m" desc:"window in which the repeat filter matches duplicates"`
+	FilterRepeatAction      string        `env:"FILTER_REPEAT_ACTION" default:"reject" desc:"action of the repeat filter"`
+
+	RateLimitEnabled bool   `env:"RATE_LIMIT_ENABLED" default:"true" desc:"rate limit calls per user, or per address for unauthenticated calls"`
+	RateLimits       string `env:"RATE_LIMITS" default:"CreatePost=5/1h,CreateComment=30/10m" desc:"method=requests/period list"`
+	RateLimitStore   string `env:"RATE_LIMIT_STORE" default:"memory" desc:"where rate limits are counted: memory or postgres"`
+
+	// The service refuses to start without a key unless AuthDisabled is set
+	AuthDisabled         bool     `env:"AUTH_DISABLED" default:"false" desc:"run without authentication, opening every method to anyone"`
+	AuthHMACSecret       string   `env:"AUTH_HMAC_SECRET" secret:"true" desc:"secret of HMAC signed tokens"`
+	AuthRSAPublicKeyFile string   `env:"AUTH_RSA_PUBLIC_KEY_FILE" desc:"PEM encoded public key of RSA signed tokens"`
+	AuthIssuer           string   `env:"AUTH_ISSUER" desc:"required token issuer"`
+	AuthAudience         string   `env:"AUTH_AUDIENCE" desc:"required token audience"`
+	AuthPublicMethods    []string `env:"AUTH_PUBLIC_METHODS" desc:"methods callable without a token, path.Match patterns allowed" default:"GetPost*,GetAllPosts,GetComment*,GetAllComments,WatchPostComments,GetCategor*,GetAllCategories,GetTag*,GetAllTags,GetFamousTags,GetRelatedTags,ListTagSynonyms,GetAllPostTags,GetPostsByTag,/grpc.reflection.*/*"`
 }
 
-// Load ...
-func Load() Config {
-	if err := godotenv.Load(".env"); err != nil {
-		fmt.Println("No .env file found", err)
+// setting describes one field of Config.
+type setting struct {
+	index  int
+	env    string
+	key    string
+	flag   string
+	def    string
+	desc   string
+	secret bool
+}
+
+// settings lists the fields of Config in declaration order.
+var settings = func() []setting {
+	t := reflect.TypeOf(Config{})
+	var list []setting
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		env := f.Tag.Get("env")
+		key := strings.ToLower(env)
+		list = append(list, setting{
+			index:  i,
+			env:    env,
+			key:    key,
+			flag:   strings.ReplaceAll(key, "_", "-"),
+			def:    f.Tag.Get("default"),
+			desc:   f.Tag.Get("desc"),
+			secret: f.Tag.Get("secret") == "true",
+		})
 	}
+	return list
+}()
 
-	config := Config{}
+// Load builds the configuration from, in increasing precedence, defaults, the
+// YAML file named by -config or CONFIG_FILE, the environment (including a
+// .env file) and the flags in args, which are registered on fs. The result
+// is validated.
+func Load(fs *flag.FlagSet, args []string) (Config, error) {
+	if err := godotenv.Load(".env"); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return Config{}, fmt.Errorf("reading .env: %w", err)
+	}
 
-	config.HTTPPort = cast.ToString(getOrReturnDefaultValue("HTTP_PORT", ":8080"))
+	path := fs.String("config", os.Getenv("CONFIG_FILE"), "YAML config file")
+	flags := make(map[string]string)
+	for _, s := range settings {
+		fs.Func(s.flag, fmt.Sprintf("%s (env %s)", s.desc, s.env), func(value string) error {
+			flags[s.env] = value
+			return nil
+		})
+	}
+	if err := fs.Parse(args); err != nil {
+		return Config{}, err
+	}
 
-	config.PostgresHost = cast.ToString(getOrReturnDefaultValue("POSTGRES_HOST", "postgres_dock"))
-	config.PostgresPort = cast.ToInt(getOrReturnDefaultValue("POSTGRES_PORT", 5432))
-	config.PostgresUser = cast.ToString(getOrReturnDefaultValue("POSTGRES_USER", "postgres"))
-	config.PostgresPassword = cast.ToString(getOrReturnDefaultValue("POSTGRES_PASSWORD", "root"))
-	config.PostgresDatabase = cast.ToString(getOrReturnDefaultValue("POSTGRES_DATABASE", "forum"))
+	var config Config
+	v := reflect.ValueOf(&config).Elem()
+	for _, s := range settings {
+		if err := set(v.Field(s.index), s.def); err != nil {
+			return Config{}, fmt.Errorf("default of %s: %w", s.key, err)
+		}
+	}
 
-	config.DefaultOffset = cast.ToString(getOrReturnDefaultValue("DEFAULT_OFFSET", "0"))
-	config.DefaultLimit = cast.ToString(getOrReturnDefaultValue("DEFAULT_LIMIT", "10"))
+	if *path != "" {
+		if err := loadFile(v, *path); err != nil {
+			return Config{}, err
+		}
+	}
 
-	return config
+	for _, s := range settings {
+		if value, ok := os.LookupEnv(s.env); ok {
+			if err := set(v.Field(s.index), value); err != nil {
+				return Config{}, fmt.Errorf("environment variable %s: %w", s.env, err)
+			}
+		}
+	}
+
+	for _, s := range settings {
+		if value, ok := flags[s.env]; ok {
+			if err := set(v.Field(s.index), value); err != nil {
+				return Config{}, fmt.Errorf("flag -%s: %w", s.flag, err)
+			}
+		}
+	}
+
+	if err := config.Validate(); err != nil {
+		return Config{}, err
+	}
+	return config, nil
 }
 
-func getOrReturnDefaultValue(key string, defaultValue interface{}) interface{} {
-	val, exists := os.LookupEnv(key)
+// loadFile applies the settings of a YAML file, rejecting unknown keys so a
+// typo does not silently leave a default in place.
+func loadFile(v reflect.Value, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading config file: %w", err)
+	}
+
+	var values map[string]any
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("parsing config file %s: %w", path, err)
+	}
+
+	for key, value := range values {
+		i := settingIndex(key)
+		if i < 0 {
+			return fmt.Errorf("config file %s: unknown setting %s", path, key)
+		}
+		if err := set(v.Field(settings[i].index), value); err != nil {
+			return fmt.Errorf("config file %s: %s: %w", path, key, err)
+		}
+	}
+	return nil
+}
+
+// settingIndex returns the position in settings of the one with the given file key, or -1.
+func settingIndex(key string) int {
+	for i, s := range settings {
+		if s.key == key {
+			return i
+		}
+	}
+	return -1
+}
 
-	if exists {
-		return val
+// set converts value, a string from the environment or a flag or anything a
+// YAML file holds, to the type of field and stores it.
+func set(field reflect.Value, value any) error {
+	var (
+		converted any
+		err       error
+	)
+	switch field.Interface().(type) {
+	case time.Duration:
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("invalid duration %v, use a unit such as 30s", value)
+		}
+		if converted, err = time.ParseDuration(s); err != nil {
+			return fmt.Errorf("invalid duration %q, use a unit such as 30s", s)
+		}
+	case string:
+		converted, err = cast.ToStringE(value)
+	case int:
+		if converted, err = cast.ToIntE(value); err != nil {
+			return fmt.Errorf("invalid integer %v", value)
+		}
+	case float64:
+		if converted, err = cast.ToFloat64E(value); err != nil {
+			return fmt.Errorf("invalid number %v", value)
+		}
+	case bool:
+		if converted, err = cast.ToBoolE(value); err != nil {
+			return fmt.Errorf("invalid boolean %v, use true or false", value)
+		}
+	case []string:
+		if s, ok := value.(string); ok {
+			converted = splitList(s)
+		} else {
+			converted, err = cast.ToStringSliceE(value)
+		}
+	default:
+		return fmt.Errorf("unsupported setting type %s", field.Type())
+	}
+	if err != nil {
+		return err
+	}
+	field.Set(reflect.ValueOf(converted))
+	return nil
+}
+
+// Redacted renders the configuration as a YAML config file, with secrets that
+// are set replaced by a placeholder.
+func (c Config) Redacted() string {
+	v := reflect.ValueOf(c)
+	doc := &yaml.Node{Kind: yaml.MappingNode}
+	for _, s := range settings {
+		value := v.Field(s.index).Interface()
+		switch {
+		case s.secret && value != "":
+			value = "REDACTED"
+		case reflect.TypeOf(value) == reflect.TypeOf(time.Duration(0)):
+			value = value.(time.Duration).String()
+		}
+
+		var node yaml.Node
+		if err := node.Encode(value); err != nil {
+			node = yaml.Node{Kind: yaml.ScalarNode, Value: fmt.Sprint(value)}
+		}
+		if node.Kind == yaml.SequenceNode {
+			node.Style = yaml.FlowStyle
+		}
+		doc.Content = append(doc.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: s.key}, &node)
 	}
 
-	return defaultValue
+	out, err := yaml.Marshal(doc)
+	if err != nil {
+		return err.Error()
+	}
+	return string(out)
+}
+
+// splitList splits a comma separated list, dropping empty entries.
+func splitList(s string) []string {
+	var list []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
 }
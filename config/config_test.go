@@ -0,0 +1,105 @@
+package config
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func load(args ...string) (Config, error) {
+	return Load(flag.NewFlagSet("test", flag.ContinueOnError), args)
+}
+
+func writeConfigFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Error writing config file: %v", err)
+	}
+	return path
+}
+
+func TestLoadDefaults(t *testing.T) {
+	cfg, err := load()
+	if err != nil {
+		t.Fatalf("Error loading config: %v", err)
+	}
+	assert.Equal(t, ":8082", cfg.ListenAddr)
+	assert.Equal(t, 5432, cfg.PostgresPort)
+	assert.Equal(t, 30*time.Second, cfg.ShutdownTimeout)
+	assert.Equal(t, []string{"localhost:9092"}, cfg.KafkaBrokers)
+	assert.Nil(t, cfg.FilterBannedWords)
+	assert.True(t, cfg.ReflectionEnabled)
+	assert.False(t, cfg.AuthDisabled)
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	path := writeConfigFile(t, `
+listen_addr: ":9000"
+postgres_host: db-from-file
+postgres_port: 6000
+kafka_brokers: [a:9092, b:9092]
+shutdown_timeout: 5s
+`)
+	t.Setenv("CONFIG_FILE", path)
+	t.Setenv("POSTGRES_HOST", "db-from-env")
+	t.Setenv("POSTGRES_PORT", "7000")
+
+	cfg, err := load("-postgres-port", "8000")
+	if err != nil {
+		t.Fatalf("Error loading config: %v", err)
+	}
+	assert.Equal(t, ":9000", cfg.ListenAddr)         // file over default
+	assert.Equal(t, "db-from-env", cfg.PostgresHost) // env over file
+	assert.Equal(t, 8000, cfg.PostgresPort)          // flag over env
+	assert.Equal(t, []string{"a:9092", "b:9092"}, cfg.KafkaBrokers)
+	assert.Equal(t, 5*time.Second, cfg.ShutdownTimeout)
+}
+
+func TestLoadErrors(t *testing.T) {
+	_, err := load("-config", writeConfigFile(t, "postgres_hots: db\n"))
+	assert.ErrorContains(t, err, "unknown setting postgres_hots")
+
+	_, err = load("-config", writeConfigFile(t, "shutdown_timeout: 30\n"))
+	assert.ErrorContains(t, err, "shutdown_timeout: invalid duration")
+
+	t.Setenv("POSTGRES_PORT", "five")
+	_, err = load()
+	assert.ErrorContains(t, err, "environment variable POSTGRES_PORT: invalid integer five")
+}
+
+func TestValidate(t *testing.T) {
+	_, err := load("-postgres-port", "0", "-default-page-size", "500", "-event-publisher", "queue", "-filter-caps-action", "shout")
+	if assert.Error(t, err) {
+		msg := err.Error()
+		assert.Contains(t, msg, "postgres_port: must be between 1 and 65535, got 0")
+		assert.Contains(t, msg, "default_page_size: must be between 1 and max_page_size (100), got 500")
+		assert.Contains(t, msg, `event_publisher: must be one of "kafka", "file", "memory", got "queue"`)
+		assert.Contains(t, msg, "filter_caps_action: must be one of")
+	}
+
+	// Settings of disabled features are not checked
+	_, err = load("-filter-caps-action", "", "-filter-caps-ratio", "2")
+	assert.NoError(t, err)
+}
+
+func TestRedacted(t *testing.T) {
+	cfg, err := load("-auth-hmac-secret", "s3cret", "-postgres-password", "hunter2")
+	if err != nil {
+		t.Fatalf("Error loading config: %v", err)
+	}
+	out := cfg.Redacted()
+	assert.NotContains(t, out, "s3cret")
+	assert.NotContains(t, out, "hunter2")
+	assert.Contains(t, out, "auth_hmac_secret: REDACTED")
+
+	// The output is a config file that loads back to the same settings
+	assert.True(t, strings.HasPrefix(out, "listen_addr:"))
+	reloaded, err := load("-config", writeConfigFile(t, out), "-auth-hmac-secret", "s3cret", "-postgres-password", "hunter2")
+	assert.NoError(t, err)
+	assert.Equal(t, cfg, reloaded)
+}
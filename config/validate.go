@@ -0,0 +1,124 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// filterActions are the actions a content filter can take, empty disabling it.
+var filterActions = []string{"", "reject", "mask", "flag"}
+
+// Validate reports every setting holding a value the service cannot run with,
+// naming each by its config file key.
+func (c Config) Validate() error {
+	var errs []error
+	invalid := func(key, format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+	}
+	positive := func(key string, value int) {
+		if value <= 0 {
+			invalid(key, "must be positive, got %d", value)
+		}
+	}
+	positiveDuration := func(key string, value time.Duration) {
+		if value <= 0 {
+			invalid(key, "must be positive, got %s", value)
+		}
+	}
+	oneOf := func(key, value string, allowed []string) {
+		if !slices.Contains(allowed, value) {
+			invalid(key, "must be one of %s, got %q", quoteAll(allowed), value)
+		}
+	}
+	nonEmpty := func(key, value string) {
+		if value == "" {
+			invalid(key, "must be set")
+		}
+	}
+
+	if _, _, err := net.SplitHostPort(c.ListenAddr); err != nil {
+		invalid("listen_addr", "must be host:port or :port, got %q", c.ListenAddr)
+	}
+
+	nonEmpty("postgres_host", c.PostgresHost)
+	if c.PostgresPort < 1 || c.PostgresPort > 65535 {
+		invalid("postgres_port", "must be between 1 and 65535, got %d", c.PostgresPort)
+	}
+	nonEmpty("postgres_user", c.PostgresUser)
+	nonEmpty("postgres_database", c.PostgresDatabase)
+	positive("postgres_max_conns", c.PostgresMaxConns)
+	if c.PostgresMinConns < 0 || c.PostgresMinConns > c.PostgresMaxConns {
+		invalid("postgres_min_conns", "must be between 0 and postgres_max_conns (%d), got %d", c.PostgresMaxConns, c.PostgresMinConns)
+	}
+	positiveDuration("postgres_max_conn_lifetime", c.PostgresMaxConnLifetime)
+	positiveDuration("postgres_max_conn_idle_time", c.PostgresMaxConnIdleTime)
+	positive("postgres_connect_attempts", c.PostgresConnectAttempts)
+	positiveDuration("postgres_connect_backoff", c.PostgresConnectBackoff)
+
+	positive("max_page_size", c.MaxPageSize)
+	if c.DefaultPageSize <= 0 || c.DefaultPageSize > c.MaxPageSize {
+		invalid("default_page_size", "must be between 1 and max_page_size (%d), got %d", c.MaxPageSize, c.DefaultPageSize)
+	}
+
+	positiveDuration("shutdown_timeout", c.ShutdownTimeout)
+
+	oneOf("event_publisher", c.EventPublisher, []string{"kafka", "file", "memory"})
+	switch c.EventPublisher {
+	case "kafka":
+		if len(c.KafkaBrokers) == 0 {
+			invalid("kafka_brokers", "must list at least one broker when event_publisher is kafka")
+		}
+		nonEmpty("kafka_topic", c.KafkaTopic)
+	case "file":
+		nonEmpty("event_file_path", c.EventFilePath)
+	}
+	positiveDuration("outbox_poll_interval", c.OutboxPollInterval)
+	positive("outbox_batch_size", c.OutboxBatchSize)
+
+	if c.CommentEditWindow < 0 {
+		invalid("comment_edit_window", "must not be negative, got %s", c.CommentEditWindow)
+	}
+
+	oneOf("filter_banned_words_action", c.FilterBannedWordsAction, filterActions)
+	oneOf("filter_links_action", c.FilterLinksAction, filterActions)
+	oneOf("filter_caps_action", c.FilterCapsAction, filterActions)
+	oneOf("filter_repeat_action", c.FilterRepeatAction, filterActions)
+	if c.FilterLinksAction != "" && c.FilterMaxLinks < 0 {
+		invalid("filter_max_links", "must not be negative, got %d", c.FilterMaxLinks)
+	}
+	if c.FilterCapsAction != "" {
+		if c.FilterCapsRatio <= 0 || c.FilterCapsRatio > 1 {
+			invalid("filter_caps_ratio", "must be above 0 and at most 1, got %g", c.FilterCapsRatio)
+		}
+		if c.FilterCapsMinLetters < 0 {
+			invalid("filter_caps_min_letters", "must not be negative, got %d", c.FilterCapsMinLetters)
+		}
+	}
+	if c.FilterRepeatAction != "" {
+		positiveDuration("filter_repeat_window", c.FilterRepeatWindow)
+	}
+
+	oneOf("rate_limit_store", c.RateLimitStore, []string{"memory", "postgres"})
+
+	if c.AuthRSAPublicKeyFile != "" {
+		if _, err := os.Stat(c.AuthRSAPublicKeyFile); err != nil {
+			invalid("auth_rsa_public_key_file", "%v", err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// quoteAll renders values as a comma separated list of quoted strings.
+func quoteAll(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = fmt.Sprintf("%q", v)
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,77 @@
+package events
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Aggregate types identify the entity an event belongs to.
+const (
+	AggregateCategory = "category"
+	AggregateTag      = "tag"
+	AggregatePost     = "post"
+	AggregateComment  = "comment"
+	AggregateReport   = "report"
+)
+
+// Event types emitted by the service layer.
+const (
+	CategoryCreated = "CategoryCreated"
+	CategoryUpdated = "CategoryUpdated"
+	CategoryDeleted = "CategoryDeleted"
+	CategoryMerged  = "CategoryMerged" // Emitted on the target, payload lists the merged sources
+
+	CategoryModeratorAdded   = "CategoryModeratorAdded"
+	CategoryModeratorRemoved = "CategoryModeratorRemoved"
+
+	TagCreated = "TagCreated"
+	TagUpdated = "TagUpdated"
+	TagDeleted = "TagDeleted"
+	TagMerged  = "TagMerged" // Emitted on the target, payload lists the merged sources
+
+	PostCreated = "PostCreated"
+	PostUpdated = "PostUpdated"
+	PostDeleted = "PostDeleted"
+
+	CommentCreated = "CommentCreated"
+	CommentUpdated = "CommentUpdated"
+	CommentDeleted = "CommentDeleted"
+
+	TagAttached = "TagAttached"
+	TagDetached = "TagDetached"
+
+	ContentReported = "ContentReported"
+	ReportResolved  = "ReportResolved"
+)
+
+// Event is a domain event as stored in the outbox and handed to a Publisher.
+type Event struct {
+	ID            string          `json:"id"`
+	Type          string          `json:"type"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   string          `json:"aggregate_id"`
+	Payload       json.RawMessage `json:"payload"` // protojson encoded message
+	CreatedAt     time.Time       `json:"created_at"`
+	Attempts      int             `json:"-"`
+}
+
+// New builds an event whose payload is the JSON encoding of msg.
+func New(eventType, aggregateType, aggregateID string, msg proto.Message) (*Event, error) {
+	payload, err := protojson.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Event{
+		ID:            uuid.New().String(),
+		Type:          eventType,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		Payload:       payload,
+		CreatedAt:     time.Now().UTC(),
+	}, nil
+}
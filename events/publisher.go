@@ -0,0 +1,130 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Publisher delivers events to whoever consumes them outside this service.
+// Publish may be called again for an event it already delivered, so consumers
+// must deduplicate on Event.ID.
+type Publisher interface {
+	Publish(ctx context.Context, e *Event) error
+	Close() error
+}
+
+// KafkaPublisher publishes events to a Kafka topic keyed by aggregate ID,
+// so all events of one post or comment land in the same partition in order.
+type KafkaPublisher struct {
+	writer *kafka.Writer
+}
+
+// NewKafkaPublisher creates a KafkaPublisher writing to topic on the given brokers.
+func NewKafkaPublisher(brokers []string, topic string) *KafkaPublisher {
+	return &KafkaPublisher{
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(brokers...),
+			Topic:        topic,
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireAll,
+		},
+	}
+}
+
+// Publish writes the event to Kafka and waits for the brokers to acknowledge it.
+func (p *KafkaPublisher) Publish(ctx context.Context, e *Event) error {
+	value, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	return p.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(e.AggregateID),
+		Value: value,
+		Headers: []kafka.Header{
+			{Key: "event_id", Value: []byte(e.ID)},
+			{Key: "event_type", Value: []byte(e.Type)},
+		},
+	})
+}
+
+// Close flushes and closes the underlying Kafka writer.
+func (p *KafkaPublisher) Close() error {
+	return p.writer.Close()
+}
+
+// FilePublisher appends events as JSON lines to a local file.
+// It is meant for development setups without a broker.
+type FilePublisher struct {
+	mu   sync.Mutex
+	file *os.File
+	enc  *json.Encoder
+}
+
+// NewFilePublisher opens (or creates) path for appending events.
+func NewFilePublisher(path string) (*FilePublisher, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &FilePublisher{file: file, enc: json.NewEncoder(file)}, nil
+}
+
+// Publish appends the event to the file.
+func (p *FilePublisher) Publish(_ context.Context, e *Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.enc.Encode(e)
+}
+
+// Close closes the file.
+func (p *FilePublisher) Close() error {
+	return p.file.Close()
+}
+
+// MemoryPublisher keeps published events in memory. It is meant for tests.
+type MemoryPublisher struct {
+	mu     sync.Mutex
+	events []*Event
+
+	// Fail, when set, is consulted before every publish; a non-nil result is
+	// returned instead of recording the event.
+	Fail func(e *Event) error
+}
+
+// NewMemoryPublisher creates an empty MemoryPublisher.
+func NewMemoryPublisher() *MemoryPublisher {
+	return &MemoryPublisher{}
+}
+
+// Publish records the event.
+func (p *MemoryPublisher) Publish(_ context.Context, e *Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.Fail != nil {
+		if err := p.Fail(e); err != nil {
+			return err
+		}
+	}
+	p.events = append(p.events, e)
+	return nil
+}
+
+// Events returns a copy of everything published so far.
+func (p *MemoryPublisher) Events() []*Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]*Event(nil), p.events...)
+}
+
+// Close is a no-op.
+func (p *MemoryPublisher) Close() error {
+	return nil
+}
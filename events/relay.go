@@ -0,0 +1,124 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Store is the outbox the relay drains.
+type Store interface {
+	// ClaimPending leases up to limit unpublished events that are due, so no
+	// other relay picks them up until the lease expires.
+	ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]*Event, error)
+	MarkPublished(ctx context.Context, id string) error
+	MarkFailed(ctx context.Context, id string, nextAttemptAt time.Time, lastErr string) error
+}
+
+// RelayConfig tunes how often and how aggressively the relay drains the outbox.
+type RelayConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	Lease        time.Duration
+	MinBackoff   time.Duration
+	MaxBackoff   time.Duration
+}
+
+// Relay moves events from the outbox to a Publisher. Delivery is at least
+// once: an event is only marked published after Publish succeeds, and a relay
+// that dies mid-batch leaves its events to be re-claimed once the lease expires.
+type Relay struct {
+	store     Store
+	publisher Publisher
+	cfg       RelayConfig
+}
+
+// NewRelay creates a Relay, filling in defaults for zero config values.
+func NewRelay(store Store, publisher Publisher, cfg RelayConfig) *Relay {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = time.Second
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+	if cfg.Lease <= 0 {
+		cfg.Lease = 30 * time.Second
+	}
+	if cfg.MinBackoff <= 0 {
+		cfg.MinBackoff = time.Second
+	}
+	if cfg.MaxBackoff < cfg.MinBackoff {
+		cfg.MaxBackoff = 5 * time.Minute
+	}
+	return &Relay{store: store, publisher: publisher, cfg: cfg}
+}
+
+// Run drains the outbox every poll interval until ctx is cancelled.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			n, err := r.Flush(ctx)
+			if err != nil {
+				log.Error().Err(err).Msg("Relay: Error draining outbox")
+			}
+			// Keep going while full batches come back, otherwise wait for the next tick.
+			if err != nil || n < r.cfg.BatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Flush publishes one batch of pending events and returns how many were claimed.
+// Once an event fails, the later events of its aggregate in the batch are left
+// unpublished until their lease expires, so consumers never see them before it.
+func (r *Relay) Flush(ctx context.Context) (int, error) {
+	pending, err := r.store.ClaimPending(ctx, r.cfg.BatchSize, r.cfg.Lease)
+	if err != nil {
+		return 0, err
+	}
+
+	blocked := map[[2]string]bool{}
+	for _, e := range pending {
+		aggregate := [2]string{e.AggregateType, e.AggregateID}
+		if blocked[aggregate] {
+			continue
+		}
+
+		if err := r.publisher.Publish(ctx, e); err != nil {
+			log.Error().Err(err).Str("event_id", e.ID).Str("event_type", e.Type).Msg("Relay: Error publishing event")
+			blocked[aggregate] = true
+			if err := r.store.MarkFailed(ctx, e.ID, time.Now().Add(r.backoff(e.Attempts)), err.Error()); err != nil {
+				return len(pending), err
+			}
+			continue
+		}
+
+		if err := r.store.MarkPublished(ctx, e.ID); err != nil {
+			return len(pending), err
+		}
+	}
+	return len(pending), nil
+}
+
+// backoff doubles the retry delay with every failed attempt, up to MaxBackoff.
+func (r *Relay) backoff(attempts int) time.Duration {
+	d := r.cfg.MinBackoff
+	for i := 0; i < attempts && d < r.cfg.MaxBackoff; i++ {
+		d *= 2
+	}
+	if d > r.cfg.MaxBackoff {
+		d = r.cfg.MaxBackoff
+	}
+	return d
+}
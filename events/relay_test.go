@@ -0,0 +1,141 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryStore is an in-memory Store used to exercise the relay without a database.
+type memoryStore struct {
+	mu        sync.Mutex
+	pending   []*Event
+	published []string
+	failed    map[string]int
+}
+
+func newMemoryStore(events ...*Event) *memoryStore {
+	return &memoryStore{pending: events, failed: map[string]int{}}
+}
+
+func (s *memoryStore) ClaimPending(_ context.Context, limit int, _ time.Duration) ([]*Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.pending) < limit {
+		limit = len(s.pending)
+	}
+	claimed := s.pending[:limit]
+	s.pending = s.pending[limit:]
+	return claimed, nil
+}
+
+func (s *memoryStore) MarkPublished(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.published = append(s.published, id)
+	return nil
+}
+
+func (s *memoryStore) MarkFailed(_ context.Context, id string, _ time.Time, _ string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failed[id]++
+	return nil
+}
+
+func testEvent(id string) *Event {
+	return &Event{ID: id, Type: PostCreated, AggregateType: AggregatePost, AggregateID: id, CreatedAt: time.Now()}
+}
+
+func TestRelayFlushPublishesAndMarks(t *testing.T) {
+	store := newMemoryStore(testEvent("a"), testEvent("b"))
+	publisher := NewMemoryPublisher()
+	relay := NewRelay(store, publisher, RelayConfig{BatchSize: 10})
+
+	n, err := relay.Flush(context.Background())
+	if err != nil {
+		t.Fatalf("Error flushing outbox: %v", err)
+	}
+
+	assert.Equal(t, 2, n)
+	assert.Len(t, publisher.Events(), 2)
+	assert.Equal(t, []string{"a", "b"}, store.published)
+}
+
+func TestRelayFlushSchedulesRetryOnFailure(t *testing.T) {
+	store := newMemoryStore(testEvent("a"), testEvent("b"))
+	publisher := NewMemoryPublisher()
+	publisher.Fail = func(e *Event) error {
+		if e.ID == "a" {
+			return errors.New("broker unavailable")
+		}
+		return nil
+	}
+	relay := NewRelay(store, publisher, RelayConfig{BatchSize: 10})
+
+	_, err := relay.Flush(context.Background())
+	if err != nil {
+		t.Fatalf("Error flushing outbox: %v", err)
+	}
+
+	assert.Equal(t, []string{"b"}, store.published)
+	assert.Equal(t, 1, store.failed["a"])
+}
+
+func TestRelayFlushKeepsAggregateOrderOnFailure(t *testing.T) {
+	created := testEvent("created")
+	updated := &Event{ID: "updated", Type: PostUpdated, AggregateType: AggregatePost, AggregateID: "created", CreatedAt: time.Now()}
+	other := testEvent("other")
+	store := newMemoryStore(created, updated, other)
+
+	publisher := NewMemoryPublisher()
+	failing := true
+	publisher.Fail = func(e *Event) error {
+		if e.ID == "created" && failing {
+			return errors.New("broker unavailable")
+		}
+		return nil
+	}
+	relay := NewRelay(store, publisher, RelayConfig{BatchSize: 10})
+
+	// The update waits for the failed create, other aggregates go ahead
+	_, err := relay.Flush(context.Background())
+	if err != nil {
+		t.Fatalf("Error flushing outbox: %v", err)
+	}
+	assert.Equal(t, []string{"other"}, store.published)
+	assert.Equal(t, 1, store.failed["created"])
+
+	// Once the retry succeeds both go out in the order they were written
+	failing = false
+	store.pending = []*Event{created, updated}
+	_, err = relay.Flush(context.Background())
+	if err != nil {
+		t.Fatalf("Error flushing outbox: %v", err)
+	}
+	assert.Equal(t, []string{"other", "created", "updated"}, store.published)
+
+	var order []string
+	for _, e := range publisher.Events() {
+		order = append(order, e.ID)
+	}
+	assert.Equal(t, []string{"other", "created", "updated"}, order)
+}
+
+func TestRelayBackoffIsCapped(t *testing.T) {
+	relay := NewRelay(newMemoryStore(), NewMemoryPublisher(), RelayConfig{
+		MinBackoff: time.Second,
+		MaxBackoff: 10 * time.Second,
+	})
+
+	assert.Equal(t, time.Second, relay.backoff(0))
+	assert.Equal(t, 4*time.Second, relay.backoff(2))
+	assert.Equal(t, 10*time.Second, relay.backoff(10))
+}
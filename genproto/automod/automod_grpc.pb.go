@@ -0,0 +1,304 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             v5.26.1
+// source: protos/automod.proto
+
+package automod
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	AutomodService_CreateAutomodRule_FullMethodName = "/forum.AutomodService/CreateAutomodRule"
+	AutomodService_GetAutomodRule_FullMethodName    = "/forum.AutomodService/GetAutomodRule"
+	AutomodService_UpdateAutomodRule_FullMethodName = "/forum.AutomodService/UpdateAutomodRule"
+	AutomodService_DeleteAutomodRule_FullMethodName = "/forum.AutomodService/DeleteAutomodRule"
+	AutomodService_ListAutomodRules_FullMethodName  = "/forum.AutomodService/ListAutomodRules"
+	AutomodService_DryRunAutomodRule_FullMethodName = "/forum.AutomodService/DryRunAutomodRule"
+)
+
+// AutomodServiceClient is the client API for AutomodService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AutomodServiceClient interface {
+	// Rule CRUD
+	CreateAutomodRule(ctx context.Context, in *CreateAutomodRuleRequest, opts ...grpc.CallOption) (*CreateAutomodRuleResponse, error)
+	GetAutomodRule(ctx context.Context, in *GetAutomodRuleRequest, opts ...grpc.CallOption) (*GetAutomodRuleResponse, error)
+	UpdateAutomodRule(ctx context.Context, in *UpdateAutomodRuleRequest, opts ...grpc.CallOption) (*UpdateAutomodRuleResponse, error)
+	DeleteAutomodRule(ctx context.Context, in *DeleteAutomodRuleRequest, opts ...grpc.CallOption) (*DeleteAutomodRuleResponse, error)
+	ListAutomodRules(ctx context.Context, in *ListAutomodRulesRequest, opts ...grpc.CallOption) (*ListAutomodRulesResponse, error)
+	// Test a rule before enabling it
+	DryRunAutomodRule(ctx context.Context, in *DryRunAutomodRuleRequest, opts ...grpc.CallOption) (*DryRunAutomodRuleResponse, error)
+}
+
+type automodServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAutomodServiceClient(cc grpc.ClientConnInterface) AutomodServiceClient {
+	return &automodServiceClient{cc}
+}
+
+func (c *automodServiceClient) CreateAutomodRule(ctx context.Context, in *CreateAutomodRuleRequest, opts ...grpc.CallOption) (*CreateAutomodRuleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateAutomodRuleResponse)
+	err := c.cc.Invoke(ctx, AutomodService_CreateAutomodRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *automodServiceClient) GetAutomodRule(ctx context.Context, in *GetAutomodRuleRequest, opts ...grpc.CallOption) (*GetAutomodRuleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAutomodRuleResponse)
+	err := c.cc.Invoke(ctx, AutomodService_GetAutomodRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *automodServiceClient) UpdateAutomodRule(ctx context.Context, in *UpdateAutomodRuleRequest, opts ...grpc.CallOption) (*UpdateAutomodRuleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateAutomodRuleResponse)
+	err := c.cc.Invoke(ctx, AutomodService_UpdateAutomodRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *automodServiceClient) DeleteAutomodRule(ctx context.Context, in *DeleteAutomodRuleRequest, opts ...grpc.CallOption) (*DeleteAutomodRuleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteAutomodRuleResponse)
+	err := c.cc.Invoke(ctx, AutomodService_DeleteAutomodRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *automodServiceClient) ListAutomodRules(ctx context.Context, in *ListAutomodRulesRequest, opts ...grpc.CallOption) (*ListAutomodRulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAutomodRulesResponse)
+	err := c.cc.Invoke(ctx, AutomodService_ListAutomodRules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *automodServiceClient) DryRunAutomodRule(ctx context.Context, in *DryRunAutomodRuleRequest, opts ...grpc.CallOption) (*DryRunAutomodRuleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DryRunAutomodRuleResponse)
+	err := c.cc.Invoke(ctx, AutomodService_DryRunAutomodRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AutomodServiceServer is the server API for AutomodService service.
+// All implementations must embed UnimplementedAutomodServiceServer
+// for forward compatibility
+type AutomodServiceServer interface {
+	// Rule CRUD
+	CreateAutomodRule(context.Context, *CreateAutomodRuleRequest) (*CreateAutomodRuleResponse, error)
+	GetAutomodRule(context.Context, *GetAutomodRuleRequest) (*GetAutomodRuleResponse, error)
+	UpdateAutomodRule(context.Context, *UpdateAutomodRuleRequest) (*UpdateAutomodRuleResponse, error)
+	DeleteAutomodRule(context.Context, *DeleteAutomodRuleRequest) (*DeleteAutomodRuleResponse, error)
+	ListAutomodRules(context.Context, *ListAutomodRulesRequest) (*ListAutomodRulesResponse, error)
+	// Test a rule before enabling it
+	DryRunAutomodRule(context.Context, *DryRunAutomodRuleRequest) (*DryRunAutomodRuleResponse, error)
+	mustEmbedUnimplementedAutomodServiceServer()
+}
+
+// UnimplementedAutomodServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedAutomodServiceServer struct {
+}
+
+func (UnimplementedAutomodServiceServer) CreateAutomodRule(context.Context, *CreateAutomodRuleRequest) (*CreateAutomodRuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAutomodRule not implemented")
+}
+func (UnimplementedAutomodServiceServer) GetAutomodRule(context.Context, *GetAutomodRuleRequest) (*GetAutomodRuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAutomodRule not implemented")
+}
+func (UnimplementedAutomodServiceServer) UpdateAutomodRule(context.Context, *UpdateAutomodRuleRequest) (*UpdateAutomodRuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateAutomodRule not implemented")
+}
+func (UnimplementedAutomodServiceServer) DeleteAutomodRule(context.Context, *DeleteAutomodRuleRequest) (*DeleteAutomodRuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAutomodRule not implemented")
+}
+func (UnimplementedAutomodServiceServer) ListAutomodRules(context.Context, *ListAutomodRulesRequest) (*ListAutomodRulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAutomodRules not implemented")
+}
+func (UnimplementedAutomodServiceServer) DryRunAutomodRule(context.Context, *DryRunAutomodRuleRequest) (*DryRunAutomodRuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DryRunAutomodRule not implemented")
+}
+func (UnimplementedAutomodServiceServer) mustEmbedUnimplementedAutomodServiceServer() {}
+
+// UnsafeAutomodServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AutomodServiceServer will
+// result in compilation errors.
+type UnsafeAutomodServiceServer interface {
+	mustEmbedUnimplementedAutomodServiceServer()
+}
+
+func RegisterAutomodServiceServer(s grpc.ServiceRegistrar, srv AutomodServiceServer) {
+	s.RegisterService(&AutomodService_ServiceDesc, srv)
+}
+
+func _AutomodService_CreateAutomodRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAutomodRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AutomodServiceServer).CreateAutomodRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AutomodService_CreateAutomodRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AutomodServiceServer).CreateAutomodRule(ctx, req.(*CreateAutomodRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AutomodService_GetAutomodRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAutomodRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AutomodServiceServer).GetAutomodRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AutomodService_GetAutomodRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AutomodServiceServer).GetAutomodRule(ctx, req.(*GetAutomodRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AutomodService_UpdateAutomodRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAutomodRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AutomodServiceServer).UpdateAutomodRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AutomodService_UpdateAutomodRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AutomodServiceServer).UpdateAutomodRule(ctx, req.(*UpdateAutomodRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AutomodService_DeleteAutomodRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAutomodRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AutomodServiceServer).DeleteAutomodRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AutomodService_DeleteAutomodRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AutomodServiceServer).DeleteAutomodRule(ctx, req.(*DeleteAutomodRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AutomodService_ListAutomodRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAutomodRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AutomodServiceServer).ListAutomodRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AutomodService_ListAutomodRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AutomodServiceServer).ListAutomodRules(ctx, req.(*ListAutomodRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AutomodService_DryRunAutomodRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DryRunAutomodRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AutomodServiceServer).DryRunAutomodRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AutomodService_DryRunAutomodRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AutomodServiceServer).DryRunAutomodRule(ctx, req.(*DryRunAutomodRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AutomodService_ServiceDesc is the grpc.ServiceDesc for AutomodService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AutomodService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "forum.AutomodService",
+	HandlerType: (*AutomodServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateAutomodRule",
+			Handler:    _AutomodService_CreateAutomodRule_Handler,
+		},
+		{
+			MethodName: "GetAutomodRule",
+			Handler:    _AutomodService_GetAutomodRule_Handler,
+		},
+		{
+			MethodName: "UpdateAutomodRule",
+			Handler:    _AutomodService_UpdateAutomodRule_Handler,
+		},
+		{
+			MethodName: "DeleteAutomodRule",
+			Handler:    _AutomodService_DeleteAutomodRule_Handler,
+		},
+		{
+			MethodName: "ListAutomodRules",
+			Handler:    _AutomodService_ListAutomodRules_Handler,
+		},
+		{
+			MethodName: "DryRunAutomodRule",
+			Handler:    _AutomodService_DryRunAutomodRule_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "protos/automod.proto",
+}
@@ -19,11 +19,21 @@ import (
 const _ = grpc.SupportPackageIsVersion8
 
 const (
-	CategoryService_CreateCategory_FullMethodName   = "/forum.CategoryService/CreateCategory"
-	CategoryService_GetCategory_FullMethodName      = "/forum.CategoryService/GetCategory"
-	CategoryService_UpdateCategory_FullMethodName   = "/forum.CategoryService/UpdateCategory"
-	CategoryService_DeleteCategory_FullMethodName   = "/forum.CategoryService/DeleteCategory"
-	CategoryService_GetAllCategories_FullMethodName = "/forum.CategoryService/GetAllCategories"
+	CategoryService_CreateCategory_FullMethodName          = "/forum.CategoryService/CreateCategory"
+	CategoryService_GetCategory_FullMethodName             = "/forum.CategoryService/GetCategory"
+	CategoryService_GetCategoryBySlug_FullMethodName       = "/forum.CategoryService/GetCategoryBySlug"
+	CategoryService_UpdateCategory_FullMethodName          = "/forum.CategoryService/UpdateCategory"
+	CategoryService_DeleteCategory_FullMethodName          = "/forum.CategoryService/DeleteCategory"
+	CategoryService_GetAllCategories_FullMethodName        = "/forum.CategoryService/GetAllCategories"
+	CategoryService_ReorderCategories_FullMethodName       = "/forum.CategoryService/ReorderCategories"
+	CategoryService_MergeCategories_FullMethodName         = "/forum.CategoryService/MergeCategories"
+	CategoryService_GetCategoryTree_FullMethodName         = "/forum.CategoryService/GetCategoryTree"
+	CategoryService_GetCategoryBreadcrumb_FullMethodName   = "/forum.CategoryService/GetCategoryBreadcrumb"
+	CategoryService_GetCategoryStats_FullMethodName        = "/forum.CategoryService/GetCategoryStats"
+	CategoryService_GetCategoriesStats_FullMethodName      = "/forum.CategoryService/GetCategoriesStats"
+	CategoryService_AddCategoryModerator_FullMethodName    = "/forum.CategoryService/AddCategoryModerator"
+	CategoryService_RemoveCategoryModerator_FullMethodName = "/forum.CategoryService/RemoveCategoryModerator"
+	CategoryService_ListCategoryModerators_FullMethodName  = "/forum.CategoryService/ListCategoryModerators"
 )
 
 // CategoryServiceClient is the client API for CategoryService service.
@@ -33,10 +43,23 @@ type CategoryServiceClient interface {
 	// Category CRUD
 	CreateCategory(ctx context.Context, in *CreateCategoryRequest, opts ...grpc.CallOption) (*CreateCategoryResponse, error)
 	GetCategory(ctx context.Context, in *GetCategoryRequest, opts ...grpc.CallOption) (*GetCategoryResponse, error)
+	GetCategoryBySlug(ctx context.Context, in *GetCategoryBySlugRequest, opts ...grpc.CallOption) (*GetCategoryResponse, error)
 	UpdateCategory(ctx context.Context, in *UpdateCategoryRequest, opts ...grpc.CallOption) (*UpdateCategoryResponse, error)
 	DeleteCategory(ctx context.Context, in *DeleteCategoryRequest, opts ...grpc.CallOption) (*DeleteCategoryResponse, error)
 	// Category GetAll
 	GetAllCategories(ctx context.Context, in *GetAllCategoriesRequest, opts ...grpc.CallOption) (*GetAllCategoriesResponse, error)
+	ReorderCategories(ctx context.Context, in *ReorderCategoriesRequest, opts ...grpc.CallOption) (*ReorderCategoriesResponse, error)
+	MergeCategories(ctx context.Context, in *MergeCategoriesRequest, opts ...grpc.CallOption) (*MergeCategoriesResponse, error)
+	// Category hierarchy
+	GetCategoryTree(ctx context.Context, in *GetCategoryTreeRequest, opts ...grpc.CallOption) (*GetCategoryTreeResponse, error)
+	GetCategoryBreadcrumb(ctx context.Context, in *GetCategoryBreadcrumbRequest, opts ...grpc.CallOption) (*GetCategoryBreadcrumbResponse, error)
+	// Category statistics
+	GetCategoryStats(ctx context.Context, in *GetCategoryStatsRequest, opts ...grpc.CallOption) (*GetCategoryStatsResponse, error)
+	GetCategoriesStats(ctx context.Context, in *GetCategoriesStatsRequest, opts ...grpc.CallOption) (*GetCategoriesStatsResponse, error)
+	// Category moderators, who also moderate every sub-category
+	AddCategoryModerator(ctx context.Context, in *AddCategoryModeratorRequest, opts ...grpc.CallOption) (*AddCategoryModeratorResponse, error)
+	RemoveCategoryModerator(ctx context.Context, in *RemoveCategoryModeratorRequest, opts ...grpc.CallOption) (*RemoveCategoryModeratorResponse, error)
+	ListCategoryModerators(ctx context.Context, in *ListCategoryModeratorsRequest, opts ...grpc.CallOption) (*ListCategoryModeratorsResponse, error)
 }
 
 type categoryServiceClient struct {
@@ -67,6 +90,16 @@ func (c *categoryServiceClient) GetCategory(ctx context.Context, in *GetCategory
 	return out, nil
 }
 
+func (c *categoryServiceClient) GetCategoryBySlug(ctx context.Context, in *GetCategoryBySlugRequest, opts ...grpc.CallOption) (*GetCategoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCategoryResponse)
+	err := c.cc.Invoke(ctx, CategoryService_GetCategoryBySlug_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *categoryServiceClient) UpdateCategory(ctx context.Context, in *UpdateCategoryRequest, opts ...grpc.CallOption) (*UpdateCategoryResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(UpdateCategoryResponse)
@@ -97,6 +130,96 @@ func (c *categoryServiceClient) GetAllCategories(ctx context.Context, in *GetAll
 	return out, nil
 }
 
+func (c *categoryServiceClient) ReorderCategories(ctx context.Context, in *ReorderCategoriesRequest, opts ...grpc.CallOption) (*ReorderCategoriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReorderCategoriesResponse)
+	err := c.cc.Invoke(ctx, CategoryService_ReorderCategories_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *categoryServiceClient) MergeCategories(ctx context.Context, in *MergeCategoriesRequest, opts ...grpc.CallOption) (*MergeCategoriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MergeCategoriesResponse)
+	err := c.cc.Invoke(ctx, CategoryService_MergeCategories_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *categoryServiceClient) GetCategoryTree(ctx context.Context, in *GetCategoryTreeRequest, opts ...grpc.CallOption) (*GetCategoryTreeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCategoryTreeResponse)
+	err := c.cc.Invoke(ctx, CategoryService_GetCategoryTree_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *categoryServiceClient) GetCategoryBreadcrumb(ctx context.Context, in *GetCategoryBreadcrumbRequest, opts ...grpc.CallOption) (*GetCategoryBreadcrumbResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCategoryBreadcrumbResponse)
+	err := c.cc.Invoke(ctx, CategoryService_GetCategoryBreadcrumb_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *categoryServiceClient) GetCategoryStats(ctx context.Context, in *GetCategoryStatsRequest, opts ...grpc.CallOption) (*GetCategoryStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCategoryStatsResponse)
+	err := c.cc.Invoke(ctx, CategoryService_GetCategoryStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *categoryServiceClient) GetCategoriesStats(ctx context.Context, in *GetCategoriesStatsRequest, opts ...grpc.CallOption) (*GetCategoriesStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCategoriesStatsResponse)
+	err := c.cc.Invoke(ctx, CategoryService_GetCategoriesStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *categoryServiceClient) AddCategoryModerator(ctx context.Context, in *AddCategoryModeratorRequest, opts ...grpc.CallOption) (*AddCategoryModeratorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddCategoryModeratorResponse)
+	err := c.cc.Invoke(ctx, CategoryService_AddCategoryModerator_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *categoryServiceClient) RemoveCategoryModerator(ctx context.Context, in *RemoveCategoryModeratorRequest, opts ...grpc.CallOption) (*RemoveCategoryModeratorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveCategoryModeratorResponse)
+	err := c.cc.Invoke(ctx, CategoryService_RemoveCategoryModerator_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *categoryServiceClient) ListCategoryModerators(ctx context.Context, in *ListCategoryModeratorsRequest, opts ...grpc.CallOption) (*ListCategoryModeratorsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCategoryModeratorsResponse)
+	err := c.cc.Invoke(ctx, CategoryService_ListCategoryModerators_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CategoryServiceServer is the server API for CategoryService service.
 // All implementations must embed UnimplementedCategoryServiceServer
 // for forward compatibility
@@ -104,10 +227,23 @@ type CategoryServiceServer interface {
 	// Category CRUD
 	CreateCategory(context.Context, *CreateCategoryRequest) (*CreateCategoryResponse, error)
 	GetCategory(context.Context, *GetCategoryRequest) (*GetCategoryResponse, error)
+	GetCategoryBySlug(context.Context, *GetCategoryBySlugRequest) (*GetCategoryResponse, error)
 	UpdateCategory(context.Context, *UpdateCategoryRequest) (*UpdateCategoryResponse, error)
 	DeleteCategory(context.Context, *DeleteCategoryRequest) (*DeleteCategoryResponse, error)
 	// Category GetAll
 	GetAllCategories(context.Context, *GetAllCategoriesRequest) (*GetAllCategoriesResponse, error)
+	ReorderCategories(context.Context, *ReorderCategoriesRequest) (*ReorderCategoriesResponse, error)
+	MergeCategories(context.Context, *MergeCategoriesRequest) (*MergeCategoriesResponse, error)
+	// Category hierarchy
+	GetCategoryTree(context.Context, *GetCategoryTreeRequest) (*GetCategoryTreeResponse, error)
+	GetCategoryBreadcrumb(context.Context, *GetCategoryBreadcrumbRequest) (*GetCategoryBreadcrumbResponse, error)
+	// Category statistics
+	GetCategoryStats(context.Context, *GetCategoryStatsRequest) (*GetCategoryStatsResponse, error)
+	GetCategoriesStats(context.Context, *GetCategoriesStatsRequest) (*GetCategoriesStatsResponse, error)
+	// Category moderators, who also moderate every sub-category
+	AddCategoryModerator(context.Context, *AddCategoryModeratorRequest) (*AddCategoryModeratorResponse, error)
+	RemoveCategoryModerator(context.Context, *RemoveCategoryModeratorRequest) (*RemoveCategoryModeratorResponse, error)
+	ListCategoryModerators(context.Context, *ListCategoryModeratorsRequest) (*ListCategoryModeratorsResponse, error)
 	mustEmbedUnimplementedCategoryServiceServer()
 }
 
@@ -121,6 +257,9 @@ func (UnimplementedCategoryServiceServer) CreateCategory(context.Context, *Creat
 func (UnimplementedCategoryServiceServer) GetCategory(context.Context, *GetCategoryRequest) (*GetCategoryResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetCategory not implemented")
 }
+func (UnimplementedCategoryServiceServer) GetCategoryBySlug(context.Context, *GetCategoryBySlugRequest) (*GetCategoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCategoryBySlug not implemented")
+}
 func (UnimplementedCategoryServiceServer) UpdateCategory(context.Context, *UpdateCategoryRequest) (*UpdateCategoryResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateCategory not implemented")
 }
@@ -130,6 +269,33 @@ func (UnimplementedCategoryServiceServer) DeleteCategory(context.Context, *Delet
 func (UnimplementedCategoryServiceServer) GetAllCategories(context.Context, *GetAllCategoriesRequest) (*GetAllCategoriesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAllCategories not implemented")
 }
+func (UnimplementedCategoryServiceServer) ReorderCategories(context.Context, *ReorderCategoriesRequest) (*ReorderCategoriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReorderCategories not implemented")
+}
+func (UnimplementedCategoryServiceServer) MergeCategories(context.Context, *MergeCategoriesRequest) (*MergeCategoriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeCategories not implemented")
+}
+func (UnimplementedCategoryServiceServer) GetCategoryTree(context.Context, *GetCategoryTreeRequest) (*GetCategoryTreeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCategoryTree not implemented")
+}
+func (UnimplementedCategoryServiceServer) GetCategoryBreadcrumb(context.Context, *GetCategoryBreadcrumbRequest) (*GetCategoryBreadcrumbResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCategoryBreadcrumb not implemented")
+}
+func (UnimplementedCategoryServiceServer) GetCategoryStats(context.Context, *GetCategoryStatsRequest) (*GetCategoryStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCategoryStats not implemented")
+}
+func (UnimplementedCategoryServiceServer) GetCategoriesStats(context.Context, *GetCategoriesStatsRequest) (*GetCategoriesStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCategoriesStats not implemented")
+}
+func (UnimplementedCategoryServiceServer) AddCategoryModerator(context.Context, *AddCategoryModeratorRequest) (*AddCategoryModeratorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddCategoryModerator not implemented")
+}
+func (UnimplementedCategoryServiceServer) RemoveCategoryModerator(context.Context, *RemoveCategoryModeratorRequest) (*RemoveCategoryModeratorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveCategoryModerator not implemented")
+}
+func (UnimplementedCategoryServiceServer) ListCategoryModerators(context.Context, *ListCategoryModeratorsRequest) (*ListCategoryModeratorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCategoryModerators not implemented")
+}
 func (UnimplementedCategoryServiceServer) mustEmbedUnimplementedCategoryServiceServer() {}
 
 // UnsafeCategoryServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -179,6 +345,24 @@ func _CategoryService_GetCategory_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CategoryService_GetCategoryBySlug_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCategoryBySlugRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CategoryServiceServer).GetCategoryBySlug(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CategoryService_GetCategoryBySlug_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CategoryServiceServer).GetCategoryBySlug(ctx, req.(*GetCategoryBySlugRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CategoryService_UpdateCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateCategoryRequest)
 	if err := dec(in); err != nil {
@@ -233,6 +417,168 @@ func _CategoryService_GetAllCategories_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CategoryService_ReorderCategories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReorderCategoriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CategoryServiceServer).ReorderCategories(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CategoryService_ReorderCategories_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CategoryServiceServer).ReorderCategories(ctx, req.(*ReorderCategoriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CategoryService_MergeCategories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeCategoriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CategoryServiceServer).MergeCategories(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CategoryService_MergeCategories_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CategoryServiceServer).MergeCategories(ctx, req.(*MergeCategoriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CategoryService_GetCategoryTree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCategoryTreeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CategoryServiceServer).GetCategoryTree(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CategoryService_GetCategoryTree_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CategoryServiceServer).GetCategoryTree(ctx, req.(*GetCategoryTreeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CategoryService_GetCategoryBreadcrumb_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCategoryBreadcrumbRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CategoryServiceServer).GetCategoryBreadcrumb(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CategoryService_GetCategoryBreadcrumb_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CategoryServiceServer).GetCategoryBreadcrumb(ctx, req.(*GetCategoryBreadcrumbRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CategoryService_GetCategoryStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCategoryStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CategoryServiceServer).GetCategoryStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CategoryService_GetCategoryStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CategoryServiceServer).GetCategoryStats(ctx, req.(*GetCategoryStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CategoryService_GetCategoriesStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCategoriesStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CategoryServiceServer).GetCategoriesStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CategoryService_GetCategoriesStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CategoryServiceServer).GetCategoriesStats(ctx, req.(*GetCategoriesStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CategoryService_AddCategoryModerator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddCategoryModeratorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CategoryServiceServer).AddCategoryModerator(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CategoryService_AddCategoryModerator_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CategoryServiceServer).AddCategoryModerator(ctx, req.(*AddCategoryModeratorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CategoryService_RemoveCategoryModerator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveCategoryModeratorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CategoryServiceServer).RemoveCategoryModerator(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CategoryService_RemoveCategoryModerator_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CategoryServiceServer).RemoveCategoryModerator(ctx, req.(*RemoveCategoryModeratorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CategoryService_ListCategoryModerators_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCategoryModeratorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CategoryServiceServer).ListCategoryModerators(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CategoryService_ListCategoryModerators_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CategoryServiceServer).ListCategoryModerators(ctx, req.(*ListCategoryModeratorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CategoryService_ServiceDesc is the grpc.ServiceDesc for CategoryService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -248,6 +594,10 @@ var CategoryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetCategory",
 			Handler:    _CategoryService_GetCategory_Handler,
 		},
+		{
+			MethodName: "GetCategoryBySlug",
+			Handler:    _CategoryService_GetCategoryBySlug_Handler,
+		},
 		{
 			MethodName: "UpdateCategory",
 			Handler:    _CategoryService_UpdateCategory_Handler,
@@ -260,6 +610,42 @@ var CategoryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAllCategories",
 			Handler:    _CategoryService_GetAllCategories_Handler,
 		},
+		{
+			MethodName: "ReorderCategories",
+			Handler:    _CategoryService_ReorderCategories_Handler,
+		},
+		{
+			MethodName: "MergeCategories",
+			Handler:    _CategoryService_MergeCategories_Handler,
+		},
+		{
+			MethodName: "GetCategoryTree",
+			Handler:    _CategoryService_GetCategoryTree_Handler,
+		},
+		{
+			MethodName: "GetCategoryBreadcrumb",
+			Handler:    _CategoryService_GetCategoryBreadcrumb_Handler,
+		},
+		{
+			MethodName: "GetCategoryStats",
+			Handler:    _CategoryService_GetCategoryStats_Handler,
+		},
+		{
+			MethodName: "GetCategoriesStats",
+			Handler:    _CategoryService_GetCategoriesStats_Handler,
+		},
+		{
+			MethodName: "AddCategoryModerator",
+			Handler:    _CategoryService_AddCategoryModerator_Handler,
+		},
+		{
+			MethodName: "RemoveCategoryModerator",
+			Handler:    _CategoryService_RemoveCategoryModerator_Handler,
+		},
+		{
+			MethodName: "ListCategoryModerators",
+			Handler:    _CategoryService_ListCategoryModerators_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "protos/category.proto",
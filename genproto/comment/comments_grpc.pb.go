@@ -19,11 +19,13 @@ import (
 const _ = grpc.SupportPackageIsVersion8
 
 const (
-	CommentService_CreateComment_FullMethodName  = "/forum.CommentService/CreateComment"
-	CommentService_GetComment_FullMethodName     = "/forum.CommentService/GetComment"
-	CommentService_UpdateComment_FullMethodName  = "/forum.CommentService/UpdateComment"
-	CommentService_DeleteComment_FullMethodName  = "/forum.CommentService/DeleteComment"
-	CommentService_GetAllComments_FullMethodName = "/forum.CommentService/GetAllComments"
+	CommentService_CreateComment_FullMethodName       = "/forum.CommentService/CreateComment"
+	CommentService_GetComment_FullMethodName          = "/forum.CommentService/GetComment"
+	CommentService_UpdateComment_FullMethodName       = "/forum.CommentService/UpdateComment"
+	CommentService_DeleteComment_FullMethodName       = "/forum.CommentService/DeleteComment"
+	CommentService_GetAllComments_FullMethodName      = "/forum.CommentService/GetAllComments"
+	CommentService_GetCommentRevisions_FullMethodName = "/forum.CommentService/GetCommentRevisions"
+	CommentService_WatchPostComments_FullMethodName   = "/forum.CommentService/WatchPostComments"
 )
 
 // CommentServiceClient is the client API for CommentService service.
@@ -37,6 +39,10 @@ type CommentServiceClient interface {
 	DeleteComment(ctx context.Context, in *DeleteCommentRequest, opts ...grpc.CallOption) (*DeleteCommentResponse, error)
 	// Comment GetAll
 	GetAllComments(ctx context.Context, in *GetAllCommentsRequest, opts ...grpc.CallOption) (*GetAllCommentsResponse, error)
+	// Comment edit history
+	GetCommentRevisions(ctx context.Context, in *GetCommentRevisionsRequest, opts ...grpc.CallOption) (*GetCommentRevisionsResponse, error)
+	// Replays changes since a cursor, then streams live changes for a post
+	WatchPostComments(ctx context.Context, in *WatchPostCommentsRequest, opts ...grpc.CallOption) (CommentService_WatchPostCommentsClient, error)
 }
 
 type commentServiceClient struct {
@@ -97,6 +103,49 @@ func (c *commentServiceClient) GetAllComments(ctx context.Context, in *GetAllCom
 	return out, nil
 }
 
+func (c *commentServiceClient) GetCommentRevisions(ctx context.Context, in *GetCommentRevisionsRequest, opts ...grpc.CallOption) (*GetCommentRevisionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCommentRevisionsResponse)
+	err := c.cc.Invoke(ctx, CommentService_GetCommentRevisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentServiceClient) WatchPostComments(ctx context.Context, in *WatchPostCommentsRequest, opts ...grpc.CallOption) (CommentService_WatchPostCommentsClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CommentService_ServiceDesc.Streams[0], CommentService_WatchPostComments_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &commentServiceWatchPostCommentsClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CommentService_WatchPostCommentsClient interface {
+	Recv() (*CommentEvent, error)
+	grpc.ClientStream
+}
+
+type commentServiceWatchPostCommentsClient struct {
+	grpc.ClientStream
+}
+
+func (x *commentServiceWatchPostCommentsClient) Recv() (*CommentEvent, error) {
+	m := new(CommentEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // CommentServiceServer is the server API for CommentService service.
 // All implementations must embed UnimplementedCommentServiceServer
 // for forward compatibility
@@ -108,6 +157,10 @@ type CommentServiceServer interface {
 	DeleteComment(context.Context, *DeleteCommentRequest) (*DeleteCommentResponse, error)
 	// Comment GetAll
 	GetAllComments(context.Context, *GetAllCommentsRequest) (*GetAllCommentsResponse, error)
+	// Comment edit history
+	GetCommentRevisions(context.Context, *GetCommentRevisionsRequest) (*GetCommentRevisionsResponse, error)
+	// Replays changes since a cursor, then streams live changes for a post
+	WatchPostComments(*WatchPostCommentsRequest, CommentService_WatchPostCommentsServer) error
 	mustEmbedUnimplementedCommentServiceServer()
 }
 
@@ -130,6 +183,12 @@ func (UnimplementedCommentServiceServer) DeleteComment(context.Context, *DeleteC
 func (UnimplementedCommentServiceServer) GetAllComments(context.Context, *GetAllCommentsRequest) (*GetAllCommentsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAllComments not implemented")
 }
+func (UnimplementedCommentServiceServer) GetCommentRevisions(context.Context, *GetCommentRevisionsRequest) (*GetCommentRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCommentRevisions not implemented")
+}
+func (UnimplementedCommentServiceServer) WatchPostComments(*WatchPostCommentsRequest, CommentService_WatchPostCommentsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchPostComments not implemented")
+}
 func (UnimplementedCommentServiceServer) mustEmbedUnimplementedCommentServiceServer() {}
 
 // UnsafeCommentServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -233,6 +292,45 @@ func _CommentService_GetAllComments_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CommentService_GetCommentRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCommentRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServiceServer).GetCommentRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommentService_GetCommentRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServiceServer).GetCommentRevisions(ctx, req.(*GetCommentRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommentService_WatchPostComments_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchPostCommentsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CommentServiceServer).WatchPostComments(m, &commentServiceWatchPostCommentsServer{ServerStream: stream})
+}
+
+type CommentService_WatchPostCommentsServer interface {
+	Send(*CommentEvent) error
+	grpc.ServerStream
+}
+
+type commentServiceWatchPostCommentsServer struct {
+	grpc.ServerStream
+}
+
+func (x *commentServiceWatchPostCommentsServer) Send(m *CommentEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // CommentService_ServiceDesc is the grpc.ServiceDesc for CommentService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -260,7 +358,17 @@ var CommentService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAllComments",
 			Handler:    _CommentService_GetAllComments_Handler,
 		},
+		{
+			MethodName: "GetCommentRevisions",
+			Handler:    _CommentService_GetCommentRevisions_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchPostComments",
+			Handler:       _CommentService_WatchPostComments_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "protos/comments.proto",
 }
@@ -19,11 +19,17 @@ import (
 const _ = grpc.SupportPackageIsVersion8
 
 const (
-	PostService_CreatePost_FullMethodName  = "/forum.PostService/CreatePost"
-	PostService_GetPost_FullMethodName     = "/forum.PostService/GetPost"
-	PostService_UpdatePost_FullMethodName  = "/forum.PostService/UpdatePost"
-	PostService_DeletePost_FullMethodName  = "/forum.PostService/DeletePost"
-	PostService_GetAllPosts_FullMethodName = "/forum.PostService/GetAllPosts"
+	PostService_CreatePost_FullMethodName          = "/forum.PostService/CreatePost"
+	PostService_GetPost_FullMethodName             = "/forum.PostService/GetPost"
+	PostService_UpdatePost_FullMethodName          = "/forum.PostService/UpdatePost"
+	PostService_DeletePost_FullMethodName          = "/forum.PostService/DeletePost"
+	PostService_GetAllPosts_FullMethodName         = "/forum.PostService/GetAllPosts"
+	PostService_GetPostRevisions_FullMethodName    = "/forum.PostService/GetPostRevisions"
+	PostService_GetPostRevisionDiff_FullMethodName = "/forum.PostService/GetPostRevisionDiff"
+	PostService_RevertPost_FullMethodName          = "/forum.PostService/RevertPost"
+	PostService_PinPost_FullMethodName             = "/forum.PostService/PinPost"
+	PostService_LockPost_FullMethodName            = "/forum.PostService/LockPost"
+	PostService_ClosePost_FullMethodName           = "/forum.PostService/ClosePost"
 )
 
 // PostServiceClient is the client API for PostService service.
@@ -37,6 +43,14 @@ type PostServiceClient interface {
 	DeletePost(ctx context.Context, in *DeletePostRequest, opts ...grpc.CallOption) (*DeletePostResponse, error)
 	// Post GetAll
 	GetAllPosts(ctx context.Context, in *GetAllPostsRequest, opts ...grpc.CallOption) (*GetAllPostsResponse, error)
+	// Post revision history
+	GetPostRevisions(ctx context.Context, in *GetPostRevisionsRequest, opts ...grpc.CallOption) (*GetPostRevisionsResponse, error)
+	GetPostRevisionDiff(ctx context.Context, in *GetPostRevisionDiffRequest, opts ...grpc.CallOption) (*GetPostRevisionDiffResponse, error)
+	RevertPost(ctx context.Context, in *RevertPostRequest, opts ...grpc.CallOption) (*RevertPostResponse, error)
+	// Post moderation status
+	PinPost(ctx context.Context, in *PinPostRequest, opts ...grpc.CallOption) (*ModeratePostResponse, error)
+	LockPost(ctx context.Context, in *LockPostRequest, opts ...grpc.CallOption) (*ModeratePostResponse, error)
+	ClosePost(ctx context.Context, in *ClosePostRequest, opts ...grpc.CallOption) (*ModeratePostResponse, error)
 }
 
 type postServiceClient struct {
@@ -97,6 +111,66 @@ func (c *postServiceClient) GetAllPosts(ctx context.Context, in *GetAllPostsRequ
 	return out, nil
 }
 
+func (c *postServiceClient) GetPostRevisions(ctx context.Context, in *GetPostRevisionsRequest, opts ...grpc.CallOption) (*GetPostRevisionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPostRevisionsResponse)
+	err := c.cc.Invoke(ctx, PostService_GetPostRevisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) GetPostRevisionDiff(ctx context.Context, in *GetPostRevisionDiffRequest, opts ...grpc.CallOption) (*GetPostRevisionDiffResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPostRevisionDiffResponse)
+	err := c.cc.Invoke(ctx, PostService_GetPostRevisionDiff_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) RevertPost(ctx context.Context, in *RevertPostRequest, opts ...grpc.CallOption) (*RevertPostResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevertPostResponse)
+	err := c.cc.Invoke(ctx, PostService_RevertPost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) PinPost(ctx context.Context, in *PinPostRequest, opts ...grpc.CallOption) (*ModeratePostResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ModeratePostResponse)
+	err := c.cc.Invoke(ctx, PostService_PinPost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) LockPost(ctx context.Context, in *LockPostRequest, opts ...grpc.CallOption) (*ModeratePostResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ModeratePostResponse)
+	err := c.cc.Invoke(ctx, PostService_LockPost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) ClosePost(ctx context.Context, in *ClosePostRequest, opts ...grpc.CallOption) (*ModeratePostResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ModeratePostResponse)
+	err := c.cc.Invoke(ctx, PostService_ClosePost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PostServiceServer is the server API for PostService service.
 // All implementations must embed UnimplementedPostServiceServer
 // for forward compatibility
@@ -108,6 +182,14 @@ type PostServiceServer interface {
 	DeletePost(context.Context, *DeletePostRequest) (*DeletePostResponse, error)
 	// Post GetAll
 	GetAllPosts(context.Context, *GetAllPostsRequest) (*GetAllPostsResponse, error)
+	// Post revision history
+	GetPostRevisions(context.Context, *GetPostRevisionsRequest) (*GetPostRevisionsResponse, error)
+	GetPostRevisionDiff(context.Context, *GetPostRevisionDiffRequest) (*GetPostRevisionDiffResponse, error)
+	RevertPost(context.Context, *RevertPostRequest) (*RevertPostResponse, error)
+	// Post moderation status
+	PinPost(context.Context, *PinPostRequest) (*ModeratePostResponse, error)
+	LockPost(context.Context, *LockPostRequest) (*ModeratePostResponse, error)
+	ClosePost(context.Context, *ClosePostRequest) (*ModeratePostResponse, error)
 	mustEmbedUnimplementedPostServiceServer()
 }
 
@@ -130,6 +212,24 @@ func (UnimplementedPostServiceServer) DeletePost(context.Context, *DeletePostReq
 func (UnimplementedPostServiceServer) GetAllPosts(context.Context, *GetAllPostsRequest) (*GetAllPostsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAllPosts not implemented")
 }
+func (UnimplementedPostServiceServer) GetPostRevisions(context.Context, *GetPostRevisionsRequest) (*GetPostRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPostRevisions not implemented")
+}
+func (UnimplementedPostServiceServer) GetPostRevisionDiff(context.Context, *GetPostRevisionDiffRequest) (*GetPostRevisionDiffResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPostRevisionDiff not implemented")
+}
+func (UnimplementedPostServiceServer) RevertPost(context.Context, *RevertPostRequest) (*RevertPostResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevertPost not implemented")
+}
+func (UnimplementedPostServiceServer) PinPost(context.Context, *PinPostRequest) (*ModeratePostResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PinPost not implemented")
+}
+func (UnimplementedPostServiceServer) LockPost(context.Context, *LockPostRequest) (*ModeratePostResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LockPost not implemented")
+}
+func (UnimplementedPostServiceServer) ClosePost(context.Context, *ClosePostRequest) (*ModeratePostResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClosePost not implemented")
+}
 func (UnimplementedPostServiceServer) mustEmbedUnimplementedPostServiceServer() {}
 
 // UnsafePostServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -233,6 +333,114 @@ func _PostService_GetAllPosts_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PostService_GetPostRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPostRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).GetPostRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_GetPostRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).GetPostRevisions(ctx, req.(*GetPostRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_GetPostRevisionDiff_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPostRevisionDiffRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).GetPostRevisionDiff(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_GetPostRevisionDiff_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).GetPostRevisionDiff(ctx, req.(*GetPostRevisionDiffRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_RevertPost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevertPostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).RevertPost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_RevertPost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).RevertPost(ctx, req.(*RevertPostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_PinPost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PinPostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).PinPost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_PinPost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).PinPost(ctx, req.(*PinPostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_LockPost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LockPostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).LockPost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_LockPost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).LockPost(ctx, req.(*LockPostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_ClosePost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClosePostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).ClosePost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_ClosePost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).ClosePost(ctx, req.(*ClosePostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PostService_ServiceDesc is the grpc.ServiceDesc for PostService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -260,6 +468,30 @@ var PostService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAllPosts",
 			Handler:    _PostService_GetAllPosts_Handler,
 		},
+		{
+			MethodName: "GetPostRevisions",
+			Handler:    _PostService_GetPostRevisions_Handler,
+		},
+		{
+			MethodName: "GetPostRevisionDiff",
+			Handler:    _PostService_GetPostRevisionDiff_Handler,
+		},
+		{
+			MethodName: "RevertPost",
+			Handler:    _PostService_RevertPost_Handler,
+		},
+		{
+			MethodName: "PinPost",
+			Handler:    _PostService_PinPost_Handler,
+		},
+		{
+			MethodName: "LockPost",
+			Handler:    _PostService_LockPost_Handler,
+		},
+		{
+			MethodName: "ClosePost",
+			Handler:    _PostService_ClosePost_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "protos/posts.proto",
@@ -26,11 +26,16 @@ type Tag struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id        string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // UUID
-	Name      string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	CreatedAt string `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt string `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	DeletedAt string `protobuf:"bytes,5,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	Id          string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // UUID
+	Name        string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedAt   string `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt   string `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	DeletedAt   string `protobuf:"bytes,5,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	Version     int32  `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`        // Incremented on every update, used for optimistic concurrency
+	Description string `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"` // Short summary shown next to the tag
+	Guidance    string `protobuf:"bytes,8,opt,name=guidance,proto3" json:"guidance,omitempty"`       // Longer explanation of when to use the tag
+	Color       string `protobuf:"bytes,9,opt,name=color,proto3" json:"color,omitempty"`             // Hex colour such as #1e90ff
+	Restricted  bool   `protobuf:"varint,10,opt,name=restricted,proto3" json:"restricted,omitempty"` // Only moderators may apply restricted tags to posts
 }
 
 func (x *Tag) Reset() {
@@ -100,13 +105,52 @@ func (x *Tag) GetDeletedAt() string {
 	return ""
 }
 
+func (x *Tag) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Tag) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Tag) GetGuidance() string {
+	if x != nil {
+		return x.Guidance
+	}
+	return ""
+}
+
+func (x *Tag) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+func (x *Tag) GetRestricted() bool {
+	if x != nil {
+		return x.Restricted
+	}
+	return false
+}
+
 // Request for creating a new tag
 type CreateTagRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Name        string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Guidance    string `protobuf:"bytes,3,opt,name=guidance,proto3" json:"guidance,omitempty"`
+	Color       string `protobuf:"bytes,4,opt,name=color,proto3" json:"color,omitempty"`
+	Restricted  bool   `protobuf:"varint,5,opt,name=restricted,proto3" json:"restricted,omitempty"`
 }
 
 func (x *CreateTagRequest) Reset() {
@@ -148,6 +192,34 @@ func (x *CreateTagRequest) GetName() string {
 	return ""
 }
 
+func (x *CreateTagRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateTagRequest) GetGuidance() string {
+	if x != nil {
+		return x.Guidance
+	}
+	return ""
+}
+
+func (x *CreateTagRequest) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+func (x *CreateTagRequest) GetRestricted() bool {
+	if x != nil {
+		return x.Restricted
+	}
+	return false
+}
+
 // Response after creating a new tag
 type CreateTagResponse struct {
 	state         protoimpl.MessageState
@@ -250,7 +322,9 @@ type GetTagResponse struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Tag *Tag `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	Tag          *Tag   `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	ResolvedFrom string `protobuf:"bytes,2,opt,name=resolved_from,json=resolvedFrom,proto3" json:"resolved_from,omitempty"` // Set when the request named a synonym or merged tag, holds that name or ID
+	UsageCount   int32  `protobuf:"varint,3,opt,name=usage_count,json=usageCount,proto3" json:"usage_count,omitempty"`      // Live posts carrying the tag
 }
 
 func (x *GetTagResponse) Reset() {
@@ -292,20 +366,87 @@ func (x *GetTagResponse) GetTag() *Tag {
 	return nil
 }
 
+func (x *GetTagResponse) GetResolvedFrom() string {
+	if x != nil {
+		return x.ResolvedFrom
+	}
+	return ""
+}
+
+func (x *GetTagResponse) GetUsageCount() int32 {
+	if x != nil {
+		return x.UsageCount
+	}
+	return 0
+}
+
+// Request for retrieving a tag by its name or one of its synonyms
+type GetTagByNameRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // Matched case-insensitively
+}
+
+func (x *GetTagByNameRequest) Reset() {
+	*x = GetTagByNameRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_tag_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTagByNameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTagByNameRequest) ProtoMessage() {}
+
+func (x *GetTagByNameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_tag_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTagByNameRequest.ProtoReflect.Descriptor instead.
+func (*GetTagByNameRequest) Descriptor() ([]byte, []int) {
+	return file_protos_tag_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetTagByNameRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
 // Request for updating an existing tag
 type UpdateTagRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id   string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Id              string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name            string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ExpectedVersion int32  `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"` // Optional, the update is rejected unless the tag is still at this version
+	Description     string `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Guidance        string `protobuf:"bytes,5,opt,name=guidance,proto3" json:"guidance,omitempty"`
+	Color           string `protobuf:"bytes,6,opt,name=color,proto3" json:"color,omitempty"`
+	Restricted      *bool  `protobuf:"varint,7,opt,name=restricted,proto3,oneof" json:"restricted,omitempty"` // Left unchanged when unset
 }
 
 func (x *UpdateTagRequest) Reset() {
 	*x = UpdateTagRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_tag_proto_msgTypes[5]
+		mi := &file_protos_tag_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -318,7 +459,7 @@ func (x *UpdateTagRequest) String() string {
 func (*UpdateTagRequest) ProtoMessage() {}
 
 func (x *UpdateTagRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_tag_proto_msgTypes[5]
+	mi := &file_protos_tag_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -331,7 +472,7 @@ func (x *UpdateTagRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateTagRequest.ProtoReflect.Descriptor instead.
 func (*UpdateTagRequest) Descriptor() ([]byte, []int) {
-	return file_protos_tag_proto_rawDescGZIP(), []int{5}
+	return file_protos_tag_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *UpdateTagRequest) GetId() string {
@@ -348,6 +489,41 @@ func (x *UpdateTagRequest) GetName() string {
 	return ""
 }
 
+func (x *UpdateTagRequest) GetExpectedVersion() int32 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *UpdateTagRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdateTagRequest) GetGuidance() string {
+	if x != nil {
+		return x.Guidance
+	}
+	return ""
+}
+
+func (x *UpdateTagRequest) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+func (x *UpdateTagRequest) GetRestricted() bool {
+	if x != nil && x.Restricted != nil {
+		return *x.Restricted
+	}
+	return false
+}
+
 // Response after updating a tag
 type UpdateTagResponse struct {
 	state         protoimpl.MessageState
@@ -360,7 +536,7 @@ type UpdateTagResponse struct {
 func (x *UpdateTagResponse) Reset() {
 	*x = UpdateTagResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_tag_proto_msgTypes[6]
+		mi := &file_protos_tag_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -373,7 +549,7 @@ func (x *UpdateTagResponse) String() string {
 func (*UpdateTagResponse) ProtoMessage() {}
 
 func (x *UpdateTagResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_tag_proto_msgTypes[6]
+	mi := &file_protos_tag_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -386,7 +562,7 @@ func (x *UpdateTagResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateTagResponse.ProtoReflect.Descriptor instead.
 func (*UpdateTagResponse) Descriptor() ([]byte, []int) {
-	return file_protos_tag_proto_rawDescGZIP(), []int{6}
+	return file_protos_tag_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *UpdateTagResponse) GetTag() *Tag {
@@ -408,7 +584,7 @@ type DeleteTagRequest struct {
 func (x *DeleteTagRequest) Reset() {
 	*x = DeleteTagRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_tag_proto_msgTypes[7]
+		mi := &file_protos_tag_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -421,7 +597,7 @@ func (x *DeleteTagRequest) String() string {
 func (*DeleteTagRequest) ProtoMessage() {}
 
 func (x *DeleteTagRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_tag_proto_msgTypes[7]
+	mi := &file_protos_tag_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -434,7 +610,7 @@ func (x *DeleteTagRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteTagRequest.ProtoReflect.Descriptor instead.
 func (*DeleteTagRequest) Descriptor() ([]byte, []int) {
-	return file_protos_tag_proto_rawDescGZIP(), []int{7}
+	return file_protos_tag_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *DeleteTagRequest) GetId() string {
@@ -456,7 +632,7 @@ type DeleteTagResponse struct {
 func (x *DeleteTagResponse) Reset() {
 	*x = DeleteTagResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_tag_proto_msgTypes[8]
+		mi := &file_protos_tag_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -469,7 +645,7 @@ func (x *DeleteTagResponse) String() string {
 func (*DeleteTagResponse) ProtoMessage() {}
 
 func (x *DeleteTagResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_tag_proto_msgTypes[8]
+	mi := &file_protos_tag_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -482,7 +658,7 @@ func (x *DeleteTagResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteTagResponse.ProtoReflect.Descriptor instead.
 func (*DeleteTagResponse) Descriptor() ([]byte, []int) {
-	return file_protos_tag_proto_rawDescGZIP(), []int{8}
+	return file_protos_tag_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *DeleteTagResponse) GetMessage() string {
@@ -507,7 +683,7 @@ type GetAllTagsRequest struct {
 func (x *GetAllTagsRequest) Reset() {
 	*x = GetAllTagsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_tag_proto_msgTypes[9]
+		mi := &file_protos_tag_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -520,7 +696,7 @@ func (x *GetAllTagsRequest) String() string {
 func (*GetAllTagsRequest) ProtoMessage() {}
 
 func (x *GetAllTagsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_tag_proto_msgTypes[9]
+	mi := &file_protos_tag_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -533,7 +709,7 @@ func (x *GetAllTagsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAllTagsRequest.ProtoReflect.Descriptor instead.
 func (*GetAllTagsRequest) Descriptor() ([]byte, []int) {
-	return file_protos_tag_proto_rawDescGZIP(), []int{9}
+	return file_protos_tag_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *GetAllTagsRequest) GetName() string {
@@ -569,7 +745,7 @@ type GetAllTagsResponse struct {
 func (x *GetAllTagsResponse) Reset() {
 	*x = GetAllTagsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_tag_proto_msgTypes[10]
+		mi := &file_protos_tag_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -582,7 +758,7 @@ func (x *GetAllTagsResponse) String() string {
 func (*GetAllTagsResponse) ProtoMessage() {}
 
 func (x *GetAllTagsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_tag_proto_msgTypes[10]
+	mi := &file_protos_tag_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -595,7 +771,7 @@ func (x *GetAllTagsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAllTagsResponse.ProtoReflect.Descriptor instead.
 func (*GetAllTagsResponse) Descriptor() ([]byte, []int) {
-	return file_protos_tag_proto_rawDescGZIP(), []int{10}
+	return file_protos_tag_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *GetAllTagsResponse) GetTags() []*Tag {
@@ -620,7 +796,7 @@ type GetFamousTagsReq struct {
 func (x *GetFamousTagsReq) Reset() {
 	*x = GetFamousTagsReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_tag_proto_msgTypes[11]
+		mi := &file_protos_tag_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -633,7 +809,7 @@ func (x *GetFamousTagsReq) String() string {
 func (*GetFamousTagsReq) ProtoMessage() {}
 
 func (x *GetFamousTagsReq) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_tag_proto_msgTypes[11]
+	mi := &file_protos_tag_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -646,7 +822,7 @@ func (x *GetFamousTagsReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetFamousTagsReq.ProtoReflect.Descriptor instead.
 func (*GetFamousTagsReq) Descriptor() ([]byte, []int) {
-	return file_protos_tag_proto_rawDescGZIP(), []int{11}
+	return file_protos_tag_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *GetFamousTagsReq) GetName() string {
@@ -688,7 +864,7 @@ type GetFamousTagsRes struct {
 func (x *GetFamousTagsRes) Reset() {
 	*x = GetFamousTagsRes{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_tag_proto_msgTypes[12]
+		mi := &file_protos_tag_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -701,7 +877,7 @@ func (x *GetFamousTagsRes) String() string {
 func (*GetFamousTagsRes) ProtoMessage() {}
 
 func (x *GetFamousTagsRes) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_tag_proto_msgTypes[12]
+	mi := &file_protos_tag_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -714,7 +890,7 @@ func (x *GetFamousTagsRes) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetFamousTagsRes.ProtoReflect.Descriptor instead.
 func (*GetFamousTagsRes) Descriptor() ([]byte, []int) {
-	return file_protos_tag_proto_rawDescGZIP(), []int{12}
+	return file_protos_tag_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *GetFamousTagsRes) GetTags() []*FamousTag {
@@ -736,7 +912,7 @@ type FamousTag struct {
 func (x *FamousTag) Reset() {
 	*x = FamousTag{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_tag_proto_msgTypes[13]
+		mi := &file_protos_tag_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -749,7 +925,7 @@ func (x *FamousTag) String() string {
 func (*FamousTag) ProtoMessage() {}
 
 func (x *FamousTag) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_tag_proto_msgTypes[13]
+	mi := &file_protos_tag_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -762,7 +938,7 @@ func (x *FamousTag) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FamousTag.ProtoReflect.Descriptor instead.
 func (*FamousTag) Descriptor() ([]byte, []int) {
-	return file_protos_tag_proto_rawDescGZIP(), []int{13}
+	return file_protos_tag_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *FamousTag) GetName() string {
@@ -779,185 +955,778 @@ func (x *FamousTag) GetCount() int32 {
 	return 0
 }
 
-var File_protos_tag_proto protoreflect.FileDescriptor
+// TagSynonym maps an alternative name onto its canonical tag
+type TagSynonym struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var file_protos_tag_proto_rawDesc = []byte{
-	0x0a, 0x10, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2f, 0x74, 0x61, 0x67, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x12, 0x05, 0x66, 0x6f, 0x72, 0x75, 0x6d, 0x22, 0x86, 0x01, 0x0a, 0x03, 0x54, 0x61,
-	0x67, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
-	0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
-	0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74,
-	0x65, 0x64, 0x41, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f,
-	0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65,
-	0x64, 0x41, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x5f, 0x61,
-	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64,
-	0x41, 0x74, 0x22, 0x26, 0x0a, 0x10, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x61, 0x67, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x31, 0x0a, 0x11, 0x43, 0x72,
-	0x65, 0x61, 0x74, 0x65, 0x54, 0x61, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x1c, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x66,
-	0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x54, 0x61, 0x67, 0x52, 0x03, 0x74, 0x61, 0x67, 0x22, 0x1f, 0x0a,
-	0x0d, 0x47, 0x65, 0x74, 0x54, 0x61, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e,
-	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x2e,
-	0x0a, 0x0e, 0x47, 0x65, 0x74, 0x54, 0x61, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x1c, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e,
-	0x66, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x54, 0x61, 0x67, 0x52, 0x03, 0x74, 0x61, 0x67, 0x22, 0x36,
-	0x0a, 0x10, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x61, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
-	0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x31, 0x0a, 0x11, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
-	0x54, 0x61, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x03, 0x74,
-	0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x66, 0x6f, 0x72, 0x75, 0x6d,
-	0x2e, 0x54, 0x61, 0x67, 0x52, 0x03, 0x74, 0x61, 0x67, 0x22, 0x22, 0x0a, 0x10, 0x44, 0x65, 0x6c,
-	0x65, 0x74, 0x65, 0x54, 0x61, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a,
-	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x2d, 0x0a,
-	0x11, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x61, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x51, 0x0a, 0x11,
-	0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d,
-	0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22,
-	0x34, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x01, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x66, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x54, 0x61, 0x67, 0x52,
-	0x04, 0x74, 0x61, 0x67, 0x73, 0x22, 0x64, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x46, 0x61, 0x6d, 0x6f,
-	0x75, 0x73, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a,
-	0x04, 0x64, 0x65, 0x73, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x64, 0x65, 0x73,
-	0x63, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x04, 0x70, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x38, 0x0a, 0x10, 0x47,
-	0x65, 0x74, 0x46, 0x61, 0x6d, 0x6f, 0x75, 0x73, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x73, 0x12,
-	0x24, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e,
-	0x66, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x46, 0x61, 0x6d, 0x6f, 0x75, 0x73, 0x54, 0x61, 0x67, 0x52,
-	0x04, 0x74, 0x61, 0x67, 0x73, 0x22, 0x35, 0x0a, 0x09, 0x46, 0x61, 0x6d, 0x6f, 0x75, 0x73, 0x54,
-	0x61, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x32, 0x89, 0x03, 0x0a,
-	0x0a, 0x54, 0x61, 0x67, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x3e, 0x0a, 0x09, 0x43,
-	0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x61, 0x67, 0x12, 0x17, 0x2e, 0x66, 0x6f, 0x72, 0x75, 0x6d,
-	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x61, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x18, 0x2e, 0x66, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
-	0x54, 0x61, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x06, 0x47,
-	0x65, 0x74, 0x54, 0x61, 0x67, 0x12, 0x14, 0x2e, 0x66, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x47, 0x65,
-	0x74, 0x54, 0x61, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x66, 0x6f,
-	0x72, 0x75, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x61, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x3e, 0x0a, 0x09, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x61, 0x67, 0x12,
-	0x17, 0x2e, 0x66, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x61,
-	0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x66, 0x6f, 0x72, 0x75, 0x6d,
-	0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x61, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x3e, 0x0a, 0x09, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x61, 0x67, 0x12,
-	0x17, 0x2e, 0x66, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x61,
-	0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x66, 0x6f, 0x72, 0x75, 0x6d,
-	0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x61, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x41, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x54, 0x61, 0x67, 0x73,
-	0x12, 0x18, 0x2e, 0x66, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x54,
-	0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x66, 0x6f, 0x72,
-	0x75, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x46, 0x61, 0x6d, 0x6f,
-	0x75, 0x73, 0x54, 0x61, 0x67, 0x73, 0x12, 0x17, 0x2e, 0x66, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x47,
-	0x65, 0x74, 0x46, 0x61, 0x6d, 0x6f, 0x75, 0x73, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x1a,
-	0x17, 0x2e, 0x66, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x46, 0x61, 0x6d, 0x6f, 0x75,
-	0x73, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x73, 0x42, 0x06, 0x5a, 0x04, 0x2f, 0x74, 0x61, 0x67,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	Name        string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                // Lower-cased synonym
+	TagId       string `protobuf:"bytes,2,opt,name=tag_id,json=tagId,proto3" json:"tag_id,omitempty"` // UUID of the canonical tag
+	TagName     string `protobuf:"bytes,3,opt,name=tag_name,json=tagName,proto3" json:"tag_name,omitempty"`
+	SourceTagId string `protobuf:"bytes,4,opt,name=source_tag_id,json=sourceTagId,proto3" json:"source_tag_id,omitempty"` // UUID of the merged tag that carried this name, if any
+	CreatedAt   string `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 }
 
-var (
-	file_protos_tag_proto_rawDescOnce sync.Once
-	file_protos_tag_proto_rawDescData = file_protos_tag_proto_rawDesc
-)
+func (x *TagSynonym) Reset() {
+	*x = TagSynonym{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_tag_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func file_protos_tag_proto_rawDescGZIP() []byte {
-	file_protos_tag_proto_rawDescOnce.Do(func() {
-		file_protos_tag_proto_rawDescData = protoimpl.X.CompressGZIP(file_protos_tag_proto_rawDescData)
-	})
-	return file_protos_tag_proto_rawDescData
+func (x *TagSynonym) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_protos_tag_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
-var file_protos_tag_proto_goTypes = []any{
-	(*Tag)(nil),                // 0: forum.Tag
-	(*CreateTagRequest)(nil),   // 1: forum.CreateTagRequest
-	(*CreateTagResponse)(nil),  // 2: forum.CreateTagResponse
-	(*GetTagRequest)(nil),      // 3: forum.GetTagRequest
-	(*GetTagResponse)(nil),     // 4: forum.GetTagResponse
-	(*UpdateTagRequest)(nil),   // 5: forum.UpdateTagRequest
-	(*UpdateTagResponse)(nil),  // 6: forum.UpdateTagResponse
-	(*DeleteTagRequest)(nil),   // 7: forum.DeleteTagRequest
-	(*DeleteTagResponse)(nil),  // 8: forum.DeleteTagResponse
-	(*GetAllTagsRequest)(nil),  // 9: forum.GetAllTagsRequest
-	(*GetAllTagsResponse)(nil), // 10: forum.GetAllTagsResponse
-	(*GetFamousTagsReq)(nil),   // 11: forum.GetFamousTagsReq
-	(*GetFamousTagsRes)(nil),   // 12: forum.GetFamousTagsRes
-	(*FamousTag)(nil),          // 13: forum.FamousTag
+func (*TagSynonym) ProtoMessage() {}
+
+func (x *TagSynonym) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_tag_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_protos_tag_proto_depIdxs = []int32{
-	0,  // 0: forum.CreateTagResponse.tag:type_name -> forum.Tag
-	0,  // 1: forum.GetTagResponse.tag:type_name -> forum.Tag
-	0,  // 2: forum.UpdateTagResponse.tag:type_name -> forum.Tag
-	0,  // 3: forum.GetAllTagsResponse.tags:type_name -> forum.Tag
-	13, // 4: forum.GetFamousTagsRes.tags:type_name -> forum.FamousTag
-	1,  // 5: forum.TagService.CreateTag:input_type -> forum.CreateTagRequest
-	3,  // 6: forum.TagService.GetTag:input_type -> forum.GetTagRequest
-	5,  // 7: forum.TagService.UpdateTag:input_type -> forum.UpdateTagRequest
-	7,  // 8: forum.TagService.DeleteTag:input_type -> forum.DeleteTagRequest
-	9,  // 9: forum.TagService.GetAllTags:input_type -> forum.GetAllTagsRequest
-	11, // 10: forum.TagService.GetFamousTags:input_type -> forum.GetFamousTagsReq
-	2,  // 11: forum.TagService.CreateTag:output_type -> forum.CreateTagResponse
-	4,  // 12: forum.TagService.GetTag:output_type -> forum.GetTagResponse
-	6,  // 13: forum.TagService.UpdateTag:output_type -> forum.UpdateTagResponse
-	8,  // 14: forum.TagService.DeleteTag:output_type -> forum.DeleteTagResponse
-	10, // 15: forum.TagService.GetAllTags:output_type -> forum.GetAllTagsResponse
-	12, // 16: forum.TagService.GetFamousTags:output_type -> forum.GetFamousTagsRes
-	11, // [11:17] is the sub-list for method output_type
-	5,  // [5:11] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+
+// Deprecated: Use TagSynonym.ProtoReflect.Descriptor instead.
+func (*TagSynonym) Descriptor() ([]byte, []int) {
+	return file_protos_tag_proto_rawDescGZIP(), []int{15}
 }
 
-func init() { file_protos_tag_proto_init() }
-func file_protos_tag_proto_init() {
-	if File_protos_tag_proto != nil {
-		return
+func (x *TagSynonym) GetName() string {
+	if x != nil {
+		return x.Name
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_protos_tag_proto_msgTypes[0].Exporter = func(v any, i int) any {
-			switch v := v.(*Tag); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_protos_tag_proto_msgTypes[1].Exporter = func(v any, i int) any {
-			switch v := v.(*CreateTagRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_protos_tag_proto_msgTypes[2].Exporter = func(v any, i int) any {
-			switch v := v.(*CreateTagResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
+	return ""
+}
+
+func (x *TagSynonym) GetTagId() string {
+	if x != nil {
+		return x.TagId
+	}
+	return ""
+}
+
+func (x *TagSynonym) GetTagName() string {
+	if x != nil {
+		return x.TagName
+	}
+	return ""
+}
+
+func (x *TagSynonym) GetSourceTagId() string {
+	if x != nil {
+		return x.SourceTagId
+	}
+	return ""
+}
+
+func (x *TagSynonym) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+// Request for merging tags into a target tag
+type MergeTagsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SourceIds []string `protobuf:"bytes,1,rep,name=source_ids,json=sourceIds,proto3" json:"source_ids,omitempty"` // Tags to merge, they are deleted and become synonyms of the target
+	TargetId  string   `protobuf:"bytes,2,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+}
+
+func (x *MergeTagsRequest) Reset() {
+	*x = MergeTagsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_tag_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MergeTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeTagsRequest) ProtoMessage() {}
+
+func (x *MergeTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_tag_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeTagsRequest.ProtoReflect.Descriptor instead.
+func (*MergeTagsRequest) Descriptor() ([]byte, []int) {
+	return file_protos_tag_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *MergeTagsRequest) GetSourceIds() []string {
+	if x != nil {
+		return x.SourceIds
+	}
+	return nil
+}
+
+func (x *MergeTagsRequest) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+// Response after merging tags
+type MergeTagsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Target        *Tag  `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	RetaggedPosts int32 `protobuf:"varint,2,opt,name=retagged_posts,json=retaggedPosts,proto3" json:"retagged_posts,omitempty"` // Posts that received the target tag through the merge
+}
+
+func (x *MergeTagsResponse) Reset() {
+	*x = MergeTagsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_tag_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MergeTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeTagsResponse) ProtoMessage() {}
+
+func (x *MergeTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_tag_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeTagsResponse.ProtoReflect.Descriptor instead.
+func (*MergeTagsResponse) Descriptor() ([]byte, []int) {
+	return file_protos_tag_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *MergeTagsResponse) GetTarget() *Tag {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+func (x *MergeTagsResponse) GetRetaggedPosts() int32 {
+	if x != nil {
+		return x.RetaggedPosts
+	}
+	return 0
+}
+
+// Request for listing tag synonyms
+type ListTagSynonymsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TagId string `protobuf:"bytes,1,opt,name=tag_id,json=tagId,proto3" json:"tag_id,omitempty"` // Optional, only synonyms of this tag
+	// Pagination
+	Page  int32 `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Limit int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *ListTagSynonymsRequest) Reset() {
+	*x = ListTagSynonymsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_tag_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTagSynonymsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTagSynonymsRequest) ProtoMessage() {}
+
+func (x *ListTagSynonymsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_tag_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTagSynonymsRequest.ProtoReflect.Descriptor instead.
+func (*ListTagSynonymsRequest) Descriptor() ([]byte, []int) {
+	return file_protos_tag_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListTagSynonymsRequest) GetTagId() string {
+	if x != nil {
+		return x.TagId
+	}
+	return ""
+}
+
+func (x *ListTagSynonymsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListTagSynonymsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// Response containing tag synonyms
+type ListTagSynonymsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Synonyms []*TagSynonym `protobuf:"bytes,1,rep,name=synonyms,proto3" json:"synonyms,omitempty"`
+}
+
+func (x *ListTagSynonymsResponse) Reset() {
+	*x = ListTagSynonymsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_tag_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTagSynonymsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTagSynonymsResponse) ProtoMessage() {}
+
+func (x *ListTagSynonymsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_tag_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTagSynonymsResponse.ProtoReflect.Descriptor instead.
+func (*ListTagSynonymsResponse) Descriptor() ([]byte, []int) {
+	return file_protos_tag_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListTagSynonymsResponse) GetSynonyms() []*TagSynonym {
+	if x != nil {
+		return x.Synonyms
+	}
+	return nil
+}
+
+// RelatedTag is a tag that is often used together with another one
+type RelatedTag struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tag           *Tag    `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	CoOccurrences int32   `protobuf:"varint,2,opt,name=co_occurrences,json=coOccurrences,proto3" json:"co_occurrences,omitempty"` // Live posts carrying both tags
+	Affinity      float64 `protobuf:"fixed64,3,opt,name=affinity,proto3" json:"affinity,omitempty"`                               // Jaccard index of the two tags' posts, from 0 to 1
+}
+
+func (x *RelatedTag) Reset() {
+	*x = RelatedTag{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_tag_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RelatedTag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelatedTag) ProtoMessage() {}
+
+func (x *RelatedTag) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_tag_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelatedTag.ProtoReflect.Descriptor instead.
+func (*RelatedTag) Descriptor() ([]byte, []int) {
+	return file_protos_tag_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RelatedTag) GetTag() *Tag {
+	if x != nil {
+		return x.Tag
+	}
+	return nil
+}
+
+func (x *RelatedTag) GetCoOccurrences() int32 {
+	if x != nil {
+		return x.CoOccurrences
+	}
+	return 0
+}
+
+func (x *RelatedTag) GetAffinity() float64 {
+	if x != nil {
+		return x.Affinity
+	}
+	return 0
+}
+
+// Request for the tags most often used together with a tag
+type GetRelatedTagsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TagId string `protobuf:"bytes,1,opt,name=tag_id,json=tagId,proto3" json:"tag_id,omitempty"`
+	Limit int32  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // Defaults to 10
+}
+
+func (x *GetRelatedTagsRequest) Reset() {
+	*x = GetRelatedTagsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_tag_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRelatedTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRelatedTagsRequest) ProtoMessage() {}
+
+func (x *GetRelatedTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_tag_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRelatedTagsRequest.ProtoReflect.Descriptor instead.
+func (*GetRelatedTagsRequest) Descriptor() ([]byte, []int) {
+	return file_protos_tag_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetRelatedTagsRequest) GetTagId() string {
+	if x != nil {
+		return x.TagId
+	}
+	return ""
+}
+
+func (x *GetRelatedTagsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// Response containing related tags, most frequent first
+type GetRelatedTagsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tags []*RelatedTag `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+}
+
+func (x *GetRelatedTagsResponse) Reset() {
+	*x = GetRelatedTagsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_tag_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRelatedTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRelatedTagsResponse) ProtoMessage() {}
+
+func (x *GetRelatedTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_tag_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRelatedTagsResponse.ProtoReflect.Descriptor instead.
+func (*GetRelatedTagsResponse) Descriptor() ([]byte, []int) {
+	return file_protos_tag_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetRelatedTagsResponse) GetTags() []*RelatedTag {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+var File_protos_tag_proto protoreflect.FileDescriptor
+
+var file_protos_tag_proto_rawDesc = []byte{
+	0x0a, 0x10, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2f, 0x74, 0x61, 0x67, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x05, 0x66, 0x6f, 0x72, 0x75, 0x6d, 0x22, 0x94, 0x02, 0x0a, 0x03, 0x54, 0x61,
+	0x67, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x64, 0x41, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f,
+	0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x64, 0x41, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x5f, 0x61,
+	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64,
+	0x41, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b,
+	0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a,
+	0x0a, 0x08, 0x67, 0x75, 0x69, 0x64, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x67, 0x75, 0x69, 0x64, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f,
+	0x6c, 0x6f, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72,
+	0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x65, 0x64, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x65, 0x64,
+	0x22, 0x9a, 0x01, 0x0a, 0x10, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x61, 0x67, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x67,
+	0x75, 0x69, 0x64, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x67,
+	0x75, 0x69, 0x64, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x1e, 0x0a,
+	0x0a, 0x72, 0x65, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x65, 0x64, 0x22, 0x31, 0x0a,
+	0x11, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x61, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x1c, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0a, 0x2e, 0x66, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x54, 0x61, 0x67, 0x52, 0x03, 0x74, 0x61, 0x67,
+	0x22, 0x1f, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x54, 0x61, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x22, 0x74, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x54, 0x61, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0a, 0x2e, 0x66, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x54, 0x61, 0x67, 0x52, 0x03, 0x74, 0x61,
+	0x67, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x5f, 0x66, 0x72,
+	0x6f, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76,
+	0x65, 0x64, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x1f, 0x0a, 0x0b, 0x75, 0x73, 0x61, 0x67, 0x65, 0x5f,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x75, 0x73, 0x61,
+	0x67, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x29, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x54, 0x61,
+	0x67, 0x42, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x22, 0xe9, 0x01, 0x0a, 0x10, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x61, 0x67,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x65,
+	0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x67, 0x75, 0x69, 0x64,
+	0x61, 0x6e, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x67, 0x75, 0x69, 0x64,
+	0x61, 0x6e, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x23, 0x0a, 0x0a, 0x72, 0x65,
+	0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00,
+	0x52, 0x0a, 0x72, 0x65, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x65, 0x64, 0x88, 0x01, 0x01, 0x42,
+	0x0d, 0x0a, 0x0b, 0x5f, 0x72, 0x65, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x65, 0x64, 0x22, 0x31,
+	0x0a, 0x11, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x61, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0a, 0x2e, 0x66, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x54, 0x61, 0x67, 0x52, 0x03, 0x74, 0x61,
+	0x67, 0x22, 0x22, 0x0a, 0x10, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x61, 0x67, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x2d, 0x0a, 0x11, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54,
+	0x61, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x22, 0x51, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x54, 0x61,
+	0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x70, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x61, 0x67,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x34, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x41, 0x6c,
+	0x6c, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a,
+	0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x66, 0x6f,
+	0x72, 0x75, 0x6d, 0x2e, 0x54, 0x61, 0x67, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x22, 0x64, 0x0a,
+	0x10, 0x47, 0x65, 0x74, 0x46, 0x61, 0x6d, 0x6f, 0x75, 0x73, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65,
+	0x71, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x65, 0x73, 0x63, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x04, 0x64, 0x65, 0x73, 0x63, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x67,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x22, 0x38, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x46, 0x61, 0x6d, 0x6f, 0x75, 0x73,
+	0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x73, 0x12, 0x24, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x66, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x46, 0x61,
+	0x6d, 0x6f, 0x75, 0x73, 0x54, 0x61, 0x67, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x22, 0x35, 0x0a,
+	0x09, 0x46, 0x61, 0x6d, 0x6f, 0x75, 0x73, 0x54, 0x61, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x22, 0x95, 0x01, 0x0a, 0x0a, 0x54, 0x61, 0x67, 0x53, 0x79, 0x6e, 0x6f,
+	0x6e, 0x79, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x15, 0x0a, 0x06, 0x74, 0x61, 0x67, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x61, 0x67, 0x49, 0x64, 0x12, 0x19,
+	0x0a, 0x08, 0x74, 0x61, 0x67, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x74, 0x61, 0x67, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x22, 0x0a, 0x0d, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x5f, 0x74, 0x61, 0x67, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x61, 0x67, 0x49, 0x64, 0x12, 0x1d, 0x0a,
+	0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x4e, 0x0a, 0x10,
+	0x4d, 0x65, 0x72, 0x67, 0x65, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x73, 0x12,
+	0x1b, 0x0a, 0x09, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x49, 0x64, 0x22, 0x5e, 0x0a, 0x11,
+	0x4d, 0x65, 0x72, 0x67, 0x65, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x22, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0a, 0x2e, 0x66, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x54, 0x61, 0x67, 0x52, 0x06, 0x74,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x74, 0x61, 0x67, 0x67, 0x65,
+	0x64, 0x5f, 0x70, 0x6f, 0x73, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x72,
+	0x65, 0x74, 0x61, 0x67, 0x67, 0x65, 0x64, 0x50, 0x6f, 0x73, 0x74, 0x73, 0x22, 0x59, 0x0a, 0x16,
+	0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x67, 0x53, 0x79, 0x6e, 0x6f, 0x6e, 0x79, 0x6d, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x74, 0x61, 0x67, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x61, 0x67, 0x49, 0x64, 0x12, 0x12, 0x0a,
+	0x04, 0x70, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x61, 0x67,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x48, 0x0a, 0x17, 0x4c, 0x69, 0x73, 0x74, 0x54,
+	0x61, 0x67, 0x53, 0x79, 0x6e, 0x6f, 0x6e, 0x79, 0x6d, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x2d, 0x0a, 0x08, 0x73, 0x79, 0x6e, 0x6f, 0x6e, 0x79, 0x6d, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x66, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x54, 0x61, 0x67,
+	0x53, 0x79, 0x6e, 0x6f, 0x6e, 0x79, 0x6d, 0x52, 0x08, 0x73, 0x79, 0x6e, 0x6f, 0x6e, 0x79, 0x6d,
+	0x73, 0x22, 0x6d, 0x0a, 0x0a, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x54, 0x61, 0x67, 0x12,
+	0x1c, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x66,
+	0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x54, 0x61, 0x67, 0x52, 0x03, 0x74, 0x61, 0x67, 0x12, 0x25, 0x0a,
+	0x0e, 0x63, 0x6f, 0x5f, 0x6f, 0x63, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x63, 0x6f, 0x4f, 0x63, 0x63, 0x75, 0x72, 0x72, 0x65,
+	0x6e, 0x63, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x66, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x79,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x61, 0x66, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x79,
+	0x22, 0x44, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x54, 0x61,
+	0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x74, 0x61, 0x67,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x61, 0x67, 0x49, 0x64,
+	0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x3f, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x52, 0x65, 0x6c,
+	0x61, 0x74, 0x65, 0x64, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x25, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11,
+	0x2e, 0x66, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x54, 0x61,
+	0x67, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x32, 0xad, 0x05, 0x0a, 0x0a, 0x54, 0x61, 0x67, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x3e, 0x0a, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x54, 0x61, 0x67, 0x12, 0x17, 0x2e, 0x66, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x54, 0x61, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x66,
+	0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x61, 0x67, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x06, 0x47, 0x65, 0x74, 0x54, 0x61, 0x67,
+	0x12, 0x14, 0x2e, 0x66, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x61, 0x67, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x66, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x47,
+	0x65, 0x74, 0x54, 0x61, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a,
+	0x09, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x61, 0x67, 0x12, 0x17, 0x2e, 0x66, 0x6f, 0x72,
+	0x75, 0x6d, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x61, 0x67, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x66, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x54, 0x61, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a,
+	0x09, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x61, 0x67, 0x12, 0x17, 0x2e, 0x66, 0x6f, 0x72,
+	0x75, 0x6d, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x61, 0x67, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x66, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x54, 0x61, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a,
+	0x0a, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x54, 0x61, 0x67, 0x73, 0x12, 0x18, 0x2e, 0x66, 0x6f,
+	0x72, 0x75, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x66, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x47, 0x65,
+	0x74, 0x41, 0x6c, 0x6c, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x41, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x46, 0x61, 0x6d, 0x6f, 0x75, 0x73, 0x54, 0x61, 0x67,
+	0x73, 0x12, 0x17, 0x2e, 0x66, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x46, 0x61, 0x6d,
+	0x6f, 0x75, 0x73, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x17, 0x2e, 0x66, 0x6f, 0x72,
+	0x75, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x46, 0x61, 0x6d, 0x6f, 0x75, 0x73, 0x54, 0x61, 0x67, 0x73,
+	0x52, 0x65, 0x73, 0x12, 0x41, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x54, 0x61, 0x67, 0x42, 0x79, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x1a, 0x2e, 0x66, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x54,
+	0x61, 0x67, 0x42, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x15, 0x2e, 0x66, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x61, 0x67, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a, 0x09, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x54,
+	0x61, 0x67, 0x73, 0x12, 0x17, 0x2e, 0x66, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x4d, 0x65, 0x72, 0x67,
+	0x65, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x66,
+	0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x50, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61,
+	0x67, 0x53, 0x79, 0x6e, 0x6f, 0x6e, 0x79, 0x6d, 0x73, 0x12, 0x1d, 0x2e, 0x66, 0x6f, 0x72, 0x75,
+	0x6d, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x67, 0x53, 0x79, 0x6e, 0x6f, 0x6e, 0x79, 0x6d,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x66, 0x6f, 0x72, 0x75, 0x6d,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x67, 0x53, 0x79, 0x6e, 0x6f, 0x6e, 0x79, 0x6d, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x52,
+	0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x54, 0x61, 0x67, 0x73, 0x12, 0x1c, 0x2e, 0x66, 0x6f, 0x72,
+	0x75, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x54, 0x61, 0x67,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x66, 0x6f, 0x72, 0x75, 0x6d,
+	0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x54, 0x61, 0x67, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x06, 0x5a, 0x04, 0x2f, 0x74, 0x61, 0x67, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_protos_tag_proto_rawDescOnce sync.Once
+	file_protos_tag_proto_rawDescData = file_protos_tag_proto_rawDesc
+)
+
+func file_protos_tag_proto_rawDescGZIP() []byte {
+	file_protos_tag_proto_rawDescOnce.Do(func() {
+		file_protos_tag_proto_rawDescData = protoimpl.X.CompressGZIP(file_protos_tag_proto_rawDescData)
+	})
+	return file_protos_tag_proto_rawDescData
+}
+
+var file_protos_tag_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_protos_tag_proto_goTypes = []any{
+	(*Tag)(nil),                     // 0: forum.Tag
+	(*CreateTagRequest)(nil),        // 1: forum.CreateTagRequest
+	(*CreateTagResponse)(nil),       // 2: forum.CreateTagResponse
+	(*GetTagRequest)(nil),           // 3: forum.GetTagRequest
+	(*GetTagResponse)(nil),          // 4: forum.GetTagResponse
+	(*GetTagByNameRequest)(nil),     // 5: forum.GetTagByNameRequest
+	(*UpdateTagRequest)(nil),        // 6: forum.UpdateTagRequest
+	(*UpdateTagResponse)(nil),       // 7: forum.UpdateTagResponse
+	(*DeleteTagRequest)(nil),        // 8: forum.DeleteTagRequest
+	(*DeleteTagResponse)(nil),       // 9: forum.DeleteTagResponse
+	(*GetAllTagsRequest)(nil),       // 10: forum.GetAllTagsRequest
+	(*GetAllTagsResponse)(nil),      // 11: forum.GetAllTagsResponse
+	(*GetFamousTagsReq)(nil),        // 12: forum.GetFamousTagsReq
+	(*GetFamousTagsRes)(nil),        // 13: forum.GetFamousTagsRes
+	(*FamousTag)(nil),               // 14: forum.FamousTag
+	(*TagSynonym)(nil),              // 15: forum.TagSynonym
+	(*MergeTagsRequest)(nil),        // 16: forum.MergeTagsRequest
+	(*MergeTagsResponse)(nil),       // 17: forum.MergeTagsResponse
+	(*ListTagSynonymsRequest)(nil),  // 18: forum.ListTagSynonymsRequest
+	(*ListTagSynonymsResponse)(nil), // 19: forum.ListTagSynonymsResponse
+	(*RelatedTag)(nil),              // 20: forum.RelatedTag
+	(*GetRelatedTagsRequest)(nil),   // 21: forum.GetRelatedTagsRequest
+	(*GetRelatedTagsResponse)(nil),  // 22: forum.GetRelatedTagsResponse
+}
+var file_protos_tag_proto_depIdxs = []int32{
+	0,  // 0: forum.CreateTagResponse.tag:type_name -> forum.Tag
+	0,  // 1: forum.GetTagResponse.tag:type_name -> forum.Tag
+	0,  // 2: forum.UpdateTagResponse.tag:type_name -> forum.Tag
+	0,  // 3: forum.GetAllTagsResponse.tags:type_name -> forum.Tag
+	14, // 4: forum.GetFamousTagsRes.tags:type_name -> forum.FamousTag
+	0,  // 5: forum.MergeTagsResponse.target:type_name -> forum.Tag
+	15, // 6: forum.ListTagSynonymsResponse.synonyms:type_name -> forum.TagSynonym
+	0,  // 7: forum.RelatedTag.tag:type_name -> forum.Tag
+	20, // 8: forum.GetRelatedTagsResponse.tags:type_name -> forum.RelatedTag
+	1,  // 9: forum.TagService.CreateTag:input_type -> forum.CreateTagRequest
+	3,  // 10: forum.TagService.GetTag:input_type -> forum.GetTagRequest
+	6,  // 11: forum.TagService.UpdateTag:input_type -> forum.UpdateTagRequest
+	8,  // 12: forum.TagService.DeleteTag:input_type -> forum.DeleteTagRequest
+	10, // 13: forum.TagService.GetAllTags:input_type -> forum.GetAllTagsRequest
+	12, // 14: forum.TagService.GetFamousTags:input_type -> forum.GetFamousTagsReq
+	5,  // 15: forum.TagService.GetTagByName:input_type -> forum.GetTagByNameRequest
+	16, // 16: forum.TagService.MergeTags:input_type -> forum.MergeTagsRequest
+	18, // 17: forum.TagService.ListTagSynonyms:input_type -> forum.ListTagSynonymsRequest
+	21, // 18: forum.TagService.GetRelatedTags:input_type -> forum.GetRelatedTagsRequest
+	2,  // 19: forum.TagService.CreateTag:output_type -> forum.CreateTagResponse
+	4,  // 20: forum.TagService.GetTag:output_type -> forum.GetTagResponse
+	7,  // 21: forum.TagService.UpdateTag:output_type -> forum.UpdateTagResponse
+	9,  // 22: forum.TagService.DeleteTag:output_type -> forum.DeleteTagResponse
+	11, // 23: forum.TagService.GetAllTags:output_type -> forum.GetAllTagsResponse
+	13, // 24: forum.TagService.GetFamousTags:output_type -> forum.GetFamousTagsRes
+	4,  // 25: forum.TagService.GetTagByName:output_type -> forum.GetTagResponse
+	17, // 26: forum.TagService.MergeTags:output_type -> forum.MergeTagsResponse
+	19, // 27: forum.TagService.ListTagSynonyms:output_type -> forum.ListTagSynonymsResponse
+	22, // 28: forum.TagService.GetRelatedTags:output_type -> forum.GetRelatedTagsResponse
+	19, // [19:29] is the sub-list for method output_type
+	9,  // [9:19] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_protos_tag_proto_init() }
+func file_protos_tag_proto_init() {
+	if File_protos_tag_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_protos_tag_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*Tag); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_tag_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*CreateTagRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_tag_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*CreateTagResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
 				return nil
 			}
 		}
@@ -986,7 +1755,7 @@ func file_protos_tag_proto_init() {
 			}
 		}
 		file_protos_tag_proto_msgTypes[5].Exporter = func(v any, i int) any {
-			switch v := v.(*UpdateTagRequest); i {
+			switch v := v.(*GetTagByNameRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -998,7 +1767,7 @@ func file_protos_tag_proto_init() {
 			}
 		}
 		file_protos_tag_proto_msgTypes[6].Exporter = func(v any, i int) any {
-			switch v := v.(*UpdateTagResponse); i {
+			switch v := v.(*UpdateTagRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1010,7 +1779,7 @@ func file_protos_tag_proto_init() {
 			}
 		}
 		file_protos_tag_proto_msgTypes[7].Exporter = func(v any, i int) any {
-			switch v := v.(*DeleteTagRequest); i {
+			switch v := v.(*UpdateTagResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1022,7 +1791,7 @@ func file_protos_tag_proto_init() {
 			}
 		}
 		file_protos_tag_proto_msgTypes[8].Exporter = func(v any, i int) any {
-			switch v := v.(*DeleteTagResponse); i {
+			switch v := v.(*DeleteTagRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1034,7 +1803,7 @@ func file_protos_tag_proto_init() {
 			}
 		}
 		file_protos_tag_proto_msgTypes[9].Exporter = func(v any, i int) any {
-			switch v := v.(*GetAllTagsRequest); i {
+			switch v := v.(*DeleteTagResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1046,7 +1815,7 @@ func file_protos_tag_proto_init() {
 			}
 		}
 		file_protos_tag_proto_msgTypes[10].Exporter = func(v any, i int) any {
-			switch v := v.(*GetAllTagsResponse); i {
+			switch v := v.(*GetAllTagsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1058,7 +1827,7 @@ func file_protos_tag_proto_init() {
 			}
 		}
 		file_protos_tag_proto_msgTypes[11].Exporter = func(v any, i int) any {
-			switch v := v.(*GetFamousTagsReq); i {
+			switch v := v.(*GetAllTagsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1070,7 +1839,7 @@ func file_protos_tag_proto_init() {
 			}
 		}
 		file_protos_tag_proto_msgTypes[12].Exporter = func(v any, i int) any {
-			switch v := v.(*GetFamousTagsRes); i {
+			switch v := v.(*GetFamousTagsReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1082,6 +1851,18 @@ func file_protos_tag_proto_init() {
 			}
 		}
 		file_protos_tag_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*GetFamousTagsRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_tag_proto_msgTypes[14].Exporter = func(v any, i int) any {
 			switch v := v.(*FamousTag); i {
 			case 0:
 				return &v.state
@@ -1093,14 +1874,111 @@ func file_protos_tag_proto_init() {
 				return nil
 			}
 		}
+		file_protos_tag_proto_msgTypes[15].Exporter = func(v any, i int) any {
+			switch v := v.(*TagSynonym); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_tag_proto_msgTypes[16].Exporter = func(v any, i int) any {
+			switch v := v.(*MergeTagsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_tag_proto_msgTypes[17].Exporter = func(v any, i int) any {
+			switch v := v.(*MergeTagsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_tag_proto_msgTypes[18].Exporter = func(v any, i int) any {
+			switch v := v.(*ListTagSynonymsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_tag_proto_msgTypes[19].Exporter = func(v any, i int) any {
+			switch v := v.(*ListTagSynonymsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_tag_proto_msgTypes[20].Exporter = func(v any, i int) any {
+			switch v := v.(*RelatedTag); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_tag_proto_msgTypes[21].Exporter = func(v any, i int) any {
+			switch v := v.(*GetRelatedTagsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_tag_proto_msgTypes[22].Exporter = func(v any, i int) any {
+			switch v := v.(*GetRelatedTagsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
+	file_protos_tag_proto_msgTypes[6].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_protos_tag_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   14,
+			NumMessages:   23,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,12 +19,16 @@ import (
 const _ = grpc.SupportPackageIsVersion8
 
 const (
-	TagService_CreateTag_FullMethodName     = "/forum.TagService/CreateTag"
-	TagService_GetTag_FullMethodName        = "/forum.TagService/GetTag"
-	TagService_UpdateTag_FullMethodName     = "/forum.TagService/UpdateTag"
-	TagService_DeleteTag_FullMethodName     = "/forum.TagService/DeleteTag"
-	TagService_GetAllTags_FullMethodName    = "/forum.TagService/GetAllTags"
-	TagService_GetFamousTags_FullMethodName = "/forum.TagService/GetFamousTags"
+	TagService_CreateTag_FullMethodName       = "/forum.TagService/CreateTag"
+	TagService_GetTag_FullMethodName          = "/forum.TagService/GetTag"
+	TagService_UpdateTag_FullMethodName       = "/forum.TagService/UpdateTag"
+	TagService_DeleteTag_FullMethodName       = "/forum.TagService/DeleteTag"
+	TagService_GetAllTags_FullMethodName      = "/forum.TagService/GetAllTags"
+	TagService_GetFamousTags_FullMethodName   = "/forum.TagService/GetFamousTags"
+	TagService_GetTagByName_FullMethodName    = "/forum.TagService/GetTagByName"
+	TagService_MergeTags_FullMethodName       = "/forum.TagService/MergeTags"
+	TagService_ListTagSynonyms_FullMethodName = "/forum.TagService/ListTagSynonyms"
+	TagService_GetRelatedTags_FullMethodName  = "/forum.TagService/GetRelatedTags"
 )
 
 // TagServiceClient is the client API for TagService service.
@@ -39,6 +43,12 @@ type TagServiceClient interface {
 	// Tag GetAll
 	GetAllTags(ctx context.Context, in *GetAllTagsRequest, opts ...grpc.CallOption) (*GetAllTagsResponse, error)
 	GetFamousTags(ctx context.Context, in *GetFamousTagsReq, opts ...grpc.CallOption) (*GetFamousTagsRes, error)
+	// Tag lookup by name, merging and synonyms
+	GetTagByName(ctx context.Context, in *GetTagByNameRequest, opts ...grpc.CallOption) (*GetTagResponse, error)
+	MergeTags(ctx context.Context, in *MergeTagsRequest, opts ...grpc.CallOption) (*MergeTagsResponse, error)
+	ListTagSynonyms(ctx context.Context, in *ListTagSynonymsRequest, opts ...grpc.CallOption) (*ListTagSynonymsResponse, error)
+	// Tag co-occurrence
+	GetRelatedTags(ctx context.Context, in *GetRelatedTagsRequest, opts ...grpc.CallOption) (*GetRelatedTagsResponse, error)
 }
 
 type tagServiceClient struct {
@@ -109,6 +119,46 @@ func (c *tagServiceClient) GetFamousTags(ctx context.Context, in *GetFamousTagsR
 	return out, nil
 }
 
+func (c *tagServiceClient) GetTagByName(ctx context.Context, in *GetTagByNameRequest, opts ...grpc.CallOption) (*GetTagResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTagResponse)
+	err := c.cc.Invoke(ctx, TagService_GetTagByName_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tagServiceClient) MergeTags(ctx context.Context, in *MergeTagsRequest, opts ...grpc.CallOption) (*MergeTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MergeTagsResponse)
+	err := c.cc.Invoke(ctx, TagService_MergeTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tagServiceClient) ListTagSynonyms(ctx context.Context, in *ListTagSynonymsRequest, opts ...grpc.CallOption) (*ListTagSynonymsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTagSynonymsResponse)
+	err := c.cc.Invoke(ctx, TagService_ListTagSynonyms_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tagServiceClient) GetRelatedTags(ctx context.Context, in *GetRelatedTagsRequest, opts ...grpc.CallOption) (*GetRelatedTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRelatedTagsResponse)
+	err := c.cc.Invoke(ctx, TagService_GetRelatedTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TagServiceServer is the server API for TagService service.
 // All implementations must embed UnimplementedTagServiceServer
 // for forward compatibility
@@ -121,6 +171,12 @@ type TagServiceServer interface {
 	// Tag GetAll
 	GetAllTags(context.Context, *GetAllTagsRequest) (*GetAllTagsResponse, error)
 	GetFamousTags(context.Context, *GetFamousTagsReq) (*GetFamousTagsRes, error)
+	// Tag lookup by name, merging and synonyms
+	GetTagByName(context.Context, *GetTagByNameRequest) (*GetTagResponse, error)
+	MergeTags(context.Context, *MergeTagsRequest) (*MergeTagsResponse, error)
+	ListTagSynonyms(context.Context, *ListTagSynonymsRequest) (*ListTagSynonymsResponse, error)
+	// Tag co-occurrence
+	GetRelatedTags(context.Context, *GetRelatedTagsRequest) (*GetRelatedTagsResponse, error)
 	mustEmbedUnimplementedTagServiceServer()
 }
 
@@ -146,6 +202,18 @@ func (UnimplementedTagServiceServer) GetAllTags(context.Context, *GetAllTagsRequ
 func (UnimplementedTagServiceServer) GetFamousTags(context.Context, *GetFamousTagsReq) (*GetFamousTagsRes, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetFamousTags not implemented")
 }
+func (UnimplementedTagServiceServer) GetTagByName(context.Context, *GetTagByNameRequest) (*GetTagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTagByName not implemented")
+}
+func (UnimplementedTagServiceServer) MergeTags(context.Context, *MergeTagsRequest) (*MergeTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeTags not implemented")
+}
+func (UnimplementedTagServiceServer) ListTagSynonyms(context.Context, *ListTagSynonymsRequest) (*ListTagSynonymsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTagSynonyms not implemented")
+}
+func (UnimplementedTagServiceServer) GetRelatedTags(context.Context, *GetRelatedTagsRequest) (*GetRelatedTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRelatedTags not implemented")
+}
 func (UnimplementedTagServiceServer) mustEmbedUnimplementedTagServiceServer() {}
 
 // UnsafeTagServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -267,6 +335,78 @@ func _TagService_GetFamousTags_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TagService_GetTagByName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTagByNameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TagServiceServer).GetTagByName(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TagService_GetTagByName_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TagServiceServer).GetTagByName(ctx, req.(*GetTagByNameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TagService_MergeTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TagServiceServer).MergeTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TagService_MergeTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TagServiceServer).MergeTags(ctx, req.(*MergeTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TagService_ListTagSynonyms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTagSynonymsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TagServiceServer).ListTagSynonyms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TagService_ListTagSynonyms_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TagServiceServer).ListTagSynonyms(ctx, req.(*ListTagSynonymsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TagService_GetRelatedTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRelatedTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TagServiceServer).GetRelatedTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TagService_GetRelatedTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TagServiceServer).GetRelatedTags(ctx, req.(*GetRelatedTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TagService_ServiceDesc is the grpc.ServiceDesc for TagService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -298,6 +438,22 @@ var TagService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetFamousTags",
 			Handler:    _TagService_GetFamousTags_Handler,
 		},
+		{
+			MethodName: "GetTagByName",
+			Handler:    _TagService_GetTagByName_Handler,
+		},
+		{
+			MethodName: "MergeTags",
+			Handler:    _TagService_MergeTags_Handler,
+		},
+		{
+			MethodName: "ListTagSynonyms",
+			Handler:    _TagService_ListTagSynonyms_Handler,
+		},
+		{
+			MethodName: "GetRelatedTags",
+			Handler:    _TagService_GetRelatedTags_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "protos/tag.proto",
@@ -0,0 +1,218 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Roles, from least to most privileged. Each role can do everything the
+// roles before it can.
+const (
+	RoleMember    = "member"
+	RoleModerator = "moderator"
+	RoleAdmin     = "admin"
+)
+
+var roleRank = map[string]int{RoleMember: 1, RoleModerator: 2, RoleAdmin: 3}
+
+// ValidRole reports whether role is one of the known roles.
+func ValidRole(role string) bool {
+	return roleRank[role] > 0
+}
+
+// Identity is the authenticated caller of an RPC.
+type Identity struct {
+	UserID string
+	Roles  []string
+}
+
+// HasRole reports whether the caller holds role or a more privileged one.
+// Every authenticated caller is a member.
+func (id *Identity) HasRole(role string) bool {
+	if role == RoleMember {
+		return true
+	}
+	for _, r := range id.Roles {
+		if roleRank[r] >= roleRank[role] {
+			return true
+		}
+	}
+	return false
+}
+
+// tokenClaims are the token claims the Authenticator reads.
+type tokenClaims struct {
+	jwt.RegisteredClaims
+	Roles []string `json:"roles"`
+}
+
+type identityKey struct{}
+
+// WithIdentity returns a copy of ctx carrying id.
+func WithIdentity(ctx context.Context, id *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, id)
+}
+
+// IdentityFrom returns the authenticated caller stored in ctx, if any.
+func IdentityFrom(ctx context.Context) (*Identity, bool) {
+	id, ok := ctx.Value(identityKey{}).(*Identity)
+	return id, ok && id != nil
+}
+
+// AuthConfig configures token validation. At least one of HMACSecret and
+// RSAPublicKey must be set.
+type AuthConfig struct {
+	HMACSecret   []byte         // Verifies HS256, HS384 and HS512 tokens
+	RSAPublicKey *rsa.PublicKey // Verifies RS256, RS384 and RS512 tokens
+	Issuer       string         // Optional, required iss claim
+	Audience     string         // Optional, required aud claim
+
+	// PublicMethods can be called without a token. Entries are full or bare
+	// method names and may use path.Match patterns such as GetPost*.
+	PublicMethods []string
+}
+
+// Authenticator validates bearer tokens and puts the caller's Identity in
+// the request context.
+type Authenticator struct {
+	cfg    AuthConfig
+	parser *jwt.Parser
+}
+
+// NewAuthenticator creates an Authenticator.
+func NewAuthenticator(cfg AuthConfig) (*Authenticator, error) {
+	var methods []string
+	if len(cfg.HMACSecret) > 0 {
+		methods = append(methods, "HS256", "HS384", "HS512")
+	}
+	if cfg.RSAPublicKey != nil {
+		methods = append(methods, "RS256", "RS384", "RS512")
+	}
+	if len(methods) == 0 {
+		return nil, errors.New("an HMAC secret or an RSA public key is required")
+	}
+
+	opts := []jwt.ParserOption{jwt.WithValidMethods(methods), jwt.WithExpirationRequired()}
+	if cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.Audience))
+	}
+	return &Authenticator{cfg: cfg, parser: jwt.NewParser(opts...)}, nil
+}
+
+// UnaryInterceptor authenticates unary calls.
+func (a *Authenticator) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := a.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor authenticates streaming calls.
+func (a *Authenticator) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := a.authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &identityStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authenticate validates the bearer token of a call to method. Calls to
+// public methods may omit the token, but a token that is sent must be valid.
+func (a *Authenticator) authenticate(ctx context.Context, method string) (context.Context, error) {
+	token, err := bearerToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if token == "" {
+		if a.public(method) {
+			return ctx, nil
+		}
+		return nil, status.Error(codes.Unauthenticated, "a bearer token is required")
+	}
+
+	claims := &tokenClaims{}
+	if _, err := a.parser.ParseWithClaims(token, claims, a.key); err != nil {
+		log.Error().Err(err).Msg("Authenticator: Invalid token")
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+	if claims.Subject == "" {
+		return nil, status.Error(codes.Unauthenticated, "token has no subject")
+	}
+
+	return WithIdentity(ctx, &Identity{UserID: claims.Subject, Roles: claims.Roles}), nil
+}
+
+// key picks the verification key matching the token's signing method.
+func (a *Authenticator) key(token *jwt.Token) (any, error) {
+	switch token.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		return a.cfg.HMACSecret, nil
+	case *jwt.SigningMethodRSA:
+		return a.cfg.RSAPublicKey, nil
+	}
+	return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+}
+
+func (a *Authenticator) public(method string) bool {
+	for _, pattern := range a.cfg.PublicMethods {
+		if matchMethod(pattern, method) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchMethod reports whether the full method name matches pattern, a full
+// or bare method name that may use path.Match wildcards.
+func matchMethod(pattern, method string) bool {
+	if ok, _ := path.Match(pattern, method); ok {
+		return true
+	}
+	ok, _ := path.Match(pattern, path.Base(method))
+	return ok
+}
+
+// bearerToken returns the token of the authorization header, or an empty
+// string when there is none.
+func bearerToken(ctx context.Context) (string, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return "", nil
+	}
+
+	scheme, token, ok := strings.Cut(values[0], " ")
+	if !ok || !strings.EqualFold(scheme, "bearer") || strings.TrimSpace(token) == "" {
+		return "", status.Error(codes.Unauthenticated, "authorization must be a bearer token")
+	}
+	return strings.TrimSpace(token), nil
+}
+
+// identityStream is a ServerStream whose context carries the caller's Identity.
+type identityStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *identityStream) Context() context.Context {
+	return s.ctx
+}
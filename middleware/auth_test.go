@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var testSecret = []byte("test-secret")
+
+func signedToken(t *testing.T, method jwt.SigningMethod, key any, claims jwt.RegisteredClaims) string {
+	token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	if err != nil {
+		t.Fatalf("Error signing token: %v", err)
+	}
+	return token
+}
+
+func withToken(token string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+}
+
+// callAs runs the interceptor of a on method and returns the identity the handler saw.
+func callAs(a *Authenticator, ctx context.Context, method string) (*Identity, error) {
+	var seen *Identity
+	_, err := a.UnaryInterceptor()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req any) (any, error) {
+		seen, _ = IdentityFrom(ctx)
+		return nil, nil
+	})
+	return seen, err
+}
+
+func TestAuthenticatorHMAC(t *testing.T) {
+	a, err := NewAuthenticator(AuthConfig{HMACSecret: testSecret, Issuer: "forum", PublicMethods: []string{"GetPost*"}})
+	if err != nil {
+		t.Fatalf("Error creating authenticator: %v", err)
+	}
+	valid := jwt.RegisteredClaims{
+		Subject:   "user-1",
+		Issuer:    "forum",
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}
+
+	id, err := callAs(a, withToken(signedToken(t, jwt.SigningMethodHS256, testSecret, valid)), "/forum.PostService/CreatePost")
+	assert.NoError(t, err)
+	if assert.NotNil(t, id) {
+		assert.Equal(t, "user-1", id.UserID)
+	}
+
+	_, err = callAs(a, context.Background(), "/forum.PostService/CreatePost")
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// Public methods work without a token but reject bad ones
+	id, err = callAs(a, context.Background(), "/forum.PostService/GetPostRevisions")
+	assert.NoError(t, err)
+	assert.Nil(t, id)
+	_, err = callAs(a, withToken("garbage"), "/forum.PostService/GetPost")
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	expired := valid
+	expired.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+	_, err = callAs(a, withToken(signedToken(t, jwt.SigningMethodHS256, testSecret, expired)), "/forum.PostService/CreatePost")
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	wrongIssuer := valid
+	wrongIssuer.Issuer = "elsewhere"
+	_, err = callAs(a, withToken(signedToken(t, jwt.SigningMethodHS256, testSecret, wrongIssuer)), "/forum.PostService/CreatePost")
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = callAs(a, withToken(signedToken(t, jwt.SigningMethodHS256, []byte("other-secret"), valid)), "/forum.PostService/CreatePost")
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestAuthenticatorRSA(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Error generating key: %v", err)
+	}
+	a, err := NewAuthenticator(AuthConfig{RSAPublicKey: &key.PublicKey})
+	if err != nil {
+		t.Fatalf("Error creating authenticator: %v", err)
+	}
+	claims := jwt.RegisteredClaims{Subject: "user-2", ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))}
+
+	id, err := callAs(a, withToken(signedToken(t, jwt.SigningMethodRS256, key, claims)), "/forum.CommentService/CreateComment")
+	assert.NoError(t, err)
+	if assert.NotNil(t, id) {
+		assert.Equal(t, "user-2", id.UserID)
+	}
+
+	// HMAC tokens are refused when only an RSA key is configured
+	_, err = callAs(a, withToken(signedToken(t, jwt.SigningMethodHS256, testSecret, claims)), "/forum.CommentService/CreateComment")
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestAuthenticatorRoles(t *testing.T) {
+	a, err := NewAuthenticator(AuthConfig{HMACSecret: testSecret})
+	if err != nil {
+		t.Fatalf("Error creating authenticator: %v", err)
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":   "user-3",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"roles": []string{RoleModerator},
+	}).SignedString(testSecret)
+	if err != nil {
+		t.Fatalf("Error signing token: %v", err)
+	}
+
+	id, err := callAs(a, withToken(token), "/forum.TagService/CreateTag")
+	assert.NoError(t, err)
+	if assert.NotNil(t, id) {
+		assert.Equal(t, []string{RoleModerator}, id.Roles)
+		assert.True(t, id.HasRole(RoleModerator))
+	}
+}
+
+func TestNewAuthenticatorRequiresKey(t *testing.T) {
+	_, err := NewAuthenticator(AuthConfig{})
+	assert.Error(t, err)
+}
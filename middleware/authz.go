@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Forum-service/Forum-Service/audit"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Policy maps methods to the least privileged role allowed to call them.
+// Keys are full or bare method names and may use path.Match patterns, as in
+// AuthConfig.PublicMethods. Methods without an entry are open to every caller
+// the Authenticator lets through.
+type Policy map[string]string
+
+// required returns the role needed to call method. When several entries
+// match, the most privileged role wins so overlapping patterns never loosen
+// a policy.
+func (p Policy) required(method string) (string, bool) {
+	var role string
+	for pattern, r := range p {
+		if matchMethod(pattern, method) && roleRank[r] > roleRank[role] {
+			role = r
+		}
+	}
+	return role, role != ""
+}
+
+// Authorizer enforces a Policy on the Identity put in the context by the
+// Authenticator, so it belongs right after it in the interceptor chain.
+type Authorizer struct {
+	policy Policy
+	audit  audit.Log
+}
+
+// NewAuthorizer creates an Authorizer. audit may be nil, in which case
+// denials are only logged.
+func NewAuthorizer(policy Policy, audit audit.Log) (*Authorizer, error) {
+	for method, role := range policy {
+		if !ValidRole(role) {
+			return nil, fmt.Errorf("unknown role %q for method %s", role, method)
+		}
+	}
+	return &Authorizer{policy: policy, audit: audit}, nil
+}
+
+// UnaryInterceptor authorizes unary calls.
+func (a *Authorizer) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := a.authorize(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor authorizes streaming calls.
+func (a *Authorizer) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := a.authorize(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// authorize returns PermissionDenied, and records an audit entry, when the
+// caller lacks the role method requires.
+func (a *Authorizer) authorize(ctx context.Context, method string) error {
+	role, ok := a.policy.required(method)
+	if !ok {
+		return nil
+	}
+	id, ok := IdentityFrom(ctx)
+	if ok && id.HasRole(role) {
+		return nil
+	}
+
+	entry := &audit.Entry{Method: method, Required: role}
+	if ok {
+		entry.UserID = id.UserID
+		entry.Roles = id.Roles
+	}
+	log.Warn().Str("user_id", entry.UserID).Str("method", method).Str("required", role).Msg("Authorizer: Call denied")
+	if a.audit != nil {
+		if err := a.audit.Record(ctx, entry); err != nil {
+			log.Error().Err(err).Msg("Authorizer: Error recording audit entry")
+		}
+	}
+	return status.Errorf(codes.PermissionDenied, "%s requires the %s role", method, role)
+}
@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Forum-service/Forum-Service/audit"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type memoryAudit struct {
+	entries []*audit.Entry
+}
+
+func (m *memoryAudit) Record(_ context.Context, e *audit.Entry) error {
+	m.entries = append(m.entries, e)
+	return nil
+}
+
+func authorize(a *Authorizer, id *Identity, method string) error {
+	ctx := context.Background()
+	if id != nil {
+		ctx = WithIdentity(ctx, id)
+	}
+	_, err := a.UnaryInterceptor()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req any) (any, error) {
+		return nil, nil
+	})
+	return err
+}
+
+func TestIdentityHasRole(t *testing.T) {
+	member := &Identity{UserID: "u"}
+	assert.True(t, member.HasRole(RoleMember))
+	assert.False(t, member.HasRole(RoleModerator))
+
+	admin := &Identity{UserID: "u", Roles: []string{RoleAdmin}}
+	assert.True(t, admin.HasRole(RoleModerator))
+	assert.True(t, admin.HasRole(RoleAdmin))
+
+	unknown := &Identity{UserID: "u", Roles: []string{"superuser"}}
+	assert.False(t, unknown.HasRole(RoleModerator))
+}
+
+func TestAuthorizer(t *testing.T) {
+	auditLog := &memoryAudit{}
+	a, err := NewAuthorizer(Policy{
+		"/forum.CategoryService/CreateCategory": RoleAdmin,
+		"*Tag":                                  RoleModerator,
+		"DeleteTag":                             RoleAdmin,
+	}, auditLog)
+	if err != nil {
+		t.Fatalf("Error creating authorizer: %v", err)
+	}
+	moderator := &Identity{UserID: "mod", Roles: []string{RoleModerator}}
+	admin := &Identity{UserID: "admin", Roles: []string{RoleAdmin}}
+
+	assert.NoError(t, authorize(a, &Identity{UserID: "u"}, "/forum.PostService/CreatePost"))
+	assert.NoError(t, authorize(a, moderator, "/forum.TagService/CreateTag"))
+	assert.NoError(t, authorize(a, admin, "/forum.CategoryService/CreateCategory"))
+
+	err = authorize(a, moderator, "/forum.CategoryService/CreateCategory")
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// The most privileged of several matching entries applies
+	err = authorize(a, moderator, "/forum.TagService/DeleteTag")
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.NoError(t, authorize(a, admin, "/forum.TagService/DeleteTag"))
+
+	err = authorize(a, nil, "/forum.TagService/UpdateTag")
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	if assert.Len(t, auditLog.entries, 3) {
+		assert.Equal(t, &audit.Entry{
+			UserID:   "mod",
+			Roles:    []string{RoleModerator},
+			Method:   "/forum.CategoryService/CreateCategory",
+			Required: RoleAdmin,
+		}, auditLog.entries[0])
+		assert.Equal(t, RoleAdmin, auditLog.entries[1].Required)
+		assert.Equal(t, "", auditLog.entries[2].UserID)
+	}
+}
+
+func TestNewAuthorizerRejectsUnknownRoles(t *testing.T) {
+	_, err := NewAuthorizer(Policy{"CreateTag": "owner"}, nil)
+	assert.Error(t, err)
+}
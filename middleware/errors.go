@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// ErrorMapper converts the errors returned by handlers into gRPC statuses. It
+// belongs first in the interceptor chain so it also sees the errors of every
+// other interceptor.
+type ErrorMapper func(err error) error
+
+// UnaryInterceptor maps the errors of unary calls.
+func (m ErrorMapper) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return nil, m(err)
+		}
+		return resp, nil
+	}
+}
+
+// StreamInterceptor maps the errors of streaming calls.
+func (m ErrorMapper) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := handler(srv, ss); err != nil {
+			return m(err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestErrorMapper(t *testing.T) {
+	errBoom := errors.New("boom")
+	m := ErrorMapper(func(err error) error {
+		return status.Error(codes.Internal, err.Error())
+	})
+	info := &grpc.UnaryServerInfo{FullMethod: "/forum.PostService/GetPost"}
+
+	_, err := m.UnaryInterceptor()(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		return nil, errBoom
+	})
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	resp, err := m.UnaryInterceptor()(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		return "ok", nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+}
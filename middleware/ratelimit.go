@@ -0,0 +1,114 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Forum-service/Forum-Service/ratelimit"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// RetryAfterHeader is the response header telling rate limited clients how
+// many seconds to wait before retrying.
+const RetryAfterHeader = "retry-after"
+
+// RateLimiter applies per-caller token bucket limits to unary RPCs. Calls
+// are limited per user only when they are authenticated; anonymous calls are
+// limited per peer address.
+type RateLimiter struct {
+	store  ratelimit.Store
+	limits map[string]ratelimit.Limit
+}
+
+// NewRateLimiter creates a RateLimiter. limits is keyed by full method name
+// such as /forum.PostService/CreatePost or by bare method name such as
+// CreatePost; methods without a limit are not limited.
+func NewRateLimiter(store ratelimit.Store, limits map[string]ratelimit.Limit) *RateLimiter {
+	return &RateLimiter{store: store, limits: limits}
+}
+
+// UnaryInterceptor rejects calls over the limit with ResourceExhausted and a
+// retry-after header. Calls are counted per method and per caller, so it
+// belongs after the Authenticator in the interceptor chain. A failing store
+// lets calls through.
+func (l *RateLimiter) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		limit, ok := l.limits[info.FullMethod]
+		if !ok {
+			limit, ok = l.limits[path.Base(info.FullMethod)]
+		}
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		key := info.FullMethod + ":" + caller(ctx)
+		allowed, retryAfter, err := l.store.Take(ctx, key, limit)
+		if err != nil {
+			log.Error().Err(err).Msg("RateLimiter: Error taking token, letting the call through")
+			return handler(ctx, req)
+		}
+		if !allowed {
+			seconds := int(math.Ceil(retryAfter.Seconds()))
+			if err := grpc.SetHeader(ctx, metadata.Pairs(RetryAfterHeader, strconv.Itoa(seconds))); err != nil {
+				log.Error().Err(err).Msg("RateLimiter: Error setting retry-after header")
+			}
+			return nil, status.Errorf(codes.ResourceExhausted, "rate limit of %d calls per %s exceeded, retry in %ds", limit.Requests, limit.Per, seconds)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// caller identifies who made a call: the authenticated user or else the peer
+// address. The user_id of a request is never used, since anyone could claim
+// a fresh one to get a fresh bucket.
+func caller(ctx context.Context) string {
+	if id, ok := IdentityFrom(ctx); ok {
+		return "user:" + id.UserID
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		host := p.Addr.String()
+		if i := strings.LastIndex(host, ":"); i > 0 {
+			host = host[:i]
+		}
+		return "addr:" + host
+	}
+	return "unknown"
+}
+
+// ParseLimits parses a comma separated list of method=requests/period
+// limits, for example "CreatePost=5/1h,CreateComment=30/10m".
+func ParseLimits(s string) (map[string]ratelimit.Limit, error) {
+	limits := map[string]ratelimit.Limit{}
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		method, spec, ok := strings.Cut(entry, "=")
+		requests, per, ok2 := strings.Cut(spec, "/")
+		if !ok || !ok2 {
+			return nil, fmt.Errorf("invalid rate limit %q, want method=requests/period", entry)
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(requests))
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("invalid request count in rate limit %q", entry)
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(per))
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid period in rate limit %q", entry)
+		}
+		limits[strings.TrimSpace(method)] = ratelimit.Limit{Requests: n, Per: d}
+	}
+	return limits, nil
+}
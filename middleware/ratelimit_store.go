@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Forum-service/Forum-Service/ratelimit"
+)
+
+// bucket is a token bucket as of updated.
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	per     time.Duration // Time to refill completely
+}
+
+// MemoryStore keeps token buckets in memory. It suits single instance
+// deployments; replicas each keep their own buckets.
+type MemoryStore struct {
+	now func() time.Time
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	takes   int
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{now: time.Now, buckets: map[string]*bucket{}}
+}
+
+// Take removes a token from the bucket key.
+func (s *MemoryStore) Take(_ context.Context, key string, limit ratelimit.Limit) (bool, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	capacity := float64(limit.Requests)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: capacity, updated: now, per: limit.Per}
+		s.buckets[key] = b
+	}
+	b.tokens = min(capacity, b.tokens+now.Sub(b.updated).Seconds()*limit.Rate())
+	b.updated = now
+
+	s.takes++
+	if s.takes%1000 == 0 {
+		s.sweep(now)
+	}
+
+	if b.tokens < 1 {
+		return false, time.Duration((1 - b.tokens) / limit.Rate() * float64(time.Second)), nil
+	}
+	b.tokens--
+	return true, 0, nil
+}
+
+// sweep drops buckets that have had time to refill completely, which behave
+// exactly like missing ones.
+func (s *MemoryStore) sweep(now time.Time) {
+	for key, b := range s.buckets {
+		if now.Sub(b.updated) > b.per {
+			delete(s.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/Forum-service/Forum-Service/ratelimit"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+type userRequest struct{ userID string }
+
+func (r *userRequest) GetUserId() string { return r.userID }
+
+func asUser(userID string) context.Context {
+	return WithIdentity(context.Background(), &Identity{UserID: userID})
+}
+
+func fromAddr(ip string) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 4242}})
+}
+
+func TestMemoryStoreTake(t *testing.T) {
+	now := time.Now()
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+	limit := ratelimit.Limit{Requests: 2, Per: time.Minute}
+
+	for i := 0; i < 2; i++ {
+		allowed, _, err := store.Take(context.Background(), "k", limit)
+		assert.NoError(t, err)
+		assert.True(t, allowed)
+	}
+
+	allowed, retryAfter, err := store.Take(context.Background(), "k", limit)
+	assert.NoError(t, err)
+	assert.False(t, allowed)
+	assert.Equal(t, 30*time.Second, retryAfter)
+
+	// Other keys have their own bucket
+	allowed, _, _ = store.Take(context.Background(), "other", limit)
+	assert.True(t, allowed)
+
+	// One token is back after half the period
+	now = now.Add(30 * time.Second)
+	allowed, _, _ = store.Take(context.Background(), "k", limit)
+	assert.True(t, allowed)
+	allowed, _, _ = store.Take(context.Background(), "k", limit)
+	assert.False(t, allowed)
+}
+
+func TestRateLimiterInterceptor(t *testing.T) {
+	limiter := NewRateLimiter(NewMemoryStore(), map[string]ratelimit.Limit{"CreatePost": {Requests: 1, Per: time.Hour}})
+	intercept := limiter.UnaryInterceptor()
+	handler := func(ctx context.Context, req any) (any, error) { return "ok", nil }
+	createPost := &grpc.UnaryServerInfo{FullMethod: "/forum.PostService/CreatePost"}
+
+	resp, err := intercept(asUser("u1"), &userRequest{userID: "u1"}, createPost, handler)
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+
+	_, err = intercept(asUser("u1"), &userRequest{userID: "u1"}, createPost, handler)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// Limits are per user and per method
+	_, err = intercept(asUser("u2"), &userRequest{userID: "u2"}, createPost, handler)
+	assert.NoError(t, err)
+
+	getPost := &grpc.UnaryServerInfo{FullMethod: "/forum.PostService/GetPost"}
+	_, err = intercept(asUser("u1"), &userRequest{userID: "u1"}, getPost, handler)
+	assert.NoError(t, err)
+
+	// Unauthenticated calls are limited per address, whatever user they claim
+	_, err = intercept(fromAddr("10.0.0.1"), &userRequest{userID: "u3"}, createPost, handler)
+	assert.NoError(t, err)
+	_, err = intercept(fromAddr("10.0.0.1"), &userRequest{userID: "u4"}, createPost, handler)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	_, err = intercept(fromAddr("10.0.0.2"), &userRequest{userID: "u4"}, createPost, handler)
+	assert.NoError(t, err)
+}
+
+func TestParseLimits(t *testing.T) {
+	limits, err := ParseLimits("CreatePost=5/1h, /forum.CommentService/CreateComment=30/10m,")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]ratelimit.Limit{
+		"CreatePost":                          {Requests: 5, Per: time.Hour},
+		"/forum.CommentService/CreateComment": {Requests: 30, Per: 10 * time.Minute},
+	}, limits)
+
+	for _, invalid := range []string{"CreatePost", "CreatePost=5", "CreatePost=x/1h", "CreatePost=5/soon", "CreatePost=0/1h"} {
+		_, err := ParseLimits(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
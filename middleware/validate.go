@@ -0,0 +1,210 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// FieldRules constrain one field of a request message. Unset strings and
+// numbers only fail Required.
+type FieldRules struct {
+	Required bool     // Must be set, lists must not be empty
+	UUID     bool     // Every value must be a UUID
+	MaxLen   int      // Most characters in a string, 0 for no limit
+	In       []string // Allowed values of a string
+	Max      int64    // Largest number allowed, 0 for no limit
+}
+
+// Rules maps message full names, such as forum.CreatePostRequest, to the
+// rules of their fields by field name.
+//
+// On top of them every request follows naming conventions: id, ids, *_id
+// and *_ids fields hold UUIDs, id fields are required, and page and limit
+// fields are never negative, with limit capped at the validator's page size
+// unless a Max is declared.
+type Rules map[string]map[string]FieldRules
+
+// Validator rejects requests breaking their Rules with InvalidArgument and a
+// BadRequest detail listing every field violation.
+type Validator struct {
+	rules       Rules
+	maxPageSize int64
+}
+
+// NewValidator creates a Validator. It fails if rules name a message or
+// field that does not exist, so typos do not silently disable a rule.
+func NewValidator(rules Rules, maxPageSize int) (*Validator, error) {
+	for name, fields := range rules {
+		d, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(name))
+		if err != nil {
+			return nil, fmt.Errorf("validation rules for unknown message %s", name)
+		}
+		md, ok := d.(protoreflect.MessageDescriptor)
+		if !ok {
+			return nil, fmt.Errorf("validation rules for %s, which is not a message", name)
+		}
+		for field := range fields {
+			if md.Fields().ByName(protoreflect.Name(field)) == nil {
+				return nil, fmt.Errorf("validation rules for unknown field %s.%s", name, field)
+			}
+		}
+	}
+	return &Validator{rules: rules, maxPageSize: int64(maxPageSize)}, nil
+}
+
+// UnaryInterceptor validates the requests of unary calls.
+func (v *Validator) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := v.Validate(req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor validates every message received on streaming calls.
+func (v *Validator) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &validatingStream{ServerStream: ss, v: v})
+	}
+}
+
+// Validate returns an InvalidArgument status if req breaks its rules. Values
+// that are not proto messages pass.
+func (v *Validator) Validate(req any) error {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return nil
+	}
+
+	violations := v.check(msg.ProtoReflect())
+	if len(violations) == 0 {
+		return nil
+	}
+	first := violations[0]
+	st, err := status.New(codes.InvalidArgument, first.Field+": "+first.Description).
+		WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if err != nil {
+		return status.Error(codes.InvalidArgument, first.Field+": "+first.Description)
+	}
+	return st.Err()
+}
+
+func (v *Validator) check(msg protoreflect.Message) []*errdetails.BadRequest_FieldViolation {
+	var violations []*errdetails.BadRequest_FieldViolation
+	violate := func(field, description string) {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: field, Description: description})
+	}
+
+	md := msg.Descriptor()
+	declared := v.rules[string(md.FullName())]
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		name := string(fd.Name())
+		rules := v.withConventions(name, declared[name])
+
+		if fd.IsList() {
+			list := msg.Get(fd).List()
+			if rules.Required && list.Len() == 0 {
+				violate(name, "is required")
+			}
+			if fd.Kind() != protoreflect.StringKind {
+				continue
+			}
+			for j := 0; j < list.Len(); j++ {
+				if desc := rules.checkString(list.Get(j).String()); desc != "" {
+					violate(fmt.Sprintf("%s[%d]", name, j), desc)
+				}
+			}
+			continue
+		}
+
+		switch fd.Kind() {
+		case protoreflect.StringKind:
+			value := msg.Get(fd).String()
+			if value == "" {
+				if rules.Required {
+					violate(name, "is required")
+				}
+				continue
+			}
+			if desc := rules.checkString(value); desc != "" {
+				violate(name, desc)
+			}
+		case protoreflect.Int32Kind, protoreflect.Int64Kind:
+			value := msg.Get(fd).Int()
+			switch {
+			case rules.Required && !msg.Has(fd):
+				violate(name, "is required")
+			case (name == "page" || name == "limit") && value < 0:
+				violate(name, "must not be negative")
+			case rules.Max > 0 && value > rules.Max:
+				violate(name, fmt.Sprintf("must be at most %d", rules.Max))
+			}
+		}
+	}
+	return violations
+}
+
+// withConventions adds the rules implied by the name of a field.
+func (v *Validator) withConventions(name string, rules FieldRules) FieldRules {
+	if name == "id" || name == "ids" || strings.HasSuffix(name, "_id") || strings.HasSuffix(name, "_ids") {
+		rules.UUID = true
+	}
+	if name == "id" {
+		rules.Required = true
+	}
+	if name == "limit" && rules.Max == 0 {
+		rules.Max = v.maxPageSize
+	}
+	return rules
+}
+
+// checkString returns why a non-empty value breaks the rules, or an empty
+// string if it does not.
+func (r FieldRules) checkString(value string) string {
+	switch {
+	case r.UUID && !isUUID(value):
+		return "must be a UUID"
+	case r.MaxLen > 0 && utf8.RuneCountInString(value) > r.MaxLen:
+		return fmt.Sprintf("must be at most %d characters", r.MaxLen)
+	case len(r.In) > 0 && !slices.Contains(r.In, value):
+		return "must be one of " + strings.Join(r.In, ", ")
+	}
+	return ""
+}
+
+// isUUID reports whether s is a UUID in its canonical hyphenated form.
+func isUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	_, err := uuid.Parse(s)
+	return err == nil
+}
+
+// validatingStream is a ServerStream that validates the messages it receives.
+type validatingStream struct {
+	grpc.ServerStream
+	v *Validator
+}
+
+func (s *validatingStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.v.Validate(m)
+}
@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Forum-service/Forum-Service/genproto/post"
+	"github.com/Forum-service/Forum-Service/genproto/report"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newTestValidator(t *testing.T) *Validator {
+	v, err := NewValidator(Rules{
+		"forum.CreatePostRequest": {
+			"title":       {Required: true, MaxLen: 10},
+			"category_id": {Required: true},
+		},
+		"forum.ReportContentRequest": {
+			"target_type": {In: []string{"post", "comment"}},
+		},
+	}, 50)
+	if err != nil {
+		t.Fatalf("Error creating validator: %v", err)
+	}
+	return v
+}
+
+// violations returns the fields named by the BadRequest detail of err.
+func violations(t *testing.T, err error) []string {
+	st := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+
+	var fields []string
+	for _, d := range st.Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			for _, fv := range br.FieldViolations {
+				fields = append(fields, fv.Field)
+			}
+		}
+	}
+	return fields
+}
+
+func TestValidatorRules(t *testing.T) {
+	v := newTestValidator(t)
+
+	assert.NoError(t, v.Validate(&post.CreatePostRequest{Title: "Hello", CategoryId: uuid.New().String()}))
+
+	err := v.Validate(&post.CreatePostRequest{UserId: "not-a-uuid", Title: strings.Repeat("é", 11)})
+	assert.ElementsMatch(t, []string{"user_id", "title", "category_id"}, violations(t, err))
+
+	err = v.Validate(&report.ReportContentRequest{TargetType: "user"})
+	assert.Equal(t, []string{"target_type"}, violations(t, err))
+
+	// Length limits count characters, not bytes
+	assert.NoError(t, v.Validate(&post.CreatePostRequest{Title: strings.Repeat("é", 10), CategoryId: uuid.New().String()}))
+}
+
+func TestValidatorConventions(t *testing.T) {
+	v := newTestValidator(t)
+
+	err := v.Validate(&post.GetPostRequest{})
+	assert.Equal(t, []string{"id"}, violations(t, err))
+
+	err = v.Validate(&post.GetAllPostsRequest{Page: -1, Limit: 51})
+	assert.Equal(t, []string{"page", "limit"}, violations(t, err))
+	assert.NoError(t, v.Validate(&post.GetAllPostsRequest{Limit: 50}))
+
+	err = v.Validate(&post.DeletePostRequest{Id: "{" + uuid.New().String() + "}"})
+	assert.Equal(t, []string{"id"}, violations(t, err))
+}
+
+func TestNewValidatorRejectsUnknownNames(t *testing.T) {
+	_, err := NewValidator(Rules{"forum.NoSuchRequest": {}}, 50)
+	assert.Error(t, err)
+
+	_, err = NewValidator(Rules{"forum.CreatePostRequest": {"headline": {Required: true}}}, 50)
+	assert.Error(t, err)
+}
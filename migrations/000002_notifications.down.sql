@@ -0,0 +1,8 @@
+-- Drop tables in reverse order of creation
+DROP TABLE IF EXISTS category_follows;
+DROP TABLE IF EXISTS notification_preferences;
+DROP TABLE IF EXISTS notifications;
+
+ALTER TABLE comments
+    DROP CONSTRAINT IF EXISTS fk_comments_parent_id,
+    DROP COLUMN IF EXISTS parent_id;
@@ -0,0 +1,45 @@
+-- 1. Allow comments to reply to other comments
+ALTER TABLE comments
+    ADD COLUMN parent_id UUID,
+    ADD CONSTRAINT fk_comments_parent_id FOREIGN KEY (parent_id) REFERENCES comments(id);
+
+-- 2. Create Notifications Table
+CREATE TABLE notifications (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    type VARCHAR(32) NOT NULL,
+    target_id UUID NOT NULL,
+    post_id UUID,
+    comment_id UUID,
+    category_id UUID,
+    actor_id UUID NOT NULL,
+    count INT NOT NULL DEFAULT 1,
+    is_read BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITHOUT TIME ZONE DEFAULT NOW()
+);
+
+-- Only one unread notification per recipient, type and target so bursts coalesce
+CREATE UNIQUE INDEX idx_notifications_unread_target
+    ON notifications (user_id, type, target_id)
+    WHERE is_read = FALSE;
+
+CREATE INDEX idx_notifications_user_id ON notifications (user_id, updated_at DESC);
+
+-- 3. Create Notification Preferences Table
+CREATE TABLE notification_preferences (
+    user_id UUID NOT NULL,
+    type VARCHAR(32) NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_at TIMESTAMP WITHOUT TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (user_id, type)
+);
+
+-- 4. Create Category Follows Table
+CREATE TABLE category_follows (
+    user_id UUID NOT NULL,
+    category_id UUID NOT NULL,
+    created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (user_id, category_id),
+    CONSTRAINT fk_category_follows_category_id FOREIGN KEY (category_id) REFERENCES categories(id)
+);
@@ -0,0 +1,21 @@
+-- 1. Create Outbox Events Table
+-- Scheduling columns are compared against timestamps computed by the relay,
+-- so they carry a time zone unlike the rest of the schema.
+CREATE TABLE outbox_events (
+    id UUID PRIMARY KEY,
+    type VARCHAR(64) NOT NULL,
+    aggregate_type VARCHAR(32) NOT NULL,
+    aggregate_id VARCHAR(64) NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    locked_until TIMESTAMP WITH TIME ZONE,
+    published_at TIMESTAMP WITH TIME ZONE,
+    last_error TEXT
+);
+
+-- The relay only ever scans unpublished events
+CREATE INDEX idx_outbox_events_pending
+    ON outbox_events (next_attempt_at, created_at)
+    WHERE published_at IS NULL;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_comments_post_id_updated_at;
+DROP TRIGGER IF EXISTS trg_comments_notify ON comments;
+DROP FUNCTION IF EXISTS notify_comment_change();
@@ -0,0 +1,32 @@
+-- Broadcast every comment change on the comment_events channel so each
+-- service replica can push it to clients watching the post.
+CREATE OR REPLACE FUNCTION notify_comment_change() RETURNS TRIGGER AS $$
+DECLARE
+    op TEXT;
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        op := 'created';
+    ELSIF NEW.deleted_at <> 0 AND OLD.deleted_at = 0 THEN
+        op := 'deleted';
+    ELSIF NEW.deleted_at = 0 THEN
+        op := 'updated';
+    ELSE
+        RETURN NEW;
+    END IF;
+
+    PERFORM pg_notify('comment_events', json_build_object(
+        'type', op,
+        'id', NEW.id,
+        'post_id', NEW.post_id,
+        'updated_at', NEW.updated_at
+    )::text);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_comments_notify
+    AFTER INSERT OR UPDATE ON comments
+    FOR EACH ROW EXECUTE FUNCTION notify_comment_change();
+
+-- Replaying changes since a cursor scans comments of a post by updated_at
+CREATE INDEX idx_comments_post_id_updated_at ON comments (post_id, updated_at);
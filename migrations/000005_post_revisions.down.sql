@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS post_revisions;
+
+ALTER TABLE posts
+    DROP COLUMN IF EXISTS revision_count;
@@ -0,0 +1,18 @@
+-- 1. Track how often a post was edited
+ALTER TABLE posts
+    ADD COLUMN revision_count INT NOT NULL DEFAULT 0;
+
+-- 2. Create Post Revisions Table
+-- Each row holds the content a post had before one of its edits.
+CREATE TABLE post_revisions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    post_id UUID NOT NULL,
+    revision INT NOT NULL,
+    editor_id UUID,
+    title VARCHAR(100) NOT NULL,
+    body TEXT NOT NULL,
+    category_id UUID NOT NULL,
+    created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT NOW(),
+    CONSTRAINT fk_post_revisions_post_id FOREIGN KEY (post_id) REFERENCES posts(id),
+    CONSTRAINT uq_post_revisions_post_id_revision UNIQUE (post_id, revision)
+);
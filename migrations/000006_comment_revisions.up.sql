@@ -0,0 +1,17 @@
+-- 1. Remember when a comment was last edited
+ALTER TABLE comments
+    ADD COLUMN edited_at TIMESTAMP WITHOUT TIME ZONE,
+    ADD COLUMN revision_count INT NOT NULL DEFAULT 0;
+
+-- 2. Create Comment Revisions Table
+-- Each row holds the body a comment had before one of its edits.
+CREATE TABLE comment_revisions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    comment_id UUID NOT NULL,
+    revision INT NOT NULL,
+    editor_id UUID,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT NOW(),
+    CONSTRAINT fk_comment_revisions_comment_id FOREIGN KEY (comment_id) REFERENCES comments(id),
+    CONSTRAINT uq_comment_revisions_comment_id_revision UNIQUE (comment_id, revision)
+);
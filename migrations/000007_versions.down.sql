@@ -0,0 +1,11 @@
+ALTER TABLE comments
+    DROP COLUMN IF EXISTS version;
+
+ALTER TABLE posts
+    DROP COLUMN IF EXISTS version;
+
+ALTER TABLE tags
+    DROP COLUMN IF EXISTS version;
+
+ALTER TABLE categories
+    DROP COLUMN IF EXISTS version;
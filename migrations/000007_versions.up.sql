@@ -0,0 +1,12 @@
+-- Every update bumps version so concurrent editors can detect lost updates
+ALTER TABLE categories
+    ADD COLUMN version INT NOT NULL DEFAULT 1;
+
+ALTER TABLE tags
+    ADD COLUMN version INT NOT NULL DEFAULT 1;
+
+ALTER TABLE posts
+    ADD COLUMN version INT NOT NULL DEFAULT 1;
+
+ALTER TABLE comments
+    ADD COLUMN version INT NOT NULL DEFAULT 1;
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_categories_parent_id;
+
+ALTER TABLE categories
+    DROP CONSTRAINT IF EXISTS chk_categories_parent_id,
+    DROP CONSTRAINT IF EXISTS fk_categories_parent_id,
+    DROP COLUMN IF EXISTS parent_id;
@@ -0,0 +1,7 @@
+-- Categories can be nested to form sub-forums
+ALTER TABLE categories
+    ADD COLUMN parent_id UUID,
+    ADD CONSTRAINT fk_categories_parent_id FOREIGN KEY (parent_id) REFERENCES categories(id),
+    ADD CONSTRAINT chk_categories_parent_id CHECK (parent_id <> id);
+
+CREATE INDEX idx_categories_parent_id ON categories (parent_id);
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS uq_categories_slug;
+
+ALTER TABLE categories
+    DROP CONSTRAINT IF EXISTS chk_categories_color,
+    DROP COLUMN IF EXISTS position,
+    DROP COLUMN IF EXISTS color,
+    DROP COLUMN IF EXISTS icon,
+    DROP COLUMN IF EXISTS description,
+    DROP COLUMN IF EXISTS slug;
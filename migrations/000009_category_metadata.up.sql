@@ -0,0 +1,38 @@
+-- 1. Describe categories and let them be ordered by hand
+ALTER TABLE categories
+    ADD COLUMN slug TEXT,
+    ADD COLUMN description TEXT NOT NULL DEFAULT '',
+    ADD COLUMN icon TEXT NOT NULL DEFAULT '',
+    ADD COLUMN color TEXT NOT NULL DEFAULT '',
+    ADD COLUMN position INT NOT NULL DEFAULT 0,
+    ADD CONSTRAINT chk_categories_color CHECK (color = '' OR color ~ '^#[0-9a-fA-F]{6}$');
+
+-- 2. Derive slugs for existing categories, disambiguating duplicates with the ID
+UPDATE categories
+SET slug = trim(both '-' from regexp_replace(lower(name), '[^a-z0-9]+', '-', 'g'));
+
+UPDATE categories
+SET slug = id::text
+WHERE slug = '';
+
+UPDATE categories c
+SET slug = c.slug || '-' || left(c.id::text, 8)
+WHERE EXISTS (
+    SELECT 1 FROM categories o
+    WHERE o.slug = c.slug AND o.id <> c.id AND o.deleted_at = 0
+);
+
+ALTER TABLE categories
+    ALTER COLUMN slug SET NOT NULL;
+
+-- Deleted categories give up their slug
+CREATE UNIQUE INDEX uq_categories_slug ON categories (slug) WHERE deleted_at = 0;
+
+-- 3. Keep the current alphabetical order among siblings
+UPDATE categories c
+SET position = o.position
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY parent_id ORDER BY name) AS position
+    FROM categories
+) o
+WHERE c.id = o.id;
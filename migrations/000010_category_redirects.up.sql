@@ -0,0 +1,10 @@
+-- Categories merged into another one keep resolving to their target
+CREATE TABLE category_redirects (
+    old_category_id UUID PRIMARY KEY,
+    target_category_id UUID NOT NULL,
+    created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT NOW(),
+    CONSTRAINT fk_category_redirects_old_category_id FOREIGN KEY (old_category_id) REFERENCES categories(id),
+    CONSTRAINT fk_category_redirects_target_category_id FOREIGN KEY (target_category_id) REFERENCES categories(id)
+);
+
+CREATE INDEX idx_category_redirects_target_category_id ON category_redirects (target_category_id);
@@ -0,0 +1,12 @@
+-- Alternative names that resolve to a canonical tag, filled when tags are merged
+CREATE TABLE tag_synonyms (
+    name TEXT PRIMARY KEY, -- lower-cased
+    tag_id UUID NOT NULL,
+    source_tag_id UUID,
+    created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT NOW(),
+    CONSTRAINT fk_tag_synonyms_tag_id FOREIGN KEY (tag_id) REFERENCES tags(id),
+    CONSTRAINT fk_tag_synonyms_source_tag_id FOREIGN KEY (source_tag_id) REFERENCES tags(id)
+);
+
+CREATE INDEX idx_tag_synonyms_tag_id ON tag_synonyms (tag_id);
+CREATE INDEX idx_tag_synonyms_source_tag_id ON tag_synonyms (source_tag_id);
@@ -0,0 +1,6 @@
+ALTER TABLE tags
+    DROP CONSTRAINT IF EXISTS chk_tags_color,
+    DROP COLUMN IF EXISTS restricted,
+    DROP COLUMN IF EXISTS color,
+    DROP COLUMN IF EXISTS guidance,
+    DROP COLUMN IF EXISTS description;
@@ -0,0 +1,7 @@
+-- Explain tags to users and let moderators reserve some of them
+ALTER TABLE tags
+    ADD COLUMN description TEXT NOT NULL DEFAULT '',
+    ADD COLUMN guidance TEXT NOT NULL DEFAULT '',
+    ADD COLUMN color TEXT NOT NULL DEFAULT '',
+    ADD COLUMN restricted BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD CONSTRAINT chk_tags_color CHECK (color = '' OR color ~ '^#[0-9a-fA-F]{6}$');
@@ -0,0 +1,26 @@
+-- 1. Moderators can hide content without deleting it
+ALTER TABLE posts
+    ADD COLUMN hidden BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE comments
+    ADD COLUMN hidden BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- 2. Create Reports Table
+CREATE TABLE reports (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    target_type VARCHAR(16) NOT NULL CHECK (target_type IN ('post', 'comment')),
+    target_id UUID NOT NULL,
+    reporter_id UUID NOT NULL,
+    reason VARCHAR(64) NOT NULL,
+    details TEXT NOT NULL DEFAULT '',
+    status VARCHAR(16) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'resolved')),
+    resolution VARCHAR(16) CHECK (resolution IN ('dismiss', 'hide', 'delete')),
+    resolved_by UUID,
+    resolution_note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT NOW(),
+    resolved_at TIMESTAMP WITHOUT TIME ZONE
+);
+
+-- A user can only have one open report per piece of content
+CREATE UNIQUE INDEX uq_reports_open_reporter ON reports (target_type, target_id, reporter_id) WHERE status = 'open';
+CREATE INDEX idx_reports_open_target ON reports (target_type, target_id) WHERE status = 'open';
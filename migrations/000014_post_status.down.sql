@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_posts_category_id_pinned;
+
+ALTER TABLE posts
+    DROP CONSTRAINT IF EXISTS chk_posts_duplicate_of_id,
+    DROP CONSTRAINT IF EXISTS fk_posts_duplicate_of_id,
+    DROP COLUMN IF EXISTS duplicate_of_id,
+    DROP COLUMN IF EXISTS close_reason,
+    DROP COLUMN IF EXISTS closed,
+    DROP COLUMN IF EXISTS locked,
+    DROP COLUMN IF EXISTS pinned;
@@ -0,0 +1,11 @@
+-- Moderation status of posts
+ALTER TABLE posts
+    ADD COLUMN pinned BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN locked BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN closed BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN close_reason TEXT NOT NULL DEFAULT '',
+    ADD COLUMN duplicate_of_id UUID,
+    ADD CONSTRAINT fk_posts_duplicate_of_id FOREIGN KEY (duplicate_of_id) REFERENCES posts(id),
+    ADD CONSTRAINT chk_posts_duplicate_of_id CHECK (duplicate_of_id <> id);
+
+CREATE INDEX idx_posts_category_id_pinned ON posts (category_id, pinned DESC, created_at DESC) WHERE deleted_at = 0;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_automod_rules_enabled;
+
+DROP TABLE IF EXISTS automod_rules;
@@ -0,0 +1,22 @@
+-- Moderator-defined automod rules
+CREATE TABLE automod_rules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(100) NOT NULL,
+    pattern TEXT NOT NULL,
+    field VARCHAR(16) NOT NULL DEFAULT 'any' CHECK (field IN ('any', 'title', 'body')),
+    category_id UUID,
+    action VARCHAR(16) NOT NULL CHECK (action IN ('hold', 'reject', 'tag')),
+    tag_id UUID,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    hit_count INT NOT NULL DEFAULT 0,
+    last_hit_at TIMESTAMP WITHOUT TIME ZONE,
+    created_by UUID,
+    created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITHOUT TIME ZONE DEFAULT NOW(),
+    deleted_at BIGINT DEFAULT 0,
+    CONSTRAINT fk_automod_rules_category_id FOREIGN KEY (category_id) REFERENCES categories(id),
+    CONSTRAINT fk_automod_rules_tag_id FOREIGN KEY (tag_id) REFERENCES tags(id),
+    CONSTRAINT chk_automod_rules_tag_id CHECK (action <> 'tag' OR tag_id IS NOT NULL)
+);
+
+CREATE INDEX idx_automod_rules_enabled ON automod_rules (category_id) WHERE enabled AND deleted_at = 0;
@@ -0,0 +1,7 @@
+-- Token buckets shared by all replicas for rate limiting
+CREATE TABLE rate_limit_buckets (
+    key TEXT PRIMARY KEY,
+    tokens DOUBLE PRECISION NOT NULL,
+    allowed BOOLEAN NOT NULL, -- Whether the last take got a token
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,11 @@
+-- Calls denied by the authorization policy
+CREATE TABLE audit_log (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id TEXT NOT NULL DEFAULT '', -- Token subject, empty for unauthenticated callers
+    roles TEXT[] NOT NULL DEFAULT '{}',
+    method TEXT NOT NULL,
+    required_role VARCHAR(16) NOT NULL,
+    created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_audit_log_user_id ON audit_log (user_id, created_at);
@@ -0,0 +1,11 @@
+-- Users who moderate a category and its sub-categories
+CREATE TABLE category_moderators (
+    category_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    assigned_by UUID,
+    created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (category_id, user_id),
+    CONSTRAINT fk_category_moderators_category_id FOREIGN KEY (category_id) REFERENCES categories(id)
+);
+
+CREATE INDEX idx_category_moderators_user_id ON category_moderators (user_id);
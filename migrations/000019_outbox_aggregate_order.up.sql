@@ -0,0 +1,8 @@
+-- Number events in insertion order, so events of one aggregate are published
+-- in the order they were written even when their created_at values tie
+ALTER TABLE outbox_events ADD COLUMN seq BIGSERIAL;
+
+-- The relay holds back events while an earlier one of their aggregate is unpublished
+CREATE INDEX idx_outbox_events_pending_aggregate
+    ON outbox_events (aggregate_type, aggregate_id, seq)
+    WHERE published_at IS NULL;
@@ -0,0 +1,4 @@
+-- Buckets are full again once expires_at passes, so they can be pruned
+ALTER TABLE rate_limit_buckets ADD COLUMN expires_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW();
+
+CREATE INDEX idx_rate_limit_buckets_expires_at ON rate_limit_buckets (expires_at);
@@ -0,0 +1,28 @@
+// Package ratelimit holds the rate limits and the store interface shared by
+// the rate limiting middleware and the stores keeping its token buckets.
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Limit is a token bucket holding Requests tokens that refills completely
+// over Per, so Requests calls are allowed in a burst and Requests per Per on
+// average.
+type Limit struct {
+	Requests int
+	Per      time.Duration
+}
+
+// Rate returns the refill rate in tokens per second.
+func (l Limit) Rate() float64 {
+	return float64(l.Requests) / l.Per.Seconds()
+}
+
+// Store keeps the token buckets the rate limiting middleware takes from.
+type Store interface {
+	// Take removes a token from the bucket key, which starts out full. When
+	// the bucket is empty it reports false and the time until the next token.
+	Take(ctx context.Context, key string, limit Limit) (bool, time.Duration, error)
+}
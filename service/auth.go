@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+
+	"github.com/Forum-service/Forum-Service/genproto/post"
+	"github.com/Forum-service/Forum-Service/middleware"
+	"github.com/Forum-service/Forum-Service/storage"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// actingUser returns the user a request acts as. An authenticated caller
+// fills in an empty claimed user and may not claim to be someone else.
+// Without authentication, as in deployments that run without it, claimed is
+// trusted.
+func actingUser(ctx context.Context, claimed string) (string, error) {
+	id, ok := middleware.IdentityFrom(ctx)
+	if !ok {
+		return claimed, nil
+	}
+	if claimed != "" && claimed != id.UserID {
+		return "", status.Error(codes.PermissionDenied, "requests can only act as the authenticated user")
+	}
+	return id.UserID, nil
+}
+
+// checkOwner returns PermissionDenied unless the authenticated caller is
+// ownerID or moderates the category of postID. Ownership is not checked
+// without authentication, which deployments only run with AUTH_DISABLED.
+func checkOwner(ctx context.Context, stg storage.StorageI, ownerID, postID string) error {
+	id, ok := middleware.IdentityFrom(ctx)
+	if !ok || id.UserID == ownerID {
+		return nil
+	}
+	moderator, err := moderatesPost(ctx, stg, postID, false)
+	if err != nil {
+		return err
+	}
+	if !moderator {
+		return status.Error(codes.PermissionDenied, "only the author or a moderator can change this content")
+	}
+	return nil
+}
+
+// checkModerator returns PermissionDenied unless the authenticated caller
+// moderates the category of postID. Like ownership, moderation is not checked
+// without authentication.
+func checkModerator(ctx context.Context, stg storage.StorageI, postID string) error {
+	if _, ok := middleware.IdentityFrom(ctx); !ok {
+		return nil
+	}
+	moderator, err := moderatesPost(ctx, stg, postID, false)
+	if err != nil {
+		return err
+	}
+	if !moderator {
+		return status.Error(codes.PermissionDenied, "only moderators of the post's category can do this")
+	}
+	return nil
+}
+
+// checkHiddenVisible returns a NotFoundError for resource when content is
+// hidden and the caller is neither its author nor a moderator of the category
+// of postID, so hidden content reads as missing. Without authentication hidden content is never
+// visible.
+func checkHiddenVisible(ctx context.Context, stg storage.StorageI, hidden bool, authorID, postID, resource string) error {
+	if !hidden {
+		return nil
+	}
+	if id, ok := middleware.IdentityFrom(ctx); ok && id.UserID == authorID {
+		return nil
+	}
+	moderator, err := moderatesPost(ctx, stg, postID, false)
+	if err != nil {
+		return err
+	}
+	if !moderator {
+		return &storage.NotFoundError{Resource: resource}
+	}
+	return nil
+}
+
+// moderatesPost reports whether the caller moderates the category of postID,
+// either through a global moderator role or as a moderator of the category or
+// one of its parents. Without authentication it returns claimed, which callers
+// take from the moderator flag of the request or set to false.
+func moderatesPost(ctx context.Context, stg storage.StorageI, postID string, claimed bool) (bool, error) {
+	id, ok := middleware.IdentityFrom(ctx)
+	if !ok {
+		return claimed, nil
+	}
+	if id.HasRole(middleware.RoleModerator) {
+		return true, nil
+	}
+
+	p, err := stg.Post().GetById(ctx, &post.GetPostRequest{Id: postID})
+	if err != nil {
+		log.Error().Err(err).Msg("Error getting post to check its moderators")
+		return false, err
+	}
+	return moderatesCategory(ctx, stg, p.Post.CategoryId, claimed)
+}
+
+// moderatesCategory reports whether the caller moderates categoryID, either
+// through a global moderator role or as a moderator of the category or one of
+// its parents. Without authentication it returns claimed.
+func moderatesCategory(ctx context.Context, stg storage.StorageI, categoryID string, claimed bool) (bool, error) {
+	id, ok := middleware.IdentityFrom(ctx)
+	if !ok {
+		return claimed, nil
+	}
+	if id.HasRole(middleware.RoleModerator) {
+		return true, nil
+	}
+	if categoryID == "" {
+		return false, nil
+	}
+	return stg.Category().IsModerator(ctx, categoryID, id.UserID)
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Forum-service/Forum-Service/genproto/post"
+	"github.com/Forum-service/Forum-Service/middleware"
+	"github.com/Forum-service/Forum-Service/storage"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestActingUser(t *testing.T) {
+	// Without authentication the claimed user is trusted
+	user, err := actingUser(context.Background(), "claimed")
+	assert.NoError(t, err)
+	assert.Equal(t, "claimed", user)
+
+	ctx := middleware.WithIdentity(context.Background(), &middleware.Identity{UserID: "me"})
+	user, err = actingUser(ctx, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "me", user)
+
+	user, err = actingUser(ctx, "me")
+	assert.NoError(t, err)
+	assert.Equal(t, "me", user)
+
+	_, err = actingUser(ctx, "someone-else")
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestCheckOwner(t *testing.T) {
+	// Ownership is not checked without authentication
+	assert.NoError(t, checkOwner(context.Background(), nil, "anyone", "post"))
+	assert.NoError(t, checkModerator(context.Background(), nil, "post"))
+
+	ctx := middleware.WithIdentity(context.Background(), &middleware.Identity{UserID: "me"})
+	assert.NoError(t, checkOwner(ctx, nil, "me", "post"))
+
+	moderator := middleware.WithIdentity(context.Background(), &middleware.Identity{UserID: "mod", Roles: []string{middleware.RoleModerator}})
+	assert.NoError(t, checkOwner(moderator, nil, "someone-else", "post"))
+}
+
+func TestModeratesPost(t *testing.T) {
+	// Without authentication the claimed flag is trusted
+	moderator, err := moderatesPost(context.Background(), nil, "post", true)
+	assert.NoError(t, err)
+	assert.True(t, moderator)
+	moderator, err = moderatesPost(context.Background(), nil, "post", false)
+	assert.NoError(t, err)
+	assert.False(t, moderator)
+
+	// Global moderators moderate every category
+	admin := middleware.WithIdentity(context.Background(), &middleware.Identity{UserID: "me", Roles: []string{middleware.RoleAdmin}})
+	moderator, err = moderatesPost(admin, nil, "post", false)
+	assert.NoError(t, err)
+	assert.True(t, moderator)
+	assert.NoError(t, checkModerator(admin, nil, "post"))
+}
+
+func TestCheckHiddenVisible(t *testing.T) {
+	assert.NoError(t, checkHiddenVisible(context.Background(), nil, false, "author", "post", "post"))
+
+	// Hidden content reads as missing unless the caller wrote or moderates it
+	err := checkHiddenVisible(context.Background(), nil, true, "author", "post", "post")
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+
+	author := middleware.WithIdentity(context.Background(), &middleware.Identity{UserID: "author"})
+	assert.NoError(t, checkHiddenVisible(author, nil, true, "author", "post", "post"))
+
+	moderator := middleware.WithIdentity(context.Background(), &middleware.Identity{UserID: "mod", Roles: []string{middleware.RoleModerator}})
+	assert.NoError(t, checkHiddenVisible(moderator, nil, true, "author", "post", "post"))
+}
+
+func TestHiddenPostRevisions(t *testing.T) {
+	stg := newFakeStorage()
+	stg.posts.posts["p1"] = &post.Post{Id: "p1", UserId: "author", Hidden: true}
+	s := NewPostService(stg, nil)
+
+	_, err := s.GetPostRevisions(context.Background(), &post.GetPostRevisionsRequest{PostId: "p1"})
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+	_, err = s.GetPostRevisionDiff(context.Background(), &post.GetPostRevisionDiffRequest{PostId: "p1", ToRevision: 1})
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+}
+
+func TestPolicyRoles(t *testing.T) {
+	_, err := middleware.NewAuthorizer(Policy, nil)
+	assert.NoError(t, err)
+}
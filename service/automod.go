@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/Forum-service/Forum-Service/events"
+	"github.com/Forum-service/Forum-Service/genproto/automod"
+	"github.com/Forum-service/Forum-Service/genproto/posttag"
+	"github.com/Forum-service/Forum-Service/genproto/report"
+	"github.com/Forum-service/Forum-Service/storage"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Fields an automod rule can match.
+const (
+	automodFieldAny   = "any"
+	automodFieldTitle = "title"
+	automodFieldBody  = "body"
+)
+
+// Actions an automod rule can take.
+const (
+	automodActionHold   = "hold"   // store the content hidden and queue it for review
+	automodActionReject = "reject" // refuse to store the content
+	automodActionTag    = "tag"    // attach the rule's tag to the post
+)
+
+// automodHoldReason is the report reason of content held by automod.
+// Dismissing such a report releases the content.
+const automodHoldReason = "automod_hold"
+
+// automodEvaluator checks new content against the moderators' automod rules.
+// Rules are read from storage on every check so changes apply without a
+// redeploy; compiled patterns are cached.
+type automodEvaluator struct {
+	mu       sync.Mutex
+	patterns map[string]*regexp.Regexp
+}
+
+func newAutomodEvaluator() *automodEvaluator {
+	return &automodEvaluator{patterns: map[string]*regexp.Regexp{}}
+}
+
+// compile returns the compiled pattern, caching it for later checks.
+func (a *automodEvaluator) compile(pattern string) (*regexp.Regexp, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if re, ok := a.patterns[pattern]; ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	a.patterns[pattern] = re
+	return re, nil
+}
+
+// evaluate returns the outcome of checking c, posted in categoryID, against
+// the active rules.
+func (a *automodEvaluator) evaluate(ctx context.Context, stg storage.StorageI, c *Content, categoryID string) (*automodVerdict, error) {
+	rules, err := stg.Automod().GetActive(ctx, categoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	verdict := &automodVerdict{}
+	for _, rule := range rules {
+		// Comments cannot be tagged
+		if rule.Action == automodActionTag && c.Type != reportTargetPost {
+			continue
+		}
+		re, err := a.compile(rule.Pattern)
+		if err != nil {
+			log.Error().Err(err).Str("rule", rule.Id).Msg("Automod: Skipping rule with invalid pattern")
+			continue
+		}
+		if field, _ := matchRule(re, rule.Field, c.Title, c.Body); field != "" {
+			verdict.hits = append(verdict.hits, rule)
+		}
+	}
+	return verdict, nil
+}
+
+// recordHits bumps the hit counters of the rules in v. Stored content counts
+// in the transaction that stored it; rejected content, whose transaction is
+// rolled back, counts through its own call.
+func (a *automodEvaluator) recordHits(ctx context.Context, tx storage.StorageI, v *automodVerdict) error {
+	if len(v.hits) == 0 {
+		return nil
+	}
+	ids := make([]string, len(v.hits))
+	for i, rule := range v.hits {
+		ids[i] = rule.Id
+	}
+	return tx.Automod().RecordHits(ctx, ids)
+}
+
+// matchRule returns the field of title and body that re matches, limited to
+// field, and the matched text. The returned field is empty without a match.
+func matchRule(re *regexp.Regexp, field, title, body string) (string, string) {
+	if field != automodFieldBody {
+		if loc := re.FindStringIndex(title); loc != nil {
+			return automodFieldTitle, title[loc[0]:loc[1]]
+		}
+	}
+	if field != automodFieldTitle {
+		if loc := re.FindStringIndex(body); loc != nil {
+			return automodFieldBody, body[loc[0]:loc[1]]
+		}
+	}
+	return "", ""
+}
+
+// automodVerdict lists the rules new content matched.
+type automodVerdict struct {
+	hits []*automod.AutomodRule
+}
+
+// err returns an InvalidArgument error naming the first rejecting rule, if any.
+func (v *automodVerdict) err() error {
+	for _, rule := range v.hits {
+		if rule.Action == automodActionReject {
+			return status.Errorf(codes.InvalidArgument, "content rejected by automod rule %q", rule.Name)
+		}
+	}
+	return nil
+}
+
+// apply tags and holds the stored content as the matched rules require and
+// reports whether the content was held. It is meant to run in the
+// transaction that stored the content.
+func (v *automodVerdict) apply(ctx context.Context, tx storage.StorageI, targetType, targetID string) (bool, error) {
+	var held []string
+	tagged := map[string]bool{}
+	for _, rule := range v.hits {
+		switch rule.Action {
+		case automodActionHold:
+			held = append(held, rule.Name)
+		case automodActionTag:
+			if tagged[rule.TagId] {
+				continue
+			}
+			tagged[rule.TagId] = true
+			resp, err := tx.PostTag().Create(ctx, &posttag.CreatePostTagRequest{PostId: targetID, TagId: rule.TagId})
+			if err != nil {
+				return false, err
+			}
+			if err := recordEvent(ctx, tx, events.TagAttached, events.AggregatePost, targetID, resp.PostTag); err != nil {
+				return false, err
+			}
+		}
+	}
+	if len(held) == 0 {
+		return false, nil
+	}
+
+	var err error
+	if targetType == reportTargetPost {
+		err = tx.Post().SetHidden(ctx, targetID, true)
+	} else {
+		err = tx.Comment().SetHidden(ctx, targetID, true)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := tx.Report().Create(ctx, &report.ReportContentRequest{
+		TargetType: targetType,
+		TargetId:   targetID,
+		ReporterId: filterReporterID,
+		Reason:     automodHoldReason,
+		Details:    "held by automod rules: " + strings.Join(held, ", "),
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, recordEvent(ctx, tx, events.ContentReported, events.AggregateReport, resp.Report.Id, resp.Report)
+}
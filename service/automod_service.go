@@ -0,0 +1,268 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/Forum-service/Forum-Service/genproto/automod"
+	"github.com/Forum-service/Forum-Service/genproto/category"
+	"github.com/Forum-service/Forum-Service/genproto/post"
+	"github.com/Forum-service/Forum-Service/genproto/tag"
+	"github.com/Forum-service/Forum-Service/storage"
+	"github.com/rs/zerolog/log"
+)
+
+// Dry runs test this many recent posts by default and at most maxDryRunPosts.
+const (
+	defaultDryRunPosts = 100
+	maxDryRunPosts     = 1000
+)
+
+// AutomodService implements the automod.AutomodServiceServer interface.
+type AutomodService struct {
+	stg storage.StorageI
+	automod.UnimplementedAutomodServiceServer
+}
+
+// NewAutomodService creates a new AutomodService.
+func NewAutomodService(stg storage.StorageI) *AutomodService {
+	return &AutomodService{stg: stg}
+}
+
+// CreateAutomodRule creates a new automod rule.
+func (s *AutomodService) CreateAutomodRule(ctx context.Context, req *automod.CreateAutomodRuleRequest) (*automod.CreateAutomodRuleResponse, error) {
+	log.Info().Msg("AutomodService: CreateAutomodRule called")
+
+	var err error
+	if req.CreatedBy, err = actingUser(ctx, req.CreatedBy); err != nil {
+		return nil, err
+	}
+
+	if req.Field == "" {
+		req.Field = automodFieldAny
+	}
+	err = s.validateRule(ctx, &automod.AutomodRule{
+		Name:       req.Name,
+		Pattern:    req.Pattern,
+		Field:      req.Field,
+		CategoryId: req.CategoryId,
+		Action:     req.Action,
+		TagId:      req.TagId,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("AutomodService: Invalid automod rule")
+		return nil, err
+	}
+	if req.Action == automodActionTag {
+		// Store the canonical tag, the given one may have been merged
+		if req.TagId, err = s.canonicalTag(ctx, req.TagId); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := s.stg.Automod().Create(ctx, req)
+	if err != nil {
+		log.Error().Err(err).Msg("AutomodService: Error creating automod rule")
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetAutomodRule gets an automod rule by its ID.
+func (s *AutomodService) GetAutomodRule(ctx context.Context, req *automod.GetAutomodRuleRequest) (*automod.GetAutomodRuleResponse, error) {
+	log.Info().Msg("AutomodService: GetAutomodRule called")
+
+	resp, err := s.stg.Automod().GetById(ctx, req)
+	if err != nil {
+		log.Error().Err(err).Msg("AutomodService: Error getting automod rule")
+		return nil, err
+	}
+	return resp, nil
+}
+
+// UpdateAutomodRule updates an automod rule. The rule is validated as a
+// whole after applying the changes.
+func (s *AutomodService) UpdateAutomodRule(ctx context.Context, req *automod.UpdateAutomodRuleRequest) (*automod.UpdateAutomodRuleResponse, error) {
+	log.Info().Msg("AutomodService: UpdateAutomodRule called")
+
+	existing, err := s.stg.Automod().GetById(ctx, &automod.GetAutomodRuleRequest{Id: req.Id})
+	if err != nil {
+		log.Error().Err(err).Msg("AutomodService: Error getting automod rule to update")
+		return nil, err
+	}
+
+	merged := existing.Rule
+	if req.Name != "" {
+		merged.Name = req.Name
+	}
+	if req.Pattern != "" {
+		merged.Pattern = req.Pattern
+	}
+	if req.Field != "" {
+		merged.Field = req.Field
+	}
+	if req.CategoryId != nil {
+		merged.CategoryId = *req.CategoryId
+	}
+	if req.Action != "" {
+		merged.Action = req.Action
+	}
+	if req.TagId != "" {
+		merged.TagId = req.TagId
+	}
+	if err := s.validateRule(ctx, merged); err != nil {
+		log.Error().Err(err).Msg("AutomodService: Invalid automod rule")
+		return nil, err
+	}
+	if req.TagId != "" {
+		if req.TagId, err = s.canonicalTag(ctx, req.TagId); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := s.stg.Automod().Update(ctx, req)
+	if err != nil {
+		log.Error().Err(err).Msg("AutomodService: Error updating automod rule")
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DeleteAutomodRule deletes an automod rule.
+func (s *AutomodService) DeleteAutomodRule(ctx context.Context, req *automod.DeleteAutomodRuleRequest) (*automod.DeleteAutomodRuleResponse, error) {
+	log.Info().Msg("AutomodService: DeleteAutomodRule called")
+
+	resp, err := s.stg.Automod().Delete(ctx, req)
+	if err != nil {
+		log.Error().Err(err).Msg("AutomodService: Error deleting automod rule")
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListAutomodRules lists automod rules with filtering and pagination.
+func (s *AutomodService) ListAutomodRules(ctx context.Context, req *automod.ListAutomodRulesRequest) (*automod.ListAutomodRulesResponse, error) {
+	log.Info().Msg("AutomodService: ListAutomodRules called")
+
+	resp, err := s.stg.Automod().GetAllRules(ctx, req)
+	if err != nil {
+		log.Error().Err(err).Msg("AutomodService: Error listing automod rules")
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DryRunAutomodRule reports which recent posts a rule would match, without
+// acting on them or counting hits.
+func (s *AutomodService) DryRunAutomodRule(ctx context.Context, req *automod.DryRunAutomodRuleRequest) (*automod.DryRunAutomodRuleResponse, error) {
+	log.Info().Msg("AutomodService: DryRunAutomodRule called")
+
+	rule := &automod.AutomodRule{Field: automodFieldAny}
+	if req.RuleId != "" {
+		existing, err := s.stg.Automod().GetById(ctx, &automod.GetAutomodRuleRequest{Id: req.RuleId})
+		if err != nil {
+			log.Error().Err(err).Msg("AutomodService: Error getting automod rule to dry run")
+			return nil, err
+		}
+		rule = existing.Rule
+	}
+	if req.Pattern != "" {
+		rule.Pattern = req.Pattern
+	}
+	if req.Field != "" {
+		rule.Field = req.Field
+	}
+	if req.CategoryId != "" {
+		rule.CategoryId = req.CategoryId
+	}
+
+	if rule.Pattern == "" {
+		return nil, invalidArgument("pattern", "rule_id or pattern is required")
+	}
+	re, err := compileRulePattern(rule.Pattern, rule.Field)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultDryRunPosts
+	}
+	limit = min(limit, maxDryRunPosts)
+
+	posts, err := s.stg.Post().GetAllPosts(ctx, &post.GetAllPostsRequest{CategoryId: rule.CategoryId, Limit: limit})
+	if err != nil {
+		log.Error().Err(err).Msg("AutomodService: Error getting posts to dry run against")
+		return nil, err
+	}
+
+	resp := &automod.DryRunAutomodRuleResponse{Scanned: int32(len(posts.Posts))}
+	for _, p := range posts.Posts {
+		field, excerpt := matchRule(re, rule.Field, p.Title, p.Body)
+		if field == "" {
+			continue
+		}
+		resp.Matches = append(resp.Matches, &automod.AutomodMatch{
+			PostId:  p.Id,
+			Title:   p.Title,
+			Field:   field,
+			Excerpt: excerpt,
+		})
+	}
+	return resp, nil
+}
+
+// validateRule checks a complete rule and returns an InvalidArgument status
+// for the first problem found.
+func (s *AutomodService) validateRule(ctx context.Context, rule *automod.AutomodRule) error {
+	if err := required("name", rule.Name, "pattern", rule.Pattern); err != nil {
+		return err
+	}
+	if _, err := compileRulePattern(rule.Pattern, rule.Field); err != nil {
+		return err
+	}
+
+	switch rule.Action {
+	case automodActionHold, automodActionReject:
+	case automodActionTag:
+		if rule.TagId == "" {
+			return invalidArgument("tag_id", "tag rules need a tag_id")
+		}
+	default:
+		return invalidArgument("action", "action must be hold, reject or tag")
+	}
+
+	if rule.CategoryId != "" {
+		if _, err := s.stg.Category().GetById(ctx, &category.GetCategoryRequest{Id: rule.CategoryId}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// canonicalTag resolves a tag ID, which may belong to a merged tag, to the ID
+// of the live tag.
+func (s *AutomodService) canonicalTag(ctx context.Context, tagID string) (string, error) {
+	resp, err := s.stg.Tag().GetById(ctx, &tag.GetTagRequest{Id: tagID})
+	if err != nil {
+		log.Error().Err(err).Msg("AutomodService: Error getting rule tag")
+		return "", err
+	}
+	return resp.Tag.Id, nil
+}
+
+// compileRulePattern checks the field of a rule and compiles its pattern.
+func compileRulePattern(pattern, field string) (*regexp.Regexp, error) {
+	switch field {
+	case automodFieldAny, automodFieldTitle, automodFieldBody:
+	default:
+		return nil, invalidArgument("field", "field must be title, body or any")
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, invalidArgument("pattern", fmt.Sprintf("invalid pattern: %v", err))
+	}
+	return re, nil
+}
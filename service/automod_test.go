@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/Forum-service/Forum-Service/events"
+	"github.com/Forum-service/Forum-Service/genproto/automod"
+	"github.com/Forum-service/Forum-Service/genproto/comment"
+	"github.com/Forum-service/Forum-Service/genproto/post"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMatchRule(t *testing.T) {
+	re := regexp.MustCompile(`(?i)free\s+bitcoin`)
+
+	field, excerpt := matchRule(re, automodFieldAny, "Free  Bitcoin inside", "")
+	assert.Equal(t, automodFieldTitle, field)
+	assert.Equal(t, "Free  Bitcoin", excerpt)
+
+	field, excerpt = matchRule(re, automodFieldAny, "Hello", "get free bitcoin now")
+	assert.Equal(t, automodFieldBody, field)
+	assert.Equal(t, "free bitcoin", excerpt)
+
+	field, _ = matchRule(re, automodFieldBody, "free bitcoin", "nothing here")
+	assert.Empty(t, field)
+
+	field, _ = matchRule(re, automodFieldTitle, "nothing here", "free bitcoin")
+	assert.Empty(t, field)
+}
+
+func TestAutomodVerdictErr(t *testing.T) {
+	v := &automodVerdict{hits: []*automod.AutomodRule{
+		{Name: "held", Action: automodActionHold},
+		{Name: "tagged", Action: automodActionTag},
+	}}
+	assert.NoError(t, v.err())
+
+	v.hits = append(v.hits, &automod.AutomodRule{Name: "spam", Action: automodActionReject})
+	err := v.err()
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, err.Error(), `"spam"`)
+}
+
+func TestCompileRulePattern(t *testing.T) {
+	_, err := compileRulePattern(`spam+`, automodFieldBody)
+	assert.NoError(t, err)
+
+	_, err = compileRulePattern(`spam(`, automodFieldBody)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = compileRulePattern(`spam`, "signature")
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestAutomodRejectionCountsHits(t *testing.T) {
+	stg := newFakeStorage()
+	stg.automod.rules = []*automod.AutomodRule{{Id: "spam", Name: "spam", Field: automodFieldAny, Pattern: "(?i)buy now", Action: automodActionReject}}
+	stg.posts.posts["p1"] = &post.Post{Id: "p1"}
+
+	_, err := NewPostService(stg, nil).CreatePost(context.Background(), &post.CreatePostRequest{UserId: "u1", Title: "Buy now", Body: "cheap"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, 1, stg.automod.hits["spam"])
+
+	_, err = NewCommentService(stg, nil, nil, 0).CreateComment(context.Background(), &comment.CreateCommentRequest{UserId: "u1", PostId: "p1", Body: "buy now"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, 2, stg.automod.hits["spam"])
+}
+
+func TestAutomodHoldDefersCreatedEvent(t *testing.T) {
+	stg := newFakeStorage()
+	stg.automod.rules = []*automod.AutomodRule{{Id: "links", Name: "links", Field: automodFieldBody, Pattern: "https?://", Action: automodActionHold}}
+
+	resp, err := NewPostService(stg, nil).CreatePost(context.Background(), &post.CreatePostRequest{UserId: "u1", Title: "Hi", Body: "see https://example.com"})
+	if err != nil {
+		t.Fatalf("Error creating post: %v", err)
+	}
+	assert.True(t, resp.Post.Hidden)
+	assert.Equal(t, 1, stg.automod.hits["links"])
+	// Only the hold report is recorded, the post is announced on release
+	assert.Equal(t, []string{events.ContentReported}, stg.outbox.types())
+}
@@ -2,10 +2,14 @@ package service
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/Forum-service/Forum-Service/events"
 	"github.com/Forum-service/Forum-Service/genproto/category"
 	"github.com/Forum-service/Forum-Service/storage"
 	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // CategoryService implements the category.CategoryServiceServer interface.
@@ -23,7 +27,19 @@ func NewCategoryService(stg storage.StorageI) *CategoryService {
 func (s *CategoryService) CreateCategory(ctx context.Context, req *category.CreateCategoryRequest) (*category.CreateCategoryResponse, error) {
 	log.Info().Msg("CategoryService: CreateCategory called")
 
-	resp, err := s.stg.Category().Create(ctx, req)
+	if req.Slug == "" {
+		req.Slug = slugify(req.Name)
+	}
+
+	var resp *category.CreateCategoryResponse
+	err := s.stg.Tx(ctx, func(tx storage.StorageI) error {
+		var err error
+		resp, err = tx.Category().Create(ctx, req)
+		if err != nil {
+			return err
+		}
+		return recordEvent(ctx, tx, events.CategoryCreated, events.AggregateCategory, resp.Category.Id, resp.Category)
+	})
 	if err != nil {
 		log.Error().Err(err).Msg("CategoryService: Error creating category")
 		return nil, err
@@ -47,7 +63,15 @@ func (s *CategoryService) GetCategory(ctx context.Context, req *category.GetCate
 func (s *CategoryService) UpdateCategory(ctx context.Context, req *category.UpdateCategoryRequest) (*category.UpdateCategoryResponse, error) {
 	log.Info().Msg("CategoryService: UpdateCategory called")
 
-	resp, err := s.stg.Category().Update(ctx, req)
+	var resp *category.UpdateCategoryResponse
+	err := s.stg.Tx(ctx, func(tx storage.StorageI) error {
+		var err error
+		resp, err = tx.Category().Update(ctx, req)
+		if err != nil {
+			return err
+		}
+		return recordEvent(ctx, tx, events.CategoryUpdated, events.AggregateCategory, resp.Category.Id, resp.Category)
+	})
 	if err != nil {
 		log.Error().Err(err).Msg("CategoryService: Error updating category")
 		return nil, err
@@ -59,7 +83,15 @@ func (s *CategoryService) UpdateCategory(ctx context.Context, req *category.Upda
 func (s *CategoryService) DeleteCategory(ctx context.Context, req *category.DeleteCategoryRequest) (*category.DeleteCategoryResponse, error) {
 	log.Info().Msg("CategoryService: DeleteCategory called")
 
-	resp, err := s.stg.Category().Delete(ctx, req)
+	var resp *category.DeleteCategoryResponse
+	err := s.stg.Tx(ctx, func(tx storage.StorageI) error {
+		var err error
+		resp, err = tx.Category().Delete(ctx, req)
+		if err != nil {
+			return err
+		}
+		return recordEvent(ctx, tx, events.CategoryDeleted, events.AggregateCategory, req.Id, req)
+	})
 	if err != nil {
 		log.Error().Err(err).Msg("CategoryService: Error deleting category")
 		return nil, err
@@ -78,3 +110,218 @@ func (s *CategoryService) GetAllCategories(ctx context.Context, req *category.Ge
 	}
 	return resp, nil
 }
+
+// GetCategoryTree returns the category hierarchy with post counts.
+func (s *CategoryService) GetCategoryTree(ctx context.Context, req *category.GetCategoryTreeRequest) (*category.GetCategoryTreeResponse, error) {
+	log.Info().Msg("CategoryService: GetCategoryTree called")
+
+	resp, err := s.stg.Category().GetTree(ctx, req)
+	if err != nil {
+		log.Error().Err(err).Msg("CategoryService: Error getting category tree")
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetCategoryBreadcrumb returns the path from the top level down to a category.
+func (s *CategoryService) GetCategoryBreadcrumb(ctx context.Context, req *category.GetCategoryBreadcrumbRequest) (*category.GetCategoryBreadcrumbResponse, error) {
+	log.Info().Msg("CategoryService: GetCategoryBreadcrumb called")
+
+	resp, err := s.stg.Category().GetBreadcrumb(ctx, req)
+	if err != nil {
+		log.Error().Err(err).Msg("CategoryService: Error getting category breadcrumb")
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetCategoryBySlug retrieves a category by its slug.
+func (s *CategoryService) GetCategoryBySlug(ctx context.Context, req *category.GetCategoryBySlugRequest) (*category.GetCategoryResponse, error) {
+	log.Info().Msg("CategoryService: GetCategoryBySlug called")
+
+	resp, err := s.stg.Category().GetBySlug(ctx, req)
+	if err != nil {
+		log.Error().Err(err).Msg("CategoryService: Error getting category by slug")
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ReorderCategories rewrites the position of the given categories in one transaction.
+func (s *CategoryService) ReorderCategories(ctx context.Context, req *category.ReorderCategoriesRequest) (*category.ReorderCategoriesResponse, error) {
+	log.Info().Msg("CategoryService: ReorderCategories called")
+
+	seen := make(map[string]bool, len(req.Ids))
+	for _, id := range req.Ids {
+		if seen[id] {
+			return nil, invalidArgument("ids", fmt.Sprintf("category %s is listed more than once", id))
+		}
+		seen[id] = true
+	}
+
+	var resp *category.ReorderCategoriesResponse
+	err := s.stg.Tx(ctx, func(tx storage.StorageI) error {
+		var err error
+		resp, err = tx.Category().Reorder(ctx, req)
+		if err != nil {
+			return err
+		}
+		for _, c := range resp.Categories {
+			if err := recordEvent(ctx, tx, events.CategoryUpdated, events.AggregateCategory, c.Id, c); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("CategoryService: Error reordering categories")
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetCategoryStats returns activity statistics for one category.
+func (s *CategoryService) GetCategoryStats(ctx context.Context, req *category.GetCategoryStatsRequest) (*category.GetCategoryStatsResponse, error) {
+	log.Info().Msg("CategoryService: GetCategoryStats called")
+
+	if err := required("category_id", req.CategoryId); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.stg.Category().GetStats(ctx, &category.GetCategoriesStatsRequest{
+		CategoryIds: []string{req.CategoryId},
+		TopTags:     req.TopTags,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("CategoryService: Error getting category stats")
+		return nil, err
+	}
+	if len(resp.Stats) == 0 {
+		return nil, status.Error(codes.NotFound, "category not found")
+	}
+	return &category.GetCategoryStatsResponse{Stats: resp.Stats[0]}, nil
+}
+
+// GetCategoriesStats returns activity statistics for several categories at once.
+func (s *CategoryService) GetCategoriesStats(ctx context.Context, req *category.GetCategoriesStatsRequest) (*category.GetCategoriesStatsResponse, error) {
+	log.Info().Msg("CategoryService: GetCategoriesStats called")
+
+	resp, err := s.stg.Category().GetStats(ctx, req)
+	if err != nil {
+		log.Error().Err(err).Msg("CategoryService: Error getting categories stats")
+		return nil, err
+	}
+	return resp, nil
+}
+
+// MergeCategories moves every post of the source categories into the target
+// and deletes the sources, leaving redirects behind for their IDs.
+func (s *CategoryService) MergeCategories(ctx context.Context, req *category.MergeCategoriesRequest) (*category.MergeCategoriesResponse, error) {
+	log.Info().Msg("CategoryService: MergeCategories called")
+
+	if len(req.SourceIds) == 0 {
+		return nil, invalidArgument("source_ids", "source_ids is required")
+	}
+	if err := required("target_id", req.TargetId); err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{req.TargetId: true}
+	for _, id := range req.SourceIds {
+		if seen[id] {
+			return nil, invalidArgument("source_ids", fmt.Sprintf("category %s is listed more than once or is the target", id))
+		}
+		seen[id] = true
+	}
+
+	var resp *category.MergeCategoriesResponse
+	err := s.stg.Tx(ctx, func(tx storage.StorageI) error {
+		var err error
+		resp, err = tx.Category().Merge(ctx, req)
+		if err != nil {
+			return err
+		}
+		return recordEvent(ctx, tx, events.CategoryMerged, events.AggregateCategory, req.TargetId, req)
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("CategoryService: Error merging categories")
+		return nil, err
+	}
+	return resp, nil
+}
+
+// AddCategoryModerator makes a user moderator of a category and, through
+// inheritance, of all its sub-categories.
+func (s *CategoryService) AddCategoryModerator(ctx context.Context, req *category.AddCategoryModeratorRequest) (*category.AddCategoryModeratorResponse, error) {
+	log.Info().Msg("CategoryService: AddCategoryModerator called")
+
+	var err error
+	if req.AssignedBy, err = actingUser(ctx, req.AssignedBy); err != nil {
+		return nil, err
+	}
+	if err := required("category_id", req.CategoryId, "user_id", req.UserId); err != nil {
+		return nil, err
+	}
+
+	// Assign to the live category when given the ID of a merged one
+	existing, err := s.stg.Category().GetById(ctx, &category.GetCategoryRequest{Id: req.CategoryId})
+	if err != nil {
+		log.Error().Err(err).Msg("CategoryService: Error getting category to add a moderator to")
+		return nil, err
+	}
+	req.CategoryId = existing.Category.Id
+
+	var resp *category.AddCategoryModeratorResponse
+	err = s.stg.Tx(ctx, func(tx storage.StorageI) error {
+		var err error
+		resp, err = tx.Category().AddModerator(ctx, req)
+		if err != nil {
+			return err
+		}
+		return recordEvent(ctx, tx, events.CategoryModeratorAdded, events.AggregateCategory, req.CategoryId, resp.Moderator)
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("CategoryService: Error adding category moderator")
+		return nil, err
+	}
+	return resp, nil
+}
+
+// RemoveCategoryModerator removes a moderator from a category.
+func (s *CategoryService) RemoveCategoryModerator(ctx context.Context, req *category.RemoveCategoryModeratorRequest) (*category.RemoveCategoryModeratorResponse, error) {
+	log.Info().Msg("CategoryService: RemoveCategoryModerator called")
+
+	var resp *category.RemoveCategoryModeratorResponse
+	err := s.stg.Tx(ctx, func(tx storage.StorageI) error {
+		var err error
+		resp, err = tx.Category().RemoveModerator(ctx, req)
+		if err != nil {
+			return err
+		}
+		return recordEvent(ctx, tx, events.CategoryModeratorRemoved, events.AggregateCategory, req.CategoryId, req)
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("CategoryService: Error removing category moderator")
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListCategoryModerators lists the moderators of a category, including those
+// inherited from its parents.
+func (s *CategoryService) ListCategoryModerators(ctx context.Context, req *category.ListCategoryModeratorsRequest) (*category.ListCategoryModeratorsResponse, error) {
+	log.Info().Msg("CategoryService: ListCategoryModerators called")
+
+	existing, err := s.stg.Category().GetById(ctx, &category.GetCategoryRequest{Id: req.CategoryId})
+	if err != nil {
+		log.Error().Err(err).Msg("CategoryService: Error getting category to list moderators of")
+		return nil, err
+	}
+	req.CategoryId = existing.Category.Id
+
+	resp, err := s.stg.Category().GetModerators(ctx, req)
+	if err != nil {
+		log.Error().Err(err).Msg("CategoryService: Error listing category moderators")
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/Forum-service/Forum-Service/genproto/comment"
+	"github.com/Forum-service/Forum-Service/storage"
+	"github.com/rs/zerolog/log"
+)
+
+// commentEventsChannel is the Postgres NOTIFY channel fed by the comments trigger.
+const commentEventsChannel = "comment_events"
+
+// commentSubscriberBuffer is how many events a watcher may lag behind before it is dropped.
+const commentSubscriberBuffer = 64
+
+// errSubscriberLagging is reported to watchers that could not keep up with the feed.
+var errSubscriberLagging = errors.New("comment watcher fell behind")
+
+// errFeedDisconnected is reported to watchers that may have missed changes
+// while the listen connection was down.
+var errFeedDisconnected = errors.New("comment feed lost its connection")
+
+// commentNotification is the payload sent by the comments trigger.
+type commentNotification struct {
+	Type      string `json:"type"`
+	ID        string `json:"id"`
+	PostID    string `json:"post_id"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// commentSubscriber receives live comment events for one post.
+type commentSubscriber struct {
+	events chan *comment.CommentEvent
+	err    error
+}
+
+// CommentFeed fans comment changes out to WatchPostComments streams. Changes
+// arrive through Postgres LISTEN/NOTIFY, so a comment written through any
+// replica reaches watchers connected to every replica.
+type CommentFeed struct {
+	stg storage.StorageI
+
+	mu          sync.Mutex
+	subscribers map[string]map[*commentSubscriber]struct{}
+}
+
+// NewCommentFeed creates a CommentFeed. Call Run to start receiving changes.
+func NewCommentFeed(stg storage.StorageI) *CommentFeed {
+	return &CommentFeed{
+		stg:         stg,
+		subscribers: make(map[string]map[*commentSubscriber]struct{}),
+	}
+}
+
+// Run listens for comment changes until ctx is cancelled, reconnecting with
+// backoff when the listen connection drops. Notifications sent while the
+// connection is down are lost, so every watcher is dropped before
+// reconnecting and resumes from its last cursor.
+func (f *CommentFeed) Run(ctx context.Context) {
+	backoff := time.Second
+	for {
+		err := f.stg.Listen(ctx, commentEventsChannel, func(payload string) {
+			backoff = time.Second
+			f.handle(ctx, payload)
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		log.Error().Err(err).Msg("CommentFeed: Listen connection lost, reconnecting")
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		if backoff < time.Minute {
+			backoff *= 2
+		}
+		f.dropAll(errFeedDisconnected)
+	}
+}
+
+// handle turns a trigger payload into a CommentEvent and publishes it.
+func (f *CommentFeed) handle(ctx context.Context, payload string) {
+	var n commentNotification
+	if err := json.Unmarshal([]byte(payload), &n); err != nil {
+		log.Error().Err(err).Msg("CommentFeed: Error decoding comment notification")
+		return
+	}
+
+	// Skip the lookup when nobody on this replica watches the post
+	if !f.watched(n.PostID) {
+		return
+	}
+
+	// Postgres renders TIMESTAMP WITHOUT TIME ZONE without an offset
+	updatedAt, err := time.Parse("2006-01-02T15:04:05.999999999", n.UpdatedAt)
+	if err != nil {
+		log.Error().Err(err).Msg("CommentFeed: Error parsing comment notification timestamp")
+		return
+	}
+
+	event := &comment.CommentEvent{
+		Type:   n.Type,
+		Cursor: updatedAt.Format(time.RFC3339Nano),
+	}
+
+	if n.Type == "deleted" {
+		event.Comment = &comment.Comment{Id: n.ID, PostId: n.PostID}
+	} else {
+		resp, err := f.stg.Comment().GetById(ctx, &comment.GetCommentRequest{Id: n.ID})
+		if err != nil {
+			log.Error().Err(err).Msg("CommentFeed: Error getting changed comment")
+			return
+		}
+		event.Comment = resp.Comment
+		// Hidden comments reach watchers only as a notice to drop them
+		if resp.Comment.Hidden {
+			event.Type = "hidden"
+			event.Comment = &comment.Comment{Id: n.ID, PostId: n.PostID, Hidden: true}
+		}
+	}
+
+	f.publish(n.PostID, event)
+}
+
+// subscribe registers a watcher for postID. The returned function must be
+// called once the watcher is done.
+func (f *CommentFeed) subscribe(postID string) (*commentSubscriber, func()) {
+	sub := &commentSubscriber{events: make(chan *comment.CommentEvent, commentSubscriberBuffer)}
+
+	f.mu.Lock()
+	if f.subscribers[postID] == nil {
+		f.subscribers[postID] = make(map[*commentSubscriber]struct{})
+	}
+	f.subscribers[postID][sub] = struct{}{}
+	f.mu.Unlock()
+
+	return sub, func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.remove(postID, sub)
+	}
+}
+
+// publish hands event to every watcher of postID. A watcher whose buffer is
+// full is dropped so one slow client cannot stall the feed.
+func (f *CommentFeed) publish(postID string, event *comment.CommentEvent) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for sub := range f.subscribers[postID] {
+		select {
+		case sub.events <- event:
+		default:
+			sub.err = errSubscriberLagging
+			f.remove(postID, sub)
+		}
+	}
+}
+
+// remove unregisters sub and closes its channel. f.mu must be held.
+func (f *CommentFeed) remove(postID string, sub *commentSubscriber) {
+	subs, ok := f.subscribers[postID]
+	if !ok {
+		return
+	}
+	if _, ok := subs[sub]; !ok {
+		return
+	}
+
+	delete(subs, sub)
+	close(sub.events)
+	if len(subs) == 0 {
+		delete(f.subscribers, postID)
+	}
+}
+
+// dropAll unregisters every watcher, reporting err to them.
+func (f *CommentFeed) dropAll(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for postID, subs := range f.subscribers {
+		for sub := range subs {
+			sub.err = err
+			f.remove(postID, sub)
+		}
+	}
+}
+
+// watched reports whether any watcher is subscribed to postID.
+func (f *CommentFeed) watched(postID string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return len(f.subscribers[postID]) > 0
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Forum-service/Forum-Service/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+// flakyListener fails its first Listen and then listens until cancelled.
+type flakyListener struct {
+	storage.StorageI
+	calls int
+}
+
+func (l *flakyListener) Listen(ctx context.Context, _ string, _ func(string)) error {
+	l.calls++
+	if l.calls == 1 {
+		return errors.New("connection reset")
+	}
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestCommentFeedDropsWatchersOnReconnect(t *testing.T) {
+	f := NewCommentFeed(&flakyListener{})
+	sub, unsubscribe := f.subscribe("p1")
+	defer unsubscribe()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go f.Run(ctx)
+
+	// Changes sent while disconnected are lost, so the watcher must resume
+	select {
+	case _, ok := <-sub.events:
+		assert.False(t, ok)
+		assert.ErrorIs(t, sub.err, errFeedDisconnected)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Watcher was not dropped after the feed reconnected")
+	}
+	assert.False(t, f.watched("p1"))
+}
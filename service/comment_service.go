@@ -2,32 +2,120 @@ package service
 
 import (
 	"context"
+	"time"
 
+	"github.com/Forum-service/Forum-Service/events"
 	"github.com/Forum-service/Forum-Service/genproto/comment"
+	"github.com/Forum-service/Forum-Service/genproto/post"
 	"github.com/Forum-service/Forum-Service/storage"
 	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // CommentService implements the comment.CommentServiceServer interface.
 type CommentService struct {
-	stg storage.StorageI
+	stg      storage.StorageI
+	notifier *notifier
+	feed     *CommentFeed
+	filters  ContentFilters
+	automod  *automodEvaluator
+
+	// editWindow is how long after posting authors may edit a comment; zero disables the limit
+	editWindow time.Duration
 	comment.UnimplementedCommentServiceServer
 }
 
-// NewCommentService creates a new CommentService.
-func NewCommentService(stg storage.StorageI) *CommentService {
-	return &CommentService{stg: stg}
+// NewCommentService creates a new CommentService. Live changes for
+// WatchPostComments are taken from feed, new and edited comments are checked
+// against filters and new comments also against the automod rules.
+func NewCommentService(stg storage.StorageI, feed *CommentFeed, filters ContentFilters, editWindow time.Duration) *CommentService {
+	return &CommentService{stg: stg, notifier: newNotifier(stg), feed: feed, filters: filters, automod: newAutomodEvaluator(), editWindow: editWindow}
 }
 
 // CreateComment creates a new comment.
 func (s *CommentService) CreateComment(ctx context.Context, req *comment.CreateCommentRequest) (*comment.CreateCommentResponse, error) {
 	log.Info().Msg("CommentService: CreateComment called")
 
-	resp, err := s.stg.Comment().Create(ctx, req)
+	var err error
+	if req.UserId, err = actingUser(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
+	content := &Content{Type: reportTargetComment, UserID: req.UserId, Body: req.Body}
+	flagged, err := s.filters.Run(content)
+	if err != nil {
+		log.Error().Err(err).Msg("CommentService: Comment rejected by content filter")
+		return nil, err
+	}
+	req.Body = content.Body
+
+	var (
+		resp    *comment.CreateCommentResponse
+		verdict *automodVerdict
+	)
+	err = s.stg.Tx(ctx, func(tx storage.StorageI) error {
+		postResp, err := tx.Post().GetById(ctx, &post.GetPostRequest{Id: req.PostId})
+		if err != nil {
+			return err
+		}
+		if postResp.Post.Locked {
+			return status.Error(codes.FailedPrecondition, "post is locked and does not accept new comments")
+		}
+		if req.ParentId != "" {
+			parent, err := tx.Comment().GetById(ctx, &comment.GetCommentRequest{Id: req.ParentId})
+			if err != nil {
+				return err
+			}
+			if parent.Comment.PostId != req.PostId {
+				return status.Error(codes.InvalidArgument, "parent comment belongs to another post")
+			}
+		}
+
+		// Rules of the post's category apply to its comments
+		verdict, err = s.automod.evaluate(ctx, tx, content, postResp.Post.CategoryId)
+		if err != nil {
+			return err
+		}
+		if err := verdict.err(); err != nil {
+			return err
+		}
+
+		resp, err = tx.Comment().Create(ctx, req)
+		if err != nil {
+			return err
+		}
+		held, err := verdict.apply(ctx, tx, reportTargetComment, resp.Comment.Id)
+		if err != nil {
+			return err
+		}
+		resp.Comment.Hidden = held
+		// Held comments are announced once a moderator releases them
+		if !held {
+			if err := recordEvent(ctx, tx, events.CommentCreated, events.AggregateComment, resp.Comment.Id, resp.Comment); err != nil {
+				return err
+			}
+		}
+		if err := s.automod.recordHits(ctx, tx, verdict); err != nil {
+			return err
+		}
+		return flagContent(ctx, tx, reportTargetComment, resp.Comment.Id, flagged)
+	})
 	if err != nil {
 		log.Error().Err(err).Msg("CommentService: Error creating comment")
+		// The rejection rolled the transaction back, so its hits count on their own
+		if verdict != nil && verdict.err() != nil {
+			if err := s.automod.recordHits(ctx, s.stg, verdict); err != nil {
+				log.Error().Err(err).Msg("CommentService: Error recording automod rule hits")
+			}
+		}
 		return nil, err
 	}
+
+	s.filters.Stored(content)
+	if !resp.Comment.Hidden {
+		s.notifier.CommentCreated(ctx, resp.Comment)
+	}
 	return resp, nil
 }
 
@@ -40,6 +128,10 @@ func (s *CommentService) GetComment(ctx context.Context, req *comment.GetComment
 		log.Error().Err(err).Msg("CommentService: Error getting comment by ID")
 		return nil, err
 	}
+	c := resp.Comment
+	if err := checkHiddenVisible(ctx, s.stg, c.Hidden, c.UserId, c.PostId, "comment"); err != nil {
+		return nil, err
+	}
 	return resp, nil
 }
 
@@ -47,11 +139,60 @@ func (s *CommentService) GetComment(ctx context.Context, req *comment.GetComment
 func (s *CommentService) UpdateComment(ctx context.Context, req *comment.UpdateCommentRequest) (*comment.UpdateCommentResponse, error) {
 	log.Info().Msg("CommentService: UpdateComment called")
 
-	resp, err := s.stg.Comment().Update(ctx, req)
+	existing, err := s.stg.Comment().GetById(ctx, &comment.GetCommentRequest{Id: req.Id})
+	if err != nil {
+		log.Error().Err(err).Msg("CommentService: Error getting comment to update")
+		return nil, err
+	}
+	if err := checkOwner(ctx, s.stg, existing.Comment.UserId, existing.Comment.PostId); err != nil {
+		return nil, err
+	}
+	if req.EditorId, err = actingUser(ctx, req.EditorId); err != nil {
+		return nil, err
+	}
+
+	if s.editWindow > 0 {
+		createdAt, err := s.stg.Comment().CreatedAt(ctx, req.Id)
+		if err != nil {
+			log.Error().Err(err).Msg("CommentService: Error getting comment creation time")
+			return nil, err
+		}
+		if time.Since(createdAt) > s.editWindow {
+			moderator, err := moderatesPost(ctx, s.stg, existing.Comment.PostId, req.Moderator)
+			if err != nil {
+				return nil, err
+			}
+			if !moderator {
+				return nil, status.Errorf(codes.FailedPrecondition, "comments can only be edited within %s of posting", s.editWindow)
+			}
+		}
+	}
+
+	content := &Content{Type: reportTargetComment, UserID: req.EditorId, Body: req.Body, Edit: true}
+	flagged, err := s.filters.Run(content)
+	if err != nil {
+		log.Error().Err(err).Msg("CommentService: Comment edit rejected by content filter")
+		return nil, err
+	}
+	req.Body = content.Body
+
+	var resp *comment.UpdateCommentResponse
+	err = s.stg.Tx(ctx, func(tx storage.StorageI) error {
+		var err error
+		resp, err = tx.Comment().Update(ctx, req)
+		if err != nil {
+			return err
+		}
+		if err := recordEvent(ctx, tx, events.CommentUpdated, events.AggregateComment, resp.Comment.Id, resp.Comment); err != nil {
+			return err
+		}
+		return flagContent(ctx, tx, reportTargetComment, resp.Comment.Id, flagged)
+	})
 	if err != nil {
 		log.Error().Err(err).Msg("CommentService: Error updating comment")
 		return nil, err
 	}
+
 	return resp, nil
 }
 
@@ -59,7 +200,24 @@ func (s *CommentService) UpdateComment(ctx context.Context, req *comment.UpdateC
 func (s *CommentService) DeleteComment(ctx context.Context, req *comment.DeleteCommentRequest) (*comment.DeleteCommentResponse, error) {
 	log.Info().Msg("CommentService: DeleteComment called")
 
-	resp, err := s.stg.Comment().Delete(ctx, req)
+	existing, err := s.stg.Comment().GetById(ctx, &comment.GetCommentRequest{Id: req.Id})
+	if err != nil {
+		log.Error().Err(err).Msg("CommentService: Error getting comment to delete")
+		return nil, err
+	}
+	if err := checkOwner(ctx, s.stg, existing.Comment.UserId, existing.Comment.PostId); err != nil {
+		return nil, err
+	}
+
+	var resp *comment.DeleteCommentResponse
+	err = s.stg.Tx(ctx, func(tx storage.StorageI) error {
+		var err error
+		resp, err = tx.Comment().Delete(ctx, req)
+		if err != nil {
+			return err
+		}
+		return recordEvent(ctx, tx, events.CommentDeleted, events.AggregateComment, req.Id, req)
+	})
 	if err != nil {
 		log.Error().Err(err).Msg("CommentService: Error deleting comment")
 		return nil, err
@@ -78,3 +236,73 @@ func (s *CommentService) GetAllComments(ctx context.Context, req *comment.GetAll
 	}
 	return resp, nil
 }
+
+// GetCommentRevisions lists the revisions of a comment with pagination.
+func (s *CommentService) GetCommentRevisions(ctx context.Context, req *comment.GetCommentRevisionsRequest) (*comment.GetCommentRevisionsResponse, error) {
+	log.Info().Msg("CommentService: GetCommentRevisions called")
+
+	existing, err := s.stg.Comment().GetById(ctx, &comment.GetCommentRequest{Id: req.CommentId})
+	if err != nil {
+		log.Error().Err(err).Msg("CommentService: Error getting comment to list its revisions")
+		return nil, err
+	}
+	c := existing.Comment
+	if err := checkHiddenVisible(ctx, s.stg, c.Hidden, c.UserId, c.PostId, "comment"); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.stg.Comment().GetRevisions(ctx, req)
+	if err != nil {
+		log.Error().Err(err).Msg("CommentService: Error getting comment revisions")
+		return nil, err
+	}
+	return resp, nil
+}
+
+// WatchPostComments replays comment changes on a post since the requested
+// cursor and then streams live changes until the client disconnects.
+func (s *CommentService) WatchPostComments(req *comment.WatchPostCommentsRequest, stream comment.CommentService_WatchPostCommentsServer) error {
+	log.Info().Msg("CommentService: WatchPostComments called")
+	ctx := stream.Context()
+
+	// Subscribe before replaying so changes committed in between are not missed
+	sub, unsubscribe := s.feed.subscribe(req.PostId)
+	defer unsubscribe()
+
+	replayed := map[string]bool{}
+	if req.Since != "" {
+		since, err := time.Parse(time.RFC3339Nano, req.Since)
+		if err != nil {
+			return invalidArgument("since", "since must be a cursor returned by a previous event")
+		}
+
+		changes, err := s.stg.Comment().GetChanges(ctx, req.PostId, since)
+		if err != nil {
+			log.Error().Err(err).Msg("CommentService: Error replaying comment changes")
+			return err
+		}
+		for _, change := range changes {
+			if err := stream.Send(change); err != nil {
+				return err
+			}
+			replayed[change.Comment.Id+change.Cursor] = true
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-sub.events:
+			if !ok {
+				return status.Error(codes.Unavailable, sub.err.Error()+", resume from the last cursor")
+			}
+			if replayed[event.Comment.Id+event.Cursor] {
+				continue
+			}
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/Forum-service/Forum-Service/genproto/post"
+)
+
+// Diff line operations.
+const (
+	diffEqual  = "equal"
+	diffInsert = "insert"
+	diffDelete = "delete"
+)
+
+// maxDiffCost bounds the edits searched for in one span of lines. Spans that
+// differ more are reported as deleted and reinserted whole, which is still a
+// correct diff, so very different revisions cannot tie up a request.
+const maxDiffCost = 1000
+
+// lineDiff returns a line-level diff that turns a into b. It uses Myers'
+// linear space algorithm, so memory grows with the number of lines rather
+// than with their product.
+func lineDiff(a, b string) []*post.DiffLine {
+	aLines := splitLines(a)
+	bLines := splitLines(b)
+
+	// Compare lines by number rather than by content
+	ids := map[string]int{}
+	intern := func(lines []string) []int {
+		out := make([]int, len(lines))
+		for i, line := range lines {
+			id, ok := ids[line]
+			if !ok {
+				id = len(ids)
+				ids[line] = id
+			}
+			out[i] = id
+		}
+		return out
+	}
+
+	d := &differ{a: aLines, b: bLines, aIDs: intern(aLines), bIDs: intern(bLines)}
+	d.diff(0, len(aLines), 0, len(bLines))
+	return d.out
+}
+
+// differ accumulates the diff of a and b, whose lines are compared through
+// aIDs and bIDs.
+type differ struct {
+	a, b       []string
+	aIDs, bIDs []int
+	out        []*post.DiffLine
+}
+
+// diff appends the diff of a[aLo:aHi] and b[bLo:bHi].
+func (d *differ) diff(aLo, aHi, bLo, bHi int) {
+	for aLo < aHi && bLo < bHi && d.aIDs[aLo] == d.bIDs[bLo] {
+		d.emit(diffEqual, d.a[aLo])
+		aLo++
+		bLo++
+	}
+	suffix := 0
+	for aLo < aHi-suffix && bLo < bHi-suffix && d.aIDs[aHi-suffix-1] == d.bIDs[bHi-suffix-1] {
+		suffix++
+	}
+	aHi -= suffix
+	bHi -= suffix
+
+	switch {
+	case aLo == aHi:
+		d.emitRange(diffInsert, d.b[bLo:bHi])
+	case bLo == bHi:
+		d.emitRange(diffDelete, d.a[aLo:aHi])
+	default:
+		if x, y, ok := d.bisect(aLo, aHi, bLo, bHi); ok {
+			d.diff(aLo, x, bLo, y)
+			d.diff(x, aHi, y, bHi)
+		} else {
+			d.emitRange(diffDelete, d.a[aLo:aHi])
+			d.emitRange(diffInsert, d.b[bLo:bHi])
+		}
+	}
+
+	d.emitRange(diffEqual, d.a[aHi:aHi+suffix])
+}
+
+// bisect finds the middle of a shortest edit path through a[aLo:aHi] and
+// b[bLo:bHi] by searching forwards and backwards at once until the searches
+// meet, returning where to split both spans. It gives up after maxDiffCost
+// edits.
+func (d *differ) bisect(aLo, aHi, bLo, bHi int) (int, int, bool) {
+	n, m := aHi-aLo, bHi-bLo
+	maxD := min((n+m+1)/2, maxDiffCost)
+	offset := maxD
+	// forward[k] and backward[k] hold the furthest x reached on diagonal k, -1 if none
+	forward := make([]int, 2*maxD+2)
+	backward := make([]int, 2*maxD+2)
+	for i := range forward {
+		forward[i] = -1
+		backward[i] = -1
+	}
+	forward[offset+1] = 0
+	backward[offset+1] = 0
+
+	delta := n - m
+	odd := delta%2 != 0
+	// Diagonals that ran off the edge of the grid are not extended further
+	fStart, fEnd, bStart, bEnd := 0, 0, 0, 0
+
+	for step := 0; step < maxD; step++ {
+		for k := -step + fStart; k <= step-fEnd; k += 2 {
+			i := offset + k
+			var x int
+			if k == -step || (k != step && forward[i-1] < forward[i+1]) {
+				x = forward[i+1]
+			} else {
+				x = forward[i-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && d.aIDs[aLo+x] == d.bIDs[bLo+y] {
+				x++
+				y++
+			}
+			forward[i] = x
+			switch {
+			case x > n:
+				fEnd += 2
+			case y > m:
+				fStart += 2
+			case odd:
+				j := offset + delta - k
+				if j >= 0 && j < len(backward) && backward[j] != -1 && x >= n-backward[j] {
+					return aLo + x, bLo + y, true
+				}
+			}
+		}
+
+		for k := -step + bStart; k <= step-bEnd; k += 2 {
+			i := offset + k
+			var x int
+			if k == -step || (k != step && backward[i-1] < backward[i+1]) {
+				x = backward[i+1]
+			} else {
+				x = backward[i-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && d.aIDs[aHi-x-1] == d.bIDs[bHi-y-1] {
+				x++
+				y++
+			}
+			backward[i] = x
+			switch {
+			case x > n:
+				bEnd += 2
+			case y > m:
+				bStart += 2
+			case !odd:
+				j := offset + delta - k
+				if j >= 0 && j < len(forward) && forward[j] != -1 {
+					fx := forward[j]
+					fy := offset + fx - j
+					if fx >= n-x {
+						return aLo + fx, bLo + fy, true
+					}
+				}
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+func (d *differ) emit(op, text string) {
+	d.out = append(d.out, &post.DiffLine{Op: op, Text: text})
+}
+
+func (d *differ) emitRange(op string, lines []string) {
+	for _, line := range lines {
+		d.emit(op, line)
+	}
+}
+
+// splitLines splits s into lines; an empty string has no lines.
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+}
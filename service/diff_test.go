@@ -0,0 +1,118 @@
+package service
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/Forum-service/Forum-Service/genproto/post"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLineDiff(t *testing.T) {
+	diff := lineDiff("one\ntwo\nthree", "one\n2\nthree\nfour")
+
+	expected := []*post.DiffLine{
+		{Op: diffEqual, Text: "one"},
+		{Op: diffDelete, Text: "two"},
+		{Op: diffInsert, Text: "2"},
+		{Op: diffEqual, Text: "three"},
+		{Op: diffInsert, Text: "four"},
+	}
+	if assert.Len(t, diff, len(expected)) {
+		for i := range expected {
+			assert.Equal(t, expected[i].Op, diff[i].Op)
+			assert.Equal(t, expected[i].Text, diff[i].Text)
+		}
+	}
+}
+
+func TestLineDiffEmpty(t *testing.T) {
+	assert.Empty(t, lineDiff("", ""))
+
+	diff := lineDiff("", "new")
+	if assert.Len(t, diff, 1) {
+		assert.Equal(t, diffInsert, diff[0].Op)
+	}
+}
+
+// lcsLength is the textbook quadratic LCS, used to check lineDiff is minimal.
+func lcsLength(a, b []string) int {
+	prev := make([]int, len(b)+1)
+	for i := range a {
+		cur := make([]int, len(b)+1)
+		for j := range b {
+			if a[i] == b[j] {
+				cur[j+1] = prev[j] + 1
+			} else {
+				cur[j+1] = max(prev[j+1], cur[j])
+			}
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+// applyDiff rebuilds both sides of a diff.
+func applyDiff(diff []*post.DiffLine) (from, to []string) {
+	for _, line := range diff {
+		if line.Op != diffInsert {
+			from = append(from, line.Text)
+		}
+		if line.Op != diffDelete {
+			to = append(to, line.Text)
+		}
+	}
+	return from, to
+}
+
+func TestLineDiffIsMinimal(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	randomText := func() []string {
+		lines := make([]string, rnd.Intn(30))
+		for i := range lines {
+			lines[i] = string(rune('a' + rnd.Intn(4)))
+		}
+		return lines
+	}
+
+	for i := 0; i < 500; i++ {
+		a, b := randomText(), randomText()
+		diff := lineDiff(strings.Join(a, "\n"), strings.Join(b, "\n"))
+
+		from, to := applyDiff(diff)
+		assert.Equal(t, splitLines(strings.Join(a, "\n")), from)
+		assert.Equal(t, splitLines(strings.Join(b, "\n")), to)
+
+		equal := 0
+		for _, line := range diff {
+			if line.Op == diffEqual {
+				equal++
+			}
+		}
+		assert.Equal(t, lcsLength(from, to), equal)
+	}
+}
+
+func TestLineDiffLargeInputs(t *testing.T) {
+	a := make([]string, 50000)
+	b := make([]string, 50000)
+	for i := range a {
+		a[i] = fmt.Sprintf("old %d", i)
+		b[i] = fmt.Sprintf("new %d", i)
+	}
+	b[25000] = a[25000]
+
+	// Too different to search exhaustively, the diff is coarse but correct
+	diff := lineDiff(strings.Join(a, "\n"), strings.Join(b, "\n"))
+	from, to := applyDiff(diff)
+	assert.Equal(t, a, from)
+	assert.Equal(t, b, to)
+
+	// A single edit in a large body is still found exactly
+	edited := append([]string(nil), a...)
+	edited[100] = "changed"
+	diff = lineDiff(strings.Join(a, "\n"), strings.Join(edited, "\n"))
+	assert.Len(t, diff, len(a)+1)
+}
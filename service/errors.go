@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Forum-service/Forum-Service/storage"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// Postgres error codes mapped by StatusError.
+const (
+	pgNotNullViolation     = "23502"
+	pgForeignKeyViolation  = "23503"
+	pgUniqueViolation      = "23505"
+	pgCheckViolation       = "23514"
+	pgInvalidTextValue     = "22P02" // Such as a malformed UUID
+	pgStringDataTruncation = "22001"
+)
+
+// StatusError turns the errors returned by services into gRPC statuses, so
+// clients see a meaningful code instead of Unknown:
+//
+//   - missing records become NotFound with the resource type
+//   - a stale expected_version becomes Aborted, so clients know to reload
+//     the record and retry
+//   - duplicates and unique violations become AlreadyExists
+//   - foreign key violations become FailedPrecondition
+//   - invalid values become InvalidArgument with the offending field
+//
+// Statuses are returned unchanged and anything else becomes Internal, with
+// the cause logged rather than sent to the client.
+func StatusError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+
+	var notFound *storage.NotFoundError
+	if errors.As(err, &notFound) {
+		return withDetails(codes.NotFound, err.Error(), &errdetails.ResourceInfo{
+			ResourceType: notFound.Resource,
+			Description:  err.Error(),
+		})
+	}
+
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return status.Error(codes.NotFound, "record not found")
+	case errors.Is(err, storage.ErrVersionConflict):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, storage.ErrCategoryCycle):
+		return invalidArgument("parent_id", err.Error())
+	case errors.Is(err, storage.ErrNoFieldsToUpdate):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, storage.ErrCategorySlugTaken):
+		return alreadyExists("category", err.Error())
+	case errors.Is(err, storage.ErrTagNameTaken):
+		return alreadyExists("tag", err.Error())
+	case errors.Is(err, storage.ErrAlreadyReported):
+		return alreadyExists("report", err.Error())
+	case errors.Is(err, storage.ErrAlreadyModerator):
+		return alreadyExists("category moderator", err.Error())
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case pgForeignKeyViolation:
+			return withDetails(codes.FailedPrecondition, "a referenced record does not exist", &errdetails.PreconditionFailure{
+				Violations: []*errdetails.PreconditionFailure_Violation{{
+					Type:        "FOREIGN_KEY",
+					Subject:     pgErr.ConstraintName,
+					Description: pgErr.Detail,
+				}},
+			})
+		case pgUniqueViolation:
+			description := pgErr.Detail
+			if description == "" {
+				description = pgErr.Message
+			}
+			return alreadyExists(pgErr.TableName, description)
+		case pgNotNullViolation, pgCheckViolation, pgInvalidTextValue, pgStringDataTruncation:
+			field := pgErr.ColumnName
+			if field == "" {
+				field = pgErr.ConstraintName
+			}
+			return invalidArgument(field, pgErr.Message)
+		}
+	}
+
+	log.Error().Err(err).Msg("Unexpected error")
+	return status.Error(codes.Internal, "internal error")
+}
+
+// invalidArgument returns an InvalidArgument status pointing at field.
+func invalidArgument(field, description string) error {
+	return withDetails(codes.InvalidArgument, description, &errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{
+			Field:       field,
+			Description: description,
+		}},
+	})
+}
+
+// required returns an InvalidArgument status for the first empty field of
+// fields, given as name and value pairs, or nil when they are all set.
+func required(fields ...string) error {
+	for i := 0; i+1 < len(fields); i += 2 {
+		if fields[i+1] == "" {
+			return invalidArgument(fields[i], fields[i]+" is required")
+		}
+	}
+	return nil
+}
+
+// alreadyExists returns an AlreadyExists status about a resource.
+func alreadyExists(resource, description string) error {
+	return withDetails(codes.AlreadyExists, description, &errdetails.ResourceInfo{
+		ResourceType: resource,
+		Description:  description,
+	})
+}
+
+// withDetails returns a status with details attached, or without them should
+// they fail to marshal.
+func withDetails(code codes.Code, msg string, details ...protoadapt.MessageV1) error {
+	st := status.New(code, msg)
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Forum-service/Forum-Service/storage"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStatusError(t *testing.T) {
+	postNotFound := &storage.NotFoundError{Resource: "post"}
+
+	tests := []struct {
+		name string
+		err  error
+		code codes.Code
+	}{
+		{"nil", nil, codes.OK},
+		{"status", status.Error(codes.PermissionDenied, "no"), codes.PermissionDenied},
+		{"not found", postNotFound, codes.NotFound},
+		{"wrapped not found", fmt.Errorf("loading: %w", postNotFound), codes.NotFound},
+		{"version conflict", storage.ErrVersionConflict, codes.Aborted},
+		{"category cycle", storage.ErrCategoryCycle, codes.InvalidArgument},
+		{"no fields", storage.ErrNoFieldsToUpdate, codes.InvalidArgument},
+		{"slug taken", storage.ErrCategorySlugTaken, codes.AlreadyExists},
+		{"tag name taken", storage.ErrTagNameTaken, codes.AlreadyExists},
+		{"already reported", storage.ErrAlreadyReported, codes.AlreadyExists},
+		{"foreign key", &pgconn.PgError{Code: pgForeignKeyViolation, ConstraintName: "fk_posts_category_id"}, codes.FailedPrecondition},
+		{"unique", &pgconn.PgError{Code: pgUniqueViolation, TableName: "tags"}, codes.AlreadyExists},
+		{"bad uuid", &pgconn.PgError{Code: pgInvalidTextValue, Message: "invalid input syntax for type uuid"}, codes.InvalidArgument},
+		{"cancelled", context.Canceled, codes.Canceled},
+		{"unexpected", errors.New("connection reset"), codes.Internal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.code, status.Code(StatusError(tt.err)))
+		})
+	}
+}
+
+func TestStatusErrorDetails(t *testing.T) {
+	st := status.Convert(StatusError(&storage.NotFoundError{Resource: "comment"}))
+	if assert.Len(t, st.Details(), 1) {
+		info, ok := st.Details()[0].(*errdetails.ResourceInfo)
+		if assert.True(t, ok) {
+			assert.Equal(t, "comment", info.ResourceType)
+		}
+	}
+
+	st = status.Convert(StatusError(&pgconn.PgError{Code: pgNotNullViolation, ColumnName: "title", Message: "null value"}))
+	if assert.Len(t, st.Details(), 1) {
+		badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+		if assert.True(t, ok) {
+			assert.Equal(t, "title", badRequest.FieldViolations[0].Field)
+		}
+	}
+
+	// Unexpected errors are not sent to clients
+	assert.NotContains(t, status.Convert(StatusError(errors.New("password=secret"))).Message(), "secret")
+}
+
+func TestRequired(t *testing.T) {
+	assert.NoError(t, required("name", "x", "pattern", "y"))
+
+	st := status.Convert(required("name", "x", "pattern", ""))
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Equal(t, "pattern is required", st.Message())
+}
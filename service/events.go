@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+
+	"github.com/Forum-service/Forum-Service/events"
+	"github.com/Forum-service/Forum-Service/storage"
+	"google.golang.org/protobuf/proto"
+)
+
+// recordEvent adds a domain event to the outbox. Call it with the transaction
+// storage that made the change so the event commits or rolls back with it.
+func recordEvent(ctx context.Context, tx storage.StorageI, eventType, aggregateType, aggregateID string, msg proto.Message) error {
+	e, err := events.New(eventType, aggregateType, aggregateID, msg)
+	if err != nil {
+		return err
+	}
+	return tx.Outbox().Add(ctx, e)
+}
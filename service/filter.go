@@ -0,0 +1,368 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/Forum-service/Forum-Service/events"
+	"github.com/Forum-service/Forum-Service/genproto/report"
+	"github.com/Forum-service/Forum-Service/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Actions a content filter can take on matching content.
+const (
+	FilterReject = "reject" // refuse to store the content
+	FilterMask   = "mask"   // store a cleaned-up copy of the content
+	FilterFlag   = "flag"   // store the content and report it for moderator review
+)
+
+// filterReporterID is the reporter of reports filed by content filters.
+const filterReporterID = "00000000-0000-0000-0000-000000000000"
+
+// Content is user-submitted text checked by content filters. Masking
+// filters rewrite Title and Body in place.
+type Content struct {
+	Type   string // post or comment
+	UserID string
+	Title  string // Empty for comments
+	Body   string
+	Edit   bool // Set when existing content is updated
+}
+
+// FilterMatch describes content a filter objected to.
+type FilterMatch struct {
+	Filter string
+	Action string
+	Reason string
+}
+
+// ContentFilter inspects content before it is stored. Check returns nil when
+// the content passes; a filter configured to mask rewrites c before returning.
+type ContentFilter interface {
+	Check(c *Content) *FilterMatch
+}
+
+// ContentRecorder is implemented by filters that need to know which content
+// was actually stored, not just checked.
+type ContentRecorder interface {
+	Record(c *Content)
+}
+
+// ContentFilters runs content filters in order.
+type ContentFilters []ContentFilter
+
+// Run checks c against every filter. The first rejecting match is returned
+// as an InvalidArgument error, otherwise the matches to flag for review are
+// returned.
+func (fs ContentFilters) Run(c *Content) ([]*FilterMatch, error) {
+	var flagged []*FilterMatch
+	for _, f := range fs {
+		match := f.Check(c)
+		if match == nil {
+			continue
+		}
+		switch match.Action {
+		case FilterReject:
+			return nil, status.Errorf(codes.InvalidArgument, "content rejected: %s", match.Reason)
+		case FilterFlag:
+			flagged = append(flagged, match)
+		}
+	}
+	return flagged, nil
+}
+
+// Stored tells the filters that c passed every check and was stored.
+func (fs ContentFilters) Stored(c *Content) {
+	for _, f := range fs {
+		if r, ok := f.(ContentRecorder); ok {
+			r.Record(c)
+		}
+	}
+}
+
+// FilterConfig selects the built-in filters of a deployment. A filter with
+// an empty action is disabled.
+type FilterConfig struct {
+	BannedWords       []string
+	BannedWordsAction string
+
+	MaxLinks    int
+	LinksAction string
+
+	CapsRatio      float64 // Share of upper-case letters considered shouting
+	CapsMinLetters int     // Shorter texts are never shouting
+	CapsAction     string
+
+	RepeatWindow time.Duration
+	RepeatAction string
+}
+
+// NewContentFilters builds the built-in filters enabled in cfg.
+func NewContentFilters(cfg FilterConfig) (ContentFilters, error) {
+	var fs ContentFilters
+	for _, action := range []string{cfg.BannedWordsAction, cfg.LinksAction, cfg.CapsAction, cfg.RepeatAction} {
+		switch action {
+		case "", FilterReject, FilterMask, FilterFlag:
+		default:
+			return nil, fmt.Errorf("unknown content filter action %q", action)
+		}
+	}
+
+	if cfg.BannedWordsAction != "" && len(cfg.BannedWords) > 0 {
+		fs = append(fs, NewBannedWordsFilter(cfg.BannedWords, cfg.BannedWordsAction))
+	}
+	if cfg.LinksAction != "" {
+		fs = append(fs, NewLinksFilter(cfg.MaxLinks, cfg.LinksAction))
+	}
+	if cfg.CapsAction != "" {
+		fs = append(fs, NewCapsFilter(cfg.CapsRatio, cfg.CapsMinLetters, cfg.CapsAction))
+	}
+	if cfg.RepeatAction != "" {
+		if cfg.RepeatAction == FilterMask {
+			return nil, fmt.Errorf("the repeated content filter cannot mask")
+		}
+		fs = append(fs, NewRepeatFilter(cfg.RepeatWindow, cfg.RepeatAction))
+	}
+	return fs, nil
+}
+
+// bannedWordsFilter matches content containing any of a list of words.
+type bannedWordsFilter struct {
+	pattern *regexp.Regexp
+	action  string
+}
+
+// NewBannedWordsFilter matches whole words from words, ignoring case. Masking
+// replaces every letter of a banned word with an asterisk.
+func NewBannedWordsFilter(words []string, action string) ContentFilter {
+	quoted := make([]string, 0, len(words))
+	for _, w := range words {
+		if w = strings.TrimSpace(w); w != "" {
+			quoted = append(quoted, regexp.QuoteMeta(w))
+		}
+	}
+	f := &bannedWordsFilter{action: action}
+	if len(quoted) > 0 {
+		f.pattern = regexp.MustCompile(`(?i)\b(` + strings.Join(quoted, "|") + `)\b`)
+	}
+	return f
+}
+
+func (f *bannedWordsFilter) Check(c *Content) *FilterMatch {
+	if f.pattern == nil {
+		return nil
+	}
+	word := f.pattern.FindString(c.Title + "\n" + c.Body)
+	if word == "" {
+		return nil
+	}
+	if f.action == FilterMask {
+		mask := func(s string) string {
+			return f.pattern.ReplaceAllStringFunc(s, func(w string) string {
+				return strings.Repeat("*", len([]rune(w)))
+			})
+		}
+		c.Title = mask(c.Title)
+		c.Body = mask(c.Body)
+	}
+	return &FilterMatch{Filter: "banned_words", Action: f.action, Reason: fmt.Sprintf("contains banned word %q", strings.ToLower(word))}
+}
+
+// linkPattern matches http(s) and www links.
+var linkPattern = regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s<>"']+`)
+
+// linksFilter matches content with more links than allowed.
+type linksFilter struct {
+	max    int
+	action string
+}
+
+// NewLinksFilter matches content with more than max links. Masking keeps the
+// first max links and replaces the rest.
+func NewLinksFilter(max int, action string) ContentFilter {
+	return &linksFilter{max: max, action: action}
+}
+
+func (f *linksFilter) Check(c *Content) *FilterMatch {
+	count := len(linkPattern.FindAllStringIndex(c.Title, -1)) + len(linkPattern.FindAllStringIndex(c.Body, -1))
+	if count <= f.max {
+		return nil
+	}
+	if f.action == FilterMask {
+		kept := 0
+		mask := func(s string) string {
+			return linkPattern.ReplaceAllStringFunc(s, func(link string) string {
+				if kept < f.max {
+					kept++
+					return link
+				}
+				return "[link removed]"
+			})
+		}
+		c.Title = mask(c.Title)
+		c.Body = mask(c.Body)
+	}
+	return &FilterMatch{Filter: "links", Action: f.action, Reason: fmt.Sprintf("contains %d links, at most %d are allowed", count, f.max)}
+}
+
+// capsFilter matches content written mostly in capital letters.
+type capsFilter struct {
+	ratio      float64
+	minLetters int
+	action     string
+}
+
+// NewCapsFilter matches content with at least minLetters letters of which
+// at least ratio are upper case. Masking lower-cases the content.
+func NewCapsFilter(ratio float64, minLetters int, action string) ContentFilter {
+	return &capsFilter{ratio: ratio, minLetters: minLetters, action: action}
+}
+
+func (f *capsFilter) Check(c *Content) *FilterMatch {
+	if !f.shouting(c.Title) && !f.shouting(c.Body) {
+		return nil
+	}
+	if f.action == FilterMask {
+		if f.shouting(c.Title) {
+			c.Title = strings.ToLower(c.Title)
+		}
+		if f.shouting(c.Body) {
+			c.Body = strings.ToLower(c.Body)
+		}
+	}
+	return &FilterMatch{Filter: "caps", Action: f.action, Reason: "written mostly in capital letters"}
+}
+
+func (f *capsFilter) shouting(s string) bool {
+	letters, upper := 0, 0
+	for _, r := range s {
+		if unicode.IsLetter(r) {
+			letters++
+			if unicode.IsUpper(r) {
+				upper++
+			}
+		}
+	}
+	return letters > 0 && letters >= f.minLetters && float64(upper)/float64(letters) >= f.ratio
+}
+
+// repeatFilter matches a user submitting the same content again within a
+// time window. It remembers recent submissions in memory.
+type repeatFilter struct {
+	window time.Duration
+	action string
+	now    func() time.Time
+
+	mu        sync.Mutex
+	seen      map[string]map[[sha256.Size]byte]time.Time // user -> content hash -> last stored
+	lastSweep time.Time
+}
+
+// NewRepeatFilter matches new content identical, ignoring case and
+// whitespace, to content the same user had stored within window. Edits are
+// not checked, since an edit that keeps the text would repeat itself.
+func NewRepeatFilter(window time.Duration, action string) ContentFilter {
+	return &repeatFilter{window: window, action: action, now: time.Now, seen: map[string]map[[sha256.Size]byte]time.Time{}}
+}
+
+// hash returns the key c is remembered by.
+func (f *repeatFilter) hash(c *Content) [sha256.Size]byte {
+	normalized := strings.ToLower(strings.Join(strings.Fields(c.Type+" "+c.Title+" "+c.Body), " "))
+	return sha256.Sum256([]byte(normalized))
+}
+
+func (f *repeatFilter) Check(c *Content) *FilterMatch {
+	if c.Edit || c.UserID == "" {
+		return nil
+	}
+	hash := f.hash(c)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	at, ok := f.seen[c.UserID][hash]
+	if !ok || f.now().Sub(at) > f.window {
+		return nil
+	}
+	return &FilterMatch{Filter: "repeat", Action: f.action, Reason: fmt.Sprintf("same content was submitted within %s", f.window)}
+}
+
+// Record remembers stored content, so submissions rejected later on, by
+// another filter, automod or storage, do not count as repeated.
+func (f *repeatFilter) Record(c *Content) {
+	if c.Edit || c.UserID == "" {
+		return
+	}
+	hash := f.hash(c)
+	now := f.now()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// Forget expired submissions once per window, so users who stopped
+	// posting do not keep their entries forever
+	if now.Sub(f.lastSweep) > f.window {
+		for user, recent := range f.seen {
+			for h, at := range recent {
+				if now.Sub(at) > f.window {
+					delete(recent, h)
+				}
+			}
+			if len(recent) == 0 {
+				delete(f.seen, user)
+			}
+		}
+		f.lastSweep = now
+	}
+
+	recent := f.seen[c.UserID]
+	if recent == nil {
+		recent = map[[sha256.Size]byte]time.Time{}
+		f.seen[c.UserID] = recent
+	}
+	recent[hash] = now
+}
+
+// flagContent files a report so moderators review content the filters
+// flagged. It runs in tx, the transaction storing the content, so flagged
+// content is never stored unreported. Content that is already reported by
+// the filters is left as is.
+func flagContent(ctx context.Context, tx storage.StorageI, targetType, targetID string, matches []*FilterMatch) error {
+	if len(matches) == 0 {
+		return nil
+	}
+
+	reasons := make([]string, len(matches))
+	for i, m := range matches {
+		reasons[i] = m.Filter + ": " + m.Reason
+	}
+	req := &report.ReportContentRequest{
+		TargetType: targetType,
+		TargetId:   targetID,
+		ReporterId: filterReporterID,
+		Reason:     "content_filter",
+		Details:    strings.Join(reasons, "\n"),
+	}
+
+	// A savepoint keeps a duplicate report from aborting the whole transaction
+	err := tx.Tx(ctx, func(tx storage.StorageI) error {
+		resp, err := tx.Report().Create(ctx, req)
+		if err != nil {
+			return err
+		}
+		return recordEvent(ctx, tx, events.ContentReported, events.AggregateReport, resp.Report.Id, resp.Report)
+	})
+	if errors.Is(err, storage.ErrAlreadyReported) {
+		return nil
+	}
+	return err
+}
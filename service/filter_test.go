@@ -0,0 +1,109 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBannedWordsFilter(t *testing.T) {
+	f := NewBannedWordsFilter([]string{"darn", " heck "}, FilterMask)
+
+	c := &Content{Title: "Darn it", Body: "what the heck, darned thing"}
+	match := f.Check(c)
+	if assert.NotNil(t, match) {
+		assert.Equal(t, FilterMask, match.Action)
+	}
+	assert.Equal(t, "**** it", c.Title)
+	assert.Equal(t, "what the ****, darned thing", c.Body)
+
+	assert.Nil(t, f.Check(&Content{Body: "darned clean"}))
+	assert.Nil(t, NewBannedWordsFilter(nil, FilterReject).Check(&Content{Body: "anything"}))
+}
+
+func TestLinksFilter(t *testing.T) {
+	f := NewLinksFilter(2, FilterMask)
+
+	assert.Nil(t, f.Check(&Content{Body: "see https://a.example and www.b.example"}))
+
+	c := &Content{Title: "https://a.example", Body: "http://b.example www.c.example"}
+	assert.NotNil(t, f.Check(c))
+	assert.Equal(t, "https://a.example", c.Title)
+	assert.Equal(t, "http://b.example [link removed]", c.Body)
+}
+
+func TestCapsFilter(t *testing.T) {
+	f := NewCapsFilter(0.7, 10, FilterMask)
+
+	assert.Nil(t, f.Check(&Content{Title: "OK GO", Body: "A perfectly calm sentence."}))
+
+	c := &Content{Title: "Calm title", Body: "WHY IS NOBODY ANSWERING ME"}
+	assert.NotNil(t, f.Check(c))
+	assert.Equal(t, "Calm title", c.Title)
+	assert.Equal(t, "why is nobody answering me", c.Body)
+}
+
+func TestRepeatFilter(t *testing.T) {
+	now := time.Now()
+	f := NewRepeatFilter(time.Minute, FilterReject).(*repeatFilter)
+	f.now = func() time.Time { return now }
+
+	first := &Content{Type: reportTargetComment, UserID: "u1", Body: "Buy now!"}
+	assert.Nil(t, f.Check(first))
+	// Content is remembered only once it was stored
+	assert.Nil(t, f.Check(first))
+	f.Record(first)
+	assert.NotNil(t, f.Check(&Content{Type: reportTargetComment, UserID: "u1", Body: "  buy   NOW! "}))
+
+	// Other users, edits and other content types are not repeats
+	assert.Nil(t, f.Check(&Content{Type: reportTargetComment, UserID: "u2", Body: "Buy now!"}))
+	assert.Nil(t, f.Check(&Content{Type: reportTargetComment, UserID: "u1", Body: "Buy now!", Edit: true}))
+	assert.Nil(t, f.Check(&Content{Type: reportTargetPost, UserID: "u1", Body: "Buy now!"}))
+
+	now = now.Add(2 * time.Minute)
+	assert.Nil(t, f.Check(first))
+
+	// Expired submissions are swept and users without any are forgotten
+	f.Record(&Content{Type: reportTargetComment, UserID: "u2", Body: "Hello"})
+	assert.NotContains(t, f.seen, "u1")
+	assert.Len(t, f.seen["u2"], 1)
+}
+
+func TestContentFiltersRun(t *testing.T) {
+	fs := ContentFilters{
+		NewCapsFilter(0.7, 5, FilterFlag),
+		NewBannedWordsFilter([]string{"darn"}, FilterMask),
+	}
+
+	c := &Content{Body: "DARN THIS THING"}
+	flagged, err := fs.Run(c)
+	assert.NoError(t, err)
+	if assert.Len(t, flagged, 1) {
+		assert.Equal(t, "caps", flagged[0].Filter)
+	}
+	assert.Equal(t, "**** THIS THING", c.Body)
+
+	fs = append(fs, NewLinksFilter(0, FilterReject))
+	_, err = fs.Run(&Content{Body: "https://spam.example"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// A nil pipeline lets everything through
+	flagged, err = ContentFilters(nil).Run(c)
+	assert.NoError(t, err)
+	assert.Empty(t, flagged)
+}
+
+func TestNewContentFilters(t *testing.T) {
+	fs, err := NewContentFilters(FilterConfig{LinksAction: FilterFlag, CapsAction: FilterReject, BannedWordsAction: FilterMask})
+	assert.NoError(t, err)
+	assert.Len(t, fs, 2, "banned words filter without words is skipped")
+
+	_, err = NewContentFilters(FilterConfig{LinksAction: "delete"})
+	assert.Error(t, err)
+
+	_, err = NewContentFilters(FilterConfig{RepeatAction: FilterMask})
+	assert.Error(t, err)
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+
+	"github.com/Forum-service/Forum-Service/genproto/notification"
+	"github.com/Forum-service/Forum-Service/storage"
+	"github.com/rs/zerolog/log"
+)
+
+// NotificationService implements the notification.NotificationServiceServer interface.
+type NotificationService struct {
+	stg storage.StorageI
+	notification.UnimplementedNotificationServiceServer
+}
+
+// NewNotificationService creates a new NotificationService.
+func NewNotificationService(stg storage.StorageI) *NotificationService {
+	return &NotificationService{stg: stg}
+}
+
+// ListNotifications lists notifications of a user with pagination.
+func (s *NotificationService) ListNotifications(ctx context.Context, req *notification.ListNotificationsRequest) (*notification.ListNotificationsResponse, error) {
+	log.Info().Msg("NotificationService: ListNotifications called")
+
+	var err error
+	if req.UserId, err = actingUser(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.stg.Notification().GetAllNotifications(ctx, req)
+	if err != nil {
+		log.Error().Err(err).Msg("NotificationService: Error listing notifications")
+		return nil, err
+	}
+	for _, n := range resp.Notifications {
+		n.Message = notificationMessage(n)
+	}
+	return resp, nil
+}
+
+// MarkNotificationsRead marks notifications of a user as read.
+func (s *NotificationService) MarkNotificationsRead(ctx context.Context, req *notification.MarkNotificationsReadRequest) (*notification.MarkNotificationsReadResponse, error) {
+	log.Info().Msg("NotificationService: MarkNotificationsRead called")
+
+	var err error
+	if req.UserId, err = actingUser(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.stg.Notification().MarkRead(ctx, req)
+	if err != nil {
+		log.Error().Err(err).Msg("NotificationService: Error marking notifications as read")
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetUnreadCount counts unread notifications of a user.
+func (s *NotificationService) GetUnreadCount(ctx context.Context, req *notification.GetUnreadCountRequest) (*notification.GetUnreadCountResponse, error) {
+	log.Info().Msg("NotificationService: GetUnreadCount called")
+
+	var err error
+	if req.UserId, err = actingUser(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.stg.Notification().GetUnreadCount(ctx, req)
+	if err != nil {
+		log.Error().Err(err).Msg("NotificationService: Error getting unread count")
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetNotificationPreferences returns a preference for every notification type,
+// falling back to enabled for types the user never configured.
+func (s *NotificationService) GetNotificationPreferences(ctx context.Context, req *notification.GetNotificationPreferencesRequest) (*notification.GetNotificationPreferencesResponse, error) {
+	log.Info().Msg("NotificationService: GetNotificationPreferences called")
+
+	var err error
+	if req.UserId, err = actingUser(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.stg.Notification().GetPreferences(ctx, req)
+	if err != nil {
+		log.Error().Err(err).Msg("NotificationService: Error getting notification preferences")
+		return nil, err
+	}
+	return &notification.GetNotificationPreferencesResponse{Preferences: withDefaultPreferences(resp.Preferences)}, nil
+}
+
+// UpdateNotificationPreferences enables or disables notification types for a user.
+func (s *NotificationService) UpdateNotificationPreferences(ctx context.Context, req *notification.UpdateNotificationPreferencesRequest) (*notification.UpdateNotificationPreferencesResponse, error) {
+	log.Info().Msg("NotificationService: UpdateNotificationPreferences called")
+
+	var err error
+	if req.UserId, err = actingUser(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.stg.Notification().UpdatePreferences(ctx, req)
+	if err != nil {
+		log.Error().Err(err).Msg("NotificationService: Error updating notification preferences")
+		return nil, err
+	}
+	return &notification.UpdateNotificationPreferencesResponse{Preferences: withDefaultPreferences(resp.Preferences)}, nil
+}
+
+// FollowCategory subscribes a user to new posts in a category.
+func (s *NotificationService) FollowCategory(ctx context.Context, req *notification.FollowCategoryRequest) (*notification.FollowCategoryResponse, error) {
+	log.Info().Msg("NotificationService: FollowCategory called")
+
+	var err error
+	if req.UserId, err = actingUser(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.stg.Notification().FollowCategory(ctx, req)
+	if err != nil {
+		log.Error().Err(err).Msg("NotificationService: Error following category")
+		return nil, err
+	}
+	return resp, nil
+}
+
+// UnfollowCategory removes a user's subscription to a category.
+func (s *NotificationService) UnfollowCategory(ctx context.Context, req *notification.UnfollowCategoryRequest) (*notification.UnfollowCategoryResponse, error) {
+	log.Info().Msg("NotificationService: UnfollowCategory called")
+
+	var err error
+	if req.UserId, err = actingUser(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.stg.Notification().UnfollowCategory(ctx, req)
+	if err != nil {
+		log.Error().Err(err).Msg("NotificationService: Error unfollowing category")
+		return nil, err
+	}
+	return resp, nil
+}
+
+// withDefaultPreferences fills in enabled preferences for every type missing from stored.
+func withDefaultPreferences(stored []*notification.NotificationPreference) []*notification.NotificationPreference {
+	enabled := make(map[string]bool, len(stored))
+	for _, pref := range stored {
+		enabled[pref.Type] = pref.Enabled
+	}
+
+	preferences := make([]*notification.NotificationPreference, 0, len(notificationTypes))
+	for _, t := range notificationTypes {
+		e, ok := enabled[t]
+		if !ok {
+			e = true
+		}
+		preferences = append(preferences, &notification.NotificationPreference{Type: t, Enabled: e})
+	}
+	return preferences
+}
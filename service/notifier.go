@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/Forum-service/Forum-Service/genproto/comment"
+	"github.com/Forum-service/Forum-Service/genproto/notification"
+	"github.com/Forum-service/Forum-Service/genproto/post"
+	"github.com/Forum-service/Forum-Service/storage"
+	"github.com/rs/zerolog/log"
+)
+
+// Notification types understood by the notifier and the preference RPCs.
+const (
+	NotificationPostComment  = "post_comment"
+	NotificationCommentReply = "comment_reply"
+	NotificationMention      = "mention"
+	NotificationCategoryPost = "category_post"
+)
+
+// notificationTypes lists every notification type, all of which are enabled by default.
+var notificationTypes = []string{
+	NotificationPostComment,
+	NotificationCommentReply,
+	NotificationMention,
+	NotificationCategoryPost,
+}
+
+// mentionPattern matches "@<user uuid>" mentions in comment bodies.
+var mentionPattern = regexp.MustCompile(`@([0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12})`)
+
+// notifier turns forum events into notifications for the affected users.
+// Failures are logged and never fail the operation that triggered them.
+type notifier struct {
+	stg storage.StorageI
+}
+
+func newNotifier(stg storage.StorageI) *notifier {
+	return &notifier{stg: stg}
+}
+
+// CommentCreated notifies the post author, the author of the replied-to comment
+// and every user mentioned in the comment body.
+func (n *notifier) CommentCreated(ctx context.Context, c *comment.Comment) {
+	notified := map[string]bool{c.UserId: true}
+
+	if c.ParentId != "" {
+		parent, err := n.stg.Comment().GetById(ctx, &comment.GetCommentRequest{Id: c.ParentId})
+		if err != nil {
+			log.Error().Err(err).Msg("Notifier: Error getting parent comment")
+		} else if !notified[parent.Comment.UserId] {
+			notified[parent.Comment.UserId] = true
+			n.send(ctx, &notification.Notification{
+				UserId:    parent.Comment.UserId,
+				Type:      NotificationCommentReply,
+				TargetId:  parent.Comment.Id,
+				PostId:    c.PostId,
+				CommentId: c.Id,
+				ActorId:   c.UserId,
+			})
+		}
+	}
+
+	p, err := n.stg.Post().GetById(ctx, &post.GetPostRequest{Id: c.PostId})
+	if err != nil {
+		log.Error().Err(err).Msg("Notifier: Error getting commented post")
+	} else if !notified[p.Post.UserId] {
+		notified[p.Post.UserId] = true
+		n.send(ctx, &notification.Notification{
+			UserId:    p.Post.UserId,
+			Type:      NotificationPostComment,
+			TargetId:  c.PostId,
+			PostId:    c.PostId,
+			CommentId: c.Id,
+			ActorId:   c.UserId,
+		})
+	}
+
+	for _, match := range mentionPattern.FindAllStringSubmatch(c.Body, -1) {
+		userID := match[1]
+		if notified[userID] {
+			continue
+		}
+		notified[userID] = true
+		n.send(ctx, &notification.Notification{
+			UserId:    userID,
+			Type:      NotificationMention,
+			TargetId:  c.Id,
+			PostId:    c.PostId,
+			CommentId: c.Id,
+			ActorId:   c.UserId,
+		})
+	}
+}
+
+// PostCreated notifies every follower of the post's category.
+func (n *notifier) PostCreated(ctx context.Context, p *post.Post) {
+	followers, err := n.stg.Notification().GetCategoryFollowers(ctx, p.CategoryId)
+	if err != nil {
+		log.Error().Err(err).Msg("Notifier: Error getting category followers")
+		return
+	}
+
+	for _, userID := range followers {
+		if userID == p.UserId {
+			continue
+		}
+		n.send(ctx, &notification.Notification{
+			UserId:     userID,
+			Type:       NotificationCategoryPost,
+			TargetId:   p.CategoryId,
+			PostId:     p.Id,
+			CategoryId: p.CategoryId,
+			ActorId:    p.UserId,
+		})
+	}
+}
+
+// send stores a notification unless the recipient has disabled its type.
+func (n *notifier) send(ctx context.Context, nt *notification.Notification) {
+	prefs, err := n.stg.Notification().GetPreferences(ctx, &notification.GetNotificationPreferencesRequest{UserId: nt.UserId})
+	if err != nil {
+		log.Error().Err(err).Msg("Notifier: Error getting notification preferences")
+		return
+	}
+	for _, pref := range prefs.Preferences {
+		if pref.Type == nt.Type && !pref.Enabled {
+			return
+		}
+	}
+
+	if err := n.stg.Notification().Notify(ctx, nt); err != nil {
+		log.Error().Err(err).Msg("Notifier: Error storing notification")
+	}
+}
+
+// notificationMessage renders a short summary of a possibly coalesced notification.
+func notificationMessage(n *notification.Notification) string {
+	title := n.TargetTitle
+	if title == "" {
+		switch n.Type {
+		case NotificationCategoryPost:
+			title = "a deleted category"
+		default:
+			title = "a deleted post"
+		}
+	}
+
+	switch n.Type {
+	case NotificationPostComment:
+		if n.Count > 1 {
+			return fmt.Sprintf("%d new comments on %s", n.Count, title)
+		}
+		return fmt.Sprintf("New comment on %s", title)
+	case NotificationCommentReply:
+		if n.Count > 1 {
+			return fmt.Sprintf("%d new replies to your comment on %s", n.Count, title)
+		}
+		return fmt.Sprintf("New reply to your comment on %s", title)
+	case NotificationMention:
+		return fmt.Sprintf("You were mentioned in a comment on %s", title)
+	case NotificationCategoryPost:
+		if n.Count > 1 {
+			return fmt.Sprintf("%d new posts in %s", n.Count, title)
+		}
+		return fmt.Sprintf("New post in %s", title)
+	}
+	return title
+}
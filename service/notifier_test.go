@@ -0,0 +1,17 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/Forum-service/Forum-Service/genproto/notification"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotificationMessage(t *testing.T) {
+	assert.Equal(t, "3 new comments on Hello", notificationMessage(&notification.Notification{Type: NotificationPostComment, Count: 3, TargetTitle: "Hello"}))
+	assert.Equal(t, "New post in Go", notificationMessage(&notification.Notification{Type: NotificationCategoryPost, Count: 1, TargetTitle: "Go"}))
+
+	// Targets that are gone are named by their kind
+	assert.Equal(t, "New reply to your comment on a deleted post", notificationMessage(&notification.Notification{Type: NotificationCommentReply, Count: 1}))
+	assert.Equal(t, "2 new posts in a deleted category", notificationMessage(&notification.Notification{Type: NotificationCategoryPost, Count: 2}))
+}
@@ -0,0 +1,30 @@
+package service
+
+import (
+	"github.com/Forum-service/Forum-Service/genproto/automod"
+	"github.com/Forum-service/Forum-Service/genproto/category"
+	"github.com/Forum-service/Forum-Service/genproto/tag"
+	"github.com/Forum-service/Forum-Service/middleware"
+)
+
+// Policy lists the RPCs that need more than a member role. Moderating posts
+// and comments, editing or deleting them and reading the moderation queue is
+// checked by the services themselves, since it depends on who wrote the
+// content and on the moderators of its category.
+var Policy = middleware.Policy{
+	// Only admins shape the category tree and pick its moderators
+	category.CategoryService_CreateCategory_FullMethodName:          middleware.RoleAdmin,
+	category.CategoryService_UpdateCategory_FullMethodName:          middleware.RoleAdmin,
+	category.CategoryService_DeleteCategory_FullMethodName:          middleware.RoleAdmin,
+	category.CategoryService_ReorderCategories_FullMethodName:       middleware.RoleAdmin,
+	category.CategoryService_MergeCategories_FullMethodName:         middleware.RoleAdmin,
+	category.CategoryService_AddCategoryModerator_FullMethodName:    middleware.RoleAdmin,
+	category.CategoryService_RemoveCategoryModerator_FullMethodName: middleware.RoleAdmin,
+
+	tag.TagService_CreateTag_FullMethodName: middleware.RoleModerator,
+	tag.TagService_UpdateTag_FullMethodName: middleware.RoleModerator,
+	tag.TagService_DeleteTag_FullMethodName: middleware.RoleModerator,
+	tag.TagService_MergeTags_FullMethodName: middleware.RoleModerator,
+
+	"/" + automod.AutomodService_ServiceDesc.ServiceName + "/*": middleware.RoleModerator,
+}
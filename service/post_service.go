@@ -3,31 +3,92 @@ package service
 import (
 	"context"
 
+	"github.com/Forum-service/Forum-Service/events"
 	"github.com/Forum-service/Forum-Service/genproto/post"
+	"github.com/Forum-service/Forum-Service/middleware"
 	"github.com/Forum-service/Forum-Service/storage"
 	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // PostService implements the post.PostServiceServer interface.
 type PostService struct {
-	stg storage.StorageI
+	stg      storage.StorageI
+	notifier *notifier
+	filters  ContentFilters
+	automod  *automodEvaluator
 	post.UnimplementedPostServiceServer
 }
 
-// NewPostService creates a new PostService.
-func NewPostService(stg storage.StorageI) *PostService {
-	return &PostService{stg: stg}
+// NewPostService creates a new PostService. New and edited posts are checked
+// against filters, new posts also against the automod rules.
+func NewPostService(stg storage.StorageI, filters ContentFilters) *PostService {
+	return &PostService{stg: stg, notifier: newNotifier(stg), filters: filters, automod: newAutomodEvaluator()}
 }
 
 // CreatePost creates a new post.
 func (s *PostService) CreatePost(ctx context.Context, req *post.CreatePostRequest) (*post.CreatePostResponse, error) {
 	log.Info().Msg("PostService: CreatePost called")
 
-	resp, err := s.stg.Post().Create(ctx, req)
+	var err error
+	if req.UserId, err = actingUser(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
+	content := &Content{Type: reportTargetPost, UserID: req.UserId, Title: req.Title, Body: req.Body}
+	flagged, err := s.filters.Run(content)
+	if err != nil {
+		log.Error().Err(err).Msg("PostService: Post rejected by content filter")
+		return nil, err
+	}
+	req.Title, req.Body = content.Title, content.Body
+
+	verdict, err := s.automod.evaluate(ctx, s.stg, content, req.CategoryId)
+	if err != nil {
+		log.Error().Err(err).Msg("PostService: Error evaluating automod rules")
+		return nil, err
+	}
+	if err := verdict.err(); err != nil {
+		log.Error().Err(err).Msg("PostService: Post rejected by automod")
+		if err := s.automod.recordHits(ctx, s.stg, verdict); err != nil {
+			log.Error().Err(err).Msg("PostService: Error recording automod rule hits")
+		}
+		return nil, err
+	}
+
+	var resp *post.CreatePostResponse
+	err = s.stg.Tx(ctx, func(tx storage.StorageI) error {
+		var err error
+		resp, err = tx.Post().Create(ctx, req)
+		if err != nil {
+			return err
+		}
+		held, err := verdict.apply(ctx, tx, reportTargetPost, resp.Post.Id)
+		if err != nil {
+			return err
+		}
+		resp.Post.Hidden = held
+		// Held posts are announced once a moderator releases them
+		if !held {
+			if err := recordEvent(ctx, tx, events.PostCreated, events.AggregatePost, resp.Post.Id, resp.Post); err != nil {
+				return err
+			}
+		}
+		if err := s.automod.recordHits(ctx, tx, verdict); err != nil {
+			return err
+		}
+		return flagContent(ctx, tx, reportTargetPost, resp.Post.Id, flagged)
+	})
 	if err != nil {
 		log.Error().Err(err).Msg("PostService: Error creating post")
 		return nil, err
 	}
+
+	s.filters.Stored(content)
+	if !resp.Post.Hidden {
+		s.notifier.PostCreated(ctx, resp.Post)
+	}
 	return resp, nil
 }
 
@@ -40,6 +101,10 @@ func (s *PostService) GetPost(ctx context.Context, req *post.GetPostRequest) (*p
 		log.Error().Err(err).Msg("PostService: Error getting post by ID")
 		return nil, err
 	}
+	p := resp.Post
+	if err := checkHiddenVisible(ctx, s.stg, p.Hidden, p.UserId, p.Id, "post"); err != nil {
+		return nil, err
+	}
 	return resp, nil
 }
 
@@ -47,11 +112,50 @@ func (s *PostService) GetPost(ctx context.Context, req *post.GetPostRequest) (*p
 func (s *PostService) UpdatePost(ctx context.Context, req *post.UpdatePostRequest) (*post.UpdatePostResponse, error) {
 	log.Info().Msg("PostService: UpdatePost called")
 
-	resp, err := s.stg.Post().Update(ctx, req)
+	existing, err := s.checkAuthor(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	// Moderators can only move other users' posts into categories they moderate as well
+	if id, ok := middleware.IdentityFrom(ctx); ok && id.UserID != existing.UserId && req.CategoryId != "" && req.CategoryId != existing.CategoryId {
+		moderator, err := moderatesCategory(ctx, s.stg, req.CategoryId, false)
+		if err != nil {
+			return nil, err
+		}
+		if !moderator {
+			return nil, status.Error(codes.PermissionDenied, "posts can only be moved into categories you moderate")
+		}
+	}
+
+	if req.EditorId, err = actingUser(ctx, req.EditorId); err != nil {
+		return nil, err
+	}
+
+	content := &Content{Type: reportTargetPost, UserID: req.EditorId, Title: req.Title, Body: req.Body, Edit: true}
+	flagged, err := s.filters.Run(content)
+	if err != nil {
+		log.Error().Err(err).Msg("PostService: Post edit rejected by content filter")
+		return nil, err
+	}
+	req.Title, req.Body = content.Title, content.Body
+
+	var resp *post.UpdatePostResponse
+	err = s.stg.Tx(ctx, func(tx storage.StorageI) error {
+		var err error
+		resp, err = tx.Post().Update(ctx, req)
+		if err != nil {
+			return err
+		}
+		if err := recordEvent(ctx, tx, events.PostUpdated, events.AggregatePost, resp.Post.Id, resp.Post); err != nil {
+			return err
+		}
+		return flagContent(ctx, tx, reportTargetPost, resp.Post.Id, flagged)
+	})
 	if err != nil {
 		log.Error().Err(err).Msg("PostService: Error updating post")
 		return nil, err
 	}
+
 	return resp, nil
 }
 
@@ -59,7 +163,19 @@ func (s *PostService) UpdatePost(ctx context.Context, req *post.UpdatePostReques
 func (s *PostService) DeletePost(ctx context.Context, req *post.DeletePostRequest) (*post.DeletePostResponse, error) {
 	log.Info().Msg("PostService: DeletePost called")
 
-	resp, err := s.stg.Post().Delete(ctx, req)
+	if _, err := s.checkAuthor(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	var resp *post.DeletePostResponse
+	err := s.stg.Tx(ctx, func(tx storage.StorageI) error {
+		var err error
+		resp, err = tx.Post().Delete(ctx, req)
+		if err != nil {
+			return err
+		}
+		return recordEvent(ctx, tx, events.PostDeleted, events.AggregatePost, req.Id, req)
+	})
 	if err != nil {
 		log.Error().Err(err).Msg("PostService: Error deleting post")
 		return nil, err
@@ -78,3 +194,216 @@ func (s *PostService) GetAllPosts(ctx context.Context, req *post.GetAllPostsRequ
 	}
 	return resp, nil
 }
+
+// GetPostRevisions lists the revisions of a post with pagination.
+func (s *PostService) GetPostRevisions(ctx context.Context, req *post.GetPostRevisionsRequest) (*post.GetPostRevisionsResponse, error) {
+	log.Info().Msg("PostService: GetPostRevisions called")
+
+	if err := s.checkVisible(ctx, req.PostId); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.stg.Post().GetRevisions(ctx, req)
+	if err != nil {
+		log.Error().Err(err).Msg("PostService: Error getting post revisions")
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetPostRevisionDiff diffs two versions of a post line by line.
+func (s *PostService) GetPostRevisionDiff(ctx context.Context, req *post.GetPostRevisionDiffRequest) (*post.GetPostRevisionDiffResponse, error) {
+	log.Info().Msg("PostService: GetPostRevisionDiff called")
+
+	if err := s.checkVisible(ctx, req.PostId); err != nil {
+		return nil, err
+	}
+
+	from, err := postVersion(ctx, s.stg, req.PostId, req.FromRevision)
+	if err != nil {
+		log.Error().Err(err).Msg("PostService: Error getting post revision to diff from")
+		return nil, err
+	}
+	to, err := postVersion(ctx, s.stg, req.PostId, req.ToRevision)
+	if err != nil {
+		log.Error().Err(err).Msg("PostService: Error getting post revision to diff to")
+		return nil, err
+	}
+
+	return &post.GetPostRevisionDiffResponse{
+		Title:          lineDiff(from.Title, to.Title),
+		Body:           lineDiff(from.Body, to.Body),
+		FromCategoryId: from.CategoryId,
+		ToCategoryId:   to.CategoryId,
+	}, nil
+}
+
+// RevertPost restores the content of an earlier version. The replaced
+// content is kept as a new revision, so a revert can itself be reverted.
+func (s *PostService) RevertPost(ctx context.Context, req *post.RevertPostRequest) (*post.RevertPostResponse, error) {
+	log.Info().Msg("PostService: RevertPost called")
+
+	if _, err := s.checkAuthor(ctx, req.PostId); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if req.EditorId, err = actingUser(ctx, req.EditorId); err != nil {
+		return nil, err
+	}
+
+	var resp *post.UpdatePostResponse
+	err = s.stg.Tx(ctx, func(tx storage.StorageI) error {
+		target, err := postVersion(ctx, tx, req.PostId, req.Revision)
+		if err != nil {
+			return err
+		}
+
+		// Restored content passes the filters like any edit, as they may have
+		// changed since it was written
+		content := &Content{Type: reportTargetPost, UserID: req.EditorId, Title: target.Title, Body: target.Body, Edit: true}
+		flagged, err := s.filters.Run(content)
+		if err != nil {
+			log.Error().Err(err).Msg("PostService: Post revert rejected by content filter")
+			return err
+		}
+
+		resp, err = tx.Post().Update(ctx, &post.UpdatePostRequest{
+			Id:              req.PostId,
+			Title:           content.Title,
+			Body:            content.Body,
+			CategoryId:      target.CategoryId,
+			EditorId:        req.EditorId,
+			ExpectedVersion: req.ExpectedVersion,
+		})
+		if err != nil {
+			return err
+		}
+		if err := recordEvent(ctx, tx, events.PostUpdated, events.AggregatePost, resp.Post.Id, resp.Post); err != nil {
+			return err
+		}
+		return flagContent(ctx, tx, reportTargetPost, resp.Post.Id, flagged)
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("PostService: Error reverting post")
+		return nil, err
+	}
+	return &post.RevertPostResponse{Post: resp.Post}, nil
+}
+
+// checkVisible returns a NotFoundError unless the caller may read the post,
+// which for hidden posts takes being its author or a moderator.
+func (s *PostService) checkVisible(ctx context.Context, postID string) error {
+	resp, err := s.stg.Post().GetById(ctx, &post.GetPostRequest{Id: postID})
+	if err != nil {
+		log.Error().Err(err).Msg("PostService: Error getting post to check its visibility")
+		return err
+	}
+	p := resp.Post
+	return checkHiddenVisible(ctx, s.stg, p.Hidden, p.UserId, p.Id, "post")
+}
+
+// checkAuthor returns the post, or PermissionDenied unless the caller wrote
+// the post or moderates its category.
+func (s *PostService) checkAuthor(ctx context.Context, postID string) (*post.Post, error) {
+	existing, err := s.stg.Post().GetById(ctx, &post.GetPostRequest{Id: postID})
+	if err != nil {
+		log.Error().Err(err).Msg("PostService: Error getting post to check its author")
+		return nil, err
+	}
+	if err := checkOwner(ctx, s.stg, existing.Post.UserId, postID); err != nil {
+		return nil, err
+	}
+	return existing.Post, nil
+}
+
+// postVersion returns the content of version revision of a post. Revision 0,
+// like revision_count + 1, stands for the current content.
+func postVersion(ctx context.Context, stg storage.StorageI, postID string, revision int32) (*post.PostRevision, error) {
+	current, err := stg.Post().GetById(ctx, &post.GetPostRequest{Id: postID})
+	if err != nil {
+		return nil, err
+	}
+
+	if revision == 0 || revision == current.Post.RevisionCount+1 {
+		return &post.PostRevision{
+			PostId:     current.Post.Id,
+			Revision:   current.Post.RevisionCount + 1,
+			Title:      current.Post.Title,
+			Body:       current.Post.Body,
+			CategoryId: current.Post.CategoryId,
+		}, nil
+	}
+	return stg.Post().GetRevision(ctx, postID, revision)
+}
+
+// PinPost pins a post to the top of its category or unpins it.
+func (s *PostService) PinPost(ctx context.Context, req *post.PinPostRequest) (*post.ModeratePostResponse, error) {
+	log.Info().Msg("PostService: PinPost called")
+
+	resp, err := s.moderate(ctx, req.PostId, func(tx storage.StorageI) (*post.ModeratePostResponse, error) {
+		return tx.Post().Pin(ctx, req)
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("PostService: Error pinning post")
+		return nil, err
+	}
+	return resp, nil
+}
+
+// LockPost stops or resumes accepting comments on a post.
+func (s *PostService) LockPost(ctx context.Context, req *post.LockPostRequest) (*post.ModeratePostResponse, error) {
+	log.Info().Msg("PostService: LockPost called")
+
+	resp, err := s.moderate(ctx, req.PostId, func(tx storage.StorageI) (*post.ModeratePostResponse, error) {
+		return tx.Post().Lock(ctx, req)
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("PostService: Error locking post")
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ClosePost closes a post with a reason, optionally as a duplicate of another post, or reopens it.
+func (s *PostService) ClosePost(ctx context.Context, req *post.ClosePostRequest) (*post.ModeratePostResponse, error) {
+	log.Info().Msg("PostService: ClosePost called")
+
+	if req.Closed && req.DuplicateOfId != "" {
+		if req.DuplicateOfId == req.PostId {
+			return nil, invalidArgument("duplicate_of_id", "a post cannot duplicate itself")
+		}
+		if _, err := s.stg.Post().GetById(ctx, &post.GetPostRequest{Id: req.DuplicateOfId}); err != nil {
+			log.Error().Err(err).Msg("PostService: Error getting duplicated post")
+			return nil, err
+		}
+	}
+
+	resp, err := s.moderate(ctx, req.PostId, func(tx storage.StorageI) (*post.ModeratePostResponse, error) {
+		return tx.Post().Close(ctx, req)
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("PostService: Error closing post")
+		return nil, err
+	}
+	return resp, nil
+}
+
+// moderate runs a post status change and records the updated post in one
+// transaction. Only moderators of the post's category may change its status.
+func (s *PostService) moderate(ctx context.Context, postID string, change func(tx storage.StorageI) (*post.ModeratePostResponse, error)) (*post.ModeratePostResponse, error) {
+	if err := checkModerator(ctx, s.stg, postID); err != nil {
+		return nil, err
+	}
+
+	var resp *post.ModeratePostResponse
+	err := s.stg.Tx(ctx, func(tx storage.StorageI) error {
+		var err error
+		resp, err = change(tx)
+		if err != nil {
+			return err
+		}
+		return recordEvent(ctx, tx, events.PostUpdated, events.AggregatePost, resp.Post.Id, resp.Post)
+	})
+	return resp, err
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Forum-service/Forum-Service/genproto/post"
+	"github.com/Forum-service/Forum-Service/middleware"
+	"github.com/Forum-service/Forum-Service/storage"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestModeratorsMovePostsOnlyIntoModeratedCategories(t *testing.T) {
+	stg := newFakeStorage()
+	stg.posts.posts["p1"] = &post.Post{Id: "p1", UserId: "author", CategoryId: "general"}
+	stg.moderators = map[string][]string{"general": {"mod"}}
+	s := NewPostService(stg, nil)
+
+	moderator := middleware.WithIdentity(context.Background(), &middleware.Identity{UserID: "mod"})
+	_, err := s.UpdatePost(moderator, &post.UpdatePostRequest{Id: "p1", Title: "Moved", CategoryId: "off-topic"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestRevertPostChecksContent(t *testing.T) {
+	stg := newFakeStorage()
+	stg.posts.posts["p1"] = &post.Post{Id: "p1", UserId: "author", Title: "Clean", Body: "Clean body", Version: 3, RevisionCount: 1}
+	stg.posts.revisions = map[string]*post.PostRevision{"p1/1": {PostId: "p1", Revision: 1, Title: "Darn title", Body: "What the darn"}}
+	filters := ContentFilters{NewBannedWordsFilter([]string{"darn"}, FilterMask)}
+	s := NewPostService(stg, filters)
+
+	_, err := s.RevertPost(context.Background(), &post.RevertPostRequest{PostId: "p1", Revision: 1, ExpectedVersion: 2})
+	assert.ErrorIs(t, err, storage.ErrVersionConflict)
+
+	resp, err := s.RevertPost(context.Background(), &post.RevertPostRequest{PostId: "p1", Revision: 1, ExpectedVersion: 3})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "**** title", resp.Post.Title)
+		assert.Equal(t, "What the ****", resp.Post.Body)
+	}
+
+	// Content the filters now reject cannot be restored
+	s = NewPostService(stg, ContentFilters{NewBannedWordsFilter([]string{"darn"}, FilterReject)})
+	_, err = s.RevertPost(context.Background(), &post.RevertPostRequest{PostId: "p1", Revision: 1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -3,9 +3,14 @@ package service
 import (
 	"context"
 
+	"github.com/Forum-service/Forum-Service/events"
+	"github.com/Forum-service/Forum-Service/genproto/post"
 	"github.com/Forum-service/Forum-Service/genproto/posttag"
+	"github.com/Forum-service/Forum-Service/genproto/tag"
 	"github.com/Forum-service/Forum-Service/storage"
 	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // PostTagService implements the posttag.PostTagServiceServer interface.
@@ -19,11 +24,49 @@ func NewPostTagService(stg storage.StorageI) *PostTagService {
 	return &PostTagService{stg: stg}
 }
 
-// CreatePostTag creates a new post-tag association.
+// CreatePostTag creates a new post-tag association. The tag may be given by
+// name; names and IDs of merged tags resolve to their canonical tag.
 func (s *PostTagService) CreatePostTag(ctx context.Context, req *posttag.CreatePostTagRequest) (*posttag.CreatePostTagResponse, error) {
 	log.Info().Msg("PostTagService: CreatePostTag called")
 
-	resp, err := s.stg.PostTag().Create(ctx, req)
+	if req.TagId == "" && req.TagName == "" {
+		return nil, invalidArgument("tag_id", "tag_id or tag_name is required")
+	}
+
+	var resp *posttag.CreatePostTagResponse
+	err := s.stg.Tx(ctx, func(tx storage.StorageI) error {
+		var (
+			tagResp *tag.GetTagResponse
+			err     error
+		)
+		if req.TagName != "" {
+			tagResp, err = tx.Tag().GetByName(ctx, &tag.GetTagByNameRequest{Name: req.TagName})
+		} else {
+			tagResp, err = tx.Tag().GetById(ctx, &tag.GetTagRequest{Id: req.TagId})
+		}
+		if err != nil {
+			return err
+		}
+		if err := checkPostOwner(ctx, tx, req.PostId); err != nil {
+			return err
+		}
+		if tagResp.Tag.Restricted {
+			moderator, err := moderatesPost(ctx, tx, req.PostId, req.Moderator)
+			if err != nil {
+				return err
+			}
+			if !moderator {
+				return status.Errorf(codes.PermissionDenied, "tag %q can only be applied by moderators", tagResp.Tag.Name)
+			}
+		}
+		req.TagId = tagResp.Tag.Id
+
+		resp, err = tx.PostTag().Create(ctx, req)
+		if err != nil {
+			return err
+		}
+		return recordEvent(ctx, tx, events.TagAttached, events.AggregatePost, resp.PostTag.PostId, resp.PostTag)
+	})
 	if err != nil {
 		log.Error().Err(err).Msg("PostTagService: Error creating post-tag association")
 		return nil, err
@@ -31,11 +74,36 @@ func (s *PostTagService) CreatePostTag(ctx context.Context, req *posttag.CreateP
 	return resp, nil
 }
 
-// DeletePostTag deletes a post-tag association.
+// DeletePostTag deletes a post-tag association. Like applying them, removing
+// restricted tags takes a moderator.
 func (s *PostTagService) DeletePostTag(ctx context.Context, req *posttag.DeletePostTagRequest) (*posttag.DeletePostTagResponse, error) {
 	log.Info().Msg("PostTagService: DeletePostTag called")
 
-	resp, err := s.stg.PostTag().Delete(ctx, req)
+	var resp *posttag.DeletePostTagResponse
+	err := s.stg.Tx(ctx, func(tx storage.StorageI) error {
+		if err := checkPostOwner(ctx, tx, req.PostId); err != nil {
+			return err
+		}
+		tagResp, err := tx.Tag().GetById(ctx, &tag.GetTagRequest{Id: req.TagId})
+		if err != nil {
+			return err
+		}
+		if tagResp.Tag.Restricted {
+			moderator, err := moderatesPost(ctx, tx, req.PostId, req.Moderator)
+			if err != nil {
+				return err
+			}
+			if !moderator {
+				return status.Errorf(codes.PermissionDenied, "tag %q can only be removed by moderators", tagResp.Tag.Name)
+			}
+		}
+
+		resp, err = tx.PostTag().Delete(ctx, req)
+		if err != nil {
+			return err
+		}
+		return recordEvent(ctx, tx, events.TagDetached, events.AggregatePost, req.PostId, req)
+	})
 	if err != nil {
 		log.Error().Err(err).Msg("PostTagService: Error deleting post-tag association")
 		return nil, err
@@ -43,6 +111,16 @@ func (s *PostTagService) DeletePostTag(ctx context.Context, req *posttag.DeleteP
 	return resp, nil
 }
 
+// checkPostOwner returns PermissionDenied unless the caller wrote postID or
+// moderates its category.
+func checkPostOwner(ctx context.Context, tx storage.StorageI, postID string) error {
+	p, err := tx.Post().GetById(ctx, &post.GetPostRequest{Id: postID})
+	if err != nil {
+		return err
+	}
+	return checkOwner(ctx, tx, p.Post.UserId, p.Post.Id)
+}
+
 // GetAllPostTags lists post-tag associations with filtering and pagination.
 func (s *PostTagService) GetAllPostTags(ctx context.Context, req *posttag.GetAllPostTagsRequest) (*posttag.GetAllPostTagsResponse, error) {
 	log.Info().Msg("PostTagService: GetAllPostTags called")
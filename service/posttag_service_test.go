@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Forum-service/Forum-Service/genproto/post"
+	"github.com/Forum-service/Forum-Service/genproto/posttag"
+	"github.com/Forum-service/Forum-Service/genproto/tag"
+	"github.com/Forum-service/Forum-Service/middleware"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPostTagsNeedPostOwner(t *testing.T) {
+	stg := newFakeStorage()
+	stg.posts.posts["p1"] = &post.Post{Id: "p1", UserId: "author"}
+	stg.tags.tags["t1"] = &tag.Tag{Id: "t1", Name: "go"}
+	stg.tags.tags["t2"] = &tag.Tag{Id: "t2", Name: "announcement", Restricted: true}
+	s := NewPostTagService(stg)
+
+	stranger := middleware.WithIdentity(context.Background(), &middleware.Identity{UserID: "stranger"})
+	_, err := s.CreatePostTag(stranger, &posttag.CreatePostTagRequest{PostId: "p1", TagId: "t1"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = s.DeletePostTag(stranger, &posttag.DeletePostTagRequest{PostId: "p1", TagId: "t1"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// Authors cannot remove restricted tags either
+	author := middleware.WithIdentity(context.Background(), &middleware.Identity{UserID: "author"})
+	_, err = s.DeletePostTag(author, &posttag.DeletePostTagRequest{PostId: "p1", TagId: "t2"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
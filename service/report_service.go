@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+
+	"github.com/Forum-service/Forum-Service/events"
+	"github.com/Forum-service/Forum-Service/genproto/comment"
+	"github.com/Forum-service/Forum-Service/genproto/post"
+	"github.com/Forum-service/Forum-Service/genproto/report"
+	"github.com/Forum-service/Forum-Service/middleware"
+	"github.com/Forum-service/Forum-Service/storage"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Reportable content types.
+const (
+	reportTargetPost    = "post"
+	reportTargetComment = "comment"
+)
+
+// Actions a moderator can take on a report.
+const (
+	reportActionDismiss = "dismiss"
+	reportActionHide    = "hide"
+	reportActionDelete  = "delete"
+)
+
+// ReportService implements the report.ReportServiceServer interface.
+type ReportService struct {
+	stg storage.StorageI
+	report.UnimplementedReportServiceServer
+}
+
+// NewReportService creates a new ReportService.
+func NewReportService(stg storage.StorageI) *ReportService {
+	return &ReportService{stg: stg}
+}
+
+// ReportContent flags a post or comment for moderator review.
+func (s *ReportService) ReportContent(ctx context.Context, req *report.ReportContentRequest) (*report.ReportContentResponse, error) {
+	log.Info().Msg("ReportService: ReportContent called")
+
+	var err error
+	if req.ReporterId, err = actingUser(ctx, req.ReporterId); err != nil {
+		return nil, err
+	}
+
+	if err := required("reason", req.Reason, "reporter_id", req.ReporterId); err != nil {
+		return nil, err
+	}
+
+	// Make sure the reported content exists
+	switch req.TargetType {
+	case reportTargetPost:
+		_, err = s.stg.Post().GetById(ctx, &post.GetPostRequest{Id: req.TargetId})
+	case reportTargetComment:
+		_, err = s.stg.Comment().GetById(ctx, &comment.GetCommentRequest{Id: req.TargetId})
+	default:
+		return nil, invalidArgument("target_type", "target_type must be post or comment")
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("ReportService: Error getting reported content")
+		return nil, err
+	}
+
+	var resp *report.ReportContentResponse
+	err = s.stg.Tx(ctx, func(tx storage.StorageI) error {
+		var err error
+		resp, err = tx.Report().Create(ctx, req)
+		if err != nil {
+			return err
+		}
+		return recordEvent(ctx, tx, events.ContentReported, events.AggregateReport, resp.Report.Id, resp.Report)
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("ReportService: Error reporting content")
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetModerationQueue lists content with open reports, grouped by content.
+// Global moderators see every report, other members only those in the
+// categories they moderate.
+func (s *ReportService) GetModerationQueue(ctx context.Context, req *report.GetModerationQueueRequest) (*report.GetModerationQueueResponse, error) {
+	log.Info().Msg("ReportService: GetModerationQueue called")
+
+	var moderatorID string
+	if id, ok := middleware.IdentityFrom(ctx); ok && !id.HasRole(middleware.RoleModerator) {
+		moderatorID = id.UserID
+	}
+
+	resp, err := s.stg.Report().GetQueue(ctx, req, moderatorID)
+	if err != nil {
+		log.Error().Err(err).Msg("ReportService: Error getting moderation queue")
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ResolveReport applies a moderator action to the reported content and
+// closes every open report on it.
+func (s *ReportService) ResolveReport(ctx context.Context, req *report.ResolveReportRequest) (*report.ResolveReportResponse, error) {
+	log.Info().Msg("ReportService: ResolveReport called")
+
+	var err error
+	if req.ModeratorId, err = actingUser(ctx, req.ModeratorId); err != nil {
+		return nil, err
+	}
+
+	switch req.Action {
+	case reportActionDismiss, reportActionHide, reportActionDelete:
+	default:
+		return nil, invalidArgument("action", "action must be dismiss, hide or delete")
+	}
+
+	var resp *report.ResolveReportResponse
+	err = s.stg.Tx(ctx, func(tx storage.StorageI) error {
+		r, err := tx.Report().GetById(ctx, req.ReportId)
+		if err != nil {
+			return err
+		}
+		if r.Status != "open" {
+			return status.Error(codes.FailedPrecondition, "report is already resolved")
+		}
+		postID, err := reportedPost(ctx, tx, r)
+		if err != nil {
+			return err
+		}
+		if err := checkModerator(ctx, tx, postID); err != nil {
+			return err
+		}
+
+		if err := applyReportAction(ctx, tx, r, req.Action); err != nil {
+			return err
+		}
+
+		resp, err = tx.Report().Resolve(ctx, req)
+		if err != nil {
+			return err
+		}
+		if req.Action == reportActionDismiss && heldByAutomod(resp.Reports) {
+			if err := releaseContent(ctx, tx, r); err != nil {
+				return err
+			}
+		}
+		for _, resolved := range resp.Reports {
+			if err := recordEvent(ctx, tx, events.ReportResolved, events.AggregateReport, resolved.Id, resolved); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("ReportService: Error resolving report")
+		return nil, err
+	}
+	return resp, nil
+}
+
+// applyReportAction hides or deletes the content r points at. Deletions go
+// through the regular delete paths and emit the usual events.
+func applyReportAction(ctx context.Context, tx storage.StorageI, r *report.Report, action string) error {
+	switch {
+	case action == reportActionHide && r.TargetType == reportTargetPost:
+		return tx.Post().SetHidden(ctx, r.TargetId, true)
+	case action == reportActionHide && r.TargetType == reportTargetComment:
+		return tx.Comment().SetHidden(ctx, r.TargetId, true)
+	case action == reportActionDelete && r.TargetType == reportTargetPost:
+		req := &post.DeletePostRequest{Id: r.TargetId}
+		if _, err := tx.Post().Delete(ctx, req); err != nil {
+			return err
+		}
+		return recordEvent(ctx, tx, events.PostDeleted, events.AggregatePost, req.Id, req)
+	case action == reportActionDelete && r.TargetType == reportTargetComment:
+		req := &comment.DeleteCommentRequest{Id: r.TargetId}
+		if _, err := tx.Comment().Delete(ctx, req); err != nil {
+			return err
+		}
+		return recordEvent(ctx, tx, events.CommentDeleted, events.AggregateComment, req.Id, req)
+	}
+	return nil
+}
+
+// reportedPost returns the ID of the reported post, or of the post the
+// reported comment belongs to.
+func reportedPost(ctx context.Context, tx storage.StorageI, r *report.Report) (string, error) {
+	if r.TargetType == reportTargetPost {
+		return r.TargetId, nil
+	}
+	c, err := tx.Comment().GetById(ctx, &comment.GetCommentRequest{Id: r.TargetId})
+	if err != nil {
+		return "", err
+	}
+	return c.Comment.PostId, nil
+}
+
+// heldByAutomod reports whether one of reports was filed by an automod hold.
+func heldByAutomod(reports []*report.Report) bool {
+	for _, r := range reports {
+		if r.Reason == automodHoldReason {
+			return true
+		}
+	}
+	return false
+}
+
+// releaseContent makes content held by automod visible and records the
+// created event that was held back with it.
+func releaseContent(ctx context.Context, tx storage.StorageI, r *report.Report) error {
+	if r.TargetType == reportTargetPost {
+		if err := tx.Post().SetHidden(ctx, r.TargetId, false); err != nil {
+			return err
+		}
+		resp, err := tx.Post().GetById(ctx, &post.GetPostRequest{Id: r.TargetId})
+		if err != nil {
+			return err
+		}
+		return recordEvent(ctx, tx, events.PostCreated, events.AggregatePost, resp.Post.Id, resp.Post)
+	}
+	if err := tx.Comment().SetHidden(ctx, r.TargetId, false); err != nil {
+		return err
+	}
+	resp, err := tx.Comment().GetById(ctx, &comment.GetCommentRequest{Id: r.TargetId})
+	if err != nil {
+		return err
+	}
+	return recordEvent(ctx, tx, events.CommentCreated, events.AggregateComment, resp.Comment.Id, resp.Comment)
+}
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"strings"
+	"unicode"
+)
+
+// slugify derives a URL friendly slug from name: lower-case ASCII letters
+// and digits separated by single hyphens. Names without any such characters
+// produce an empty slug, which storage replaces with the record ID.
+func slugify(name string) string {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(name) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			if hyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			hyphen = false
+		} else {
+			hyphen = true
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlugify(t *testing.T) {
+	assert.Equal(t, "general-discussion", slugify("General Discussion"))
+	assert.Equal(t, "go-rust", slugify("  Go & Rust!  "))
+	assert.Equal(t, "version-2-0", slugify("Version 2.0"))
+	assert.Equal(t, "caf", slugify("Café"))
+}
+
+func TestSlugifyWithoutASCII(t *testing.T) {
+	assert.Empty(t, slugify("Форум"))
+	assert.Empty(t, slugify(""))
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/Forum-service/Forum-Service/events"
+	"github.com/Forum-service/Forum-Service/genproto/automod"
+	"github.com/Forum-service/Forum-Service/genproto/post"
+	"github.com/Forum-service/Forum-Service/genproto/report"
+	"github.com/Forum-service/Forum-Service/genproto/tag"
+	"github.com/Forum-service/Forum-Service/storage"
+)
+
+// fakeStorage implements the parts of storage.StorageI the service tests
+// need; calling anything else panics on the nil embedded interface.
+type fakeStorage struct {
+	storage.StorageI
+	posts   *fakePosts
+	automod *fakeAutomod
+	reports *fakeReports
+	outbox  *fakeOutbox
+	tags    *fakeTags
+	// moderators maps categories to their moderators
+	moderators map[string][]string
+}
+
+func newFakeStorage() *fakeStorage {
+	return &fakeStorage{
+		posts:   &fakePosts{posts: map[string]*post.Post{}},
+		automod: &fakeAutomod{hits: map[string]int{}},
+		reports: &fakeReports{},
+		outbox:  &fakeOutbox{},
+		tags:    &fakeTags{tags: map[string]*tag.Tag{}},
+	}
+}
+
+func (s *fakeStorage) Post() storage.PostRepo       { return s.posts }
+func (s *fakeStorage) Automod() storage.AutomodRepo { return s.automod }
+func (s *fakeStorage) Report() storage.ReportRepo   { return s.reports }
+func (s *fakeStorage) Outbox() storage.OutboxRepo   { return s.outbox }
+func (s *fakeStorage) Tag() storage.TagRepo         { return s.tags }
+func (s *fakeStorage) Category() storage.CategoryRepo {
+	return &fakeCategories{moderators: s.moderators}
+}
+
+// Tx runs fn against the same storage; nothing is rolled back.
+func (s *fakeStorage) Tx(ctx context.Context, fn func(tx storage.StorageI) error) error {
+	return fn(s)
+}
+
+type fakePosts struct {
+	storage.PostRepo
+	posts     map[string]*post.Post
+	revisions map[string]*post.PostRevision // Keyed by post ID and revision, as in p1/1
+}
+
+func (r *fakePosts) GetById(_ context.Context, req *post.GetPostRequest) (*post.GetPostResponse, error) {
+	p, ok := r.posts[req.Id]
+	if !ok {
+		return nil, &storage.NotFoundError{Resource: "post"}
+	}
+	return &post.GetPostResponse{Post: p}, nil
+}
+
+func (r *fakePosts) Create(_ context.Context, req *post.CreatePostRequest) (*post.CreatePostResponse, error) {
+	p := &post.Post{Id: fmt.Sprintf("p%d", len(r.posts)+1), UserId: req.UserId, CategoryId: req.CategoryId, Title: req.Title, Body: req.Body}
+	r.posts[p.Id] = p
+	return &post.CreatePostResponse{Post: p}, nil
+}
+
+func (r *fakePosts) Update(_ context.Context, req *post.UpdatePostRequest) (*post.UpdatePostResponse, error) {
+	p, ok := r.posts[req.Id]
+	if !ok {
+		return nil, &storage.NotFoundError{Resource: "post"}
+	}
+	if req.ExpectedVersion != 0 && req.ExpectedVersion != p.Version {
+		return nil, storage.ErrVersionConflict
+	}
+	p.Title, p.Body, p.Version = req.Title, req.Body, p.Version+1
+	return &post.UpdatePostResponse{Post: p}, nil
+}
+
+func (r *fakePosts) GetRevision(_ context.Context, postID string, revision int32) (*post.PostRevision, error) {
+	rev, ok := r.revisions[fmt.Sprintf("%s/%d", postID, revision)]
+	if !ok {
+		return nil, &storage.NotFoundError{Resource: "post revision"}
+	}
+	return rev, nil
+}
+
+func (r *fakePosts) SetHidden(_ context.Context, postID string, hidden bool) error {
+	p, ok := r.posts[postID]
+	if !ok {
+		return &storage.NotFoundError{Resource: "post"}
+	}
+	p.Hidden = hidden
+	return nil
+}
+
+type fakeCategories struct {
+	storage.CategoryRepo
+	moderators map[string][]string
+}
+
+func (r *fakeCategories) IsModerator(_ context.Context, categoryID, userID string) (bool, error) {
+	return slices.Contains(r.moderators[categoryID], userID), nil
+}
+
+type fakeTags struct {
+	storage.TagRepo
+	tags map[string]*tag.Tag
+}
+
+func (r *fakeTags) GetById(_ context.Context, req *tag.GetTagRequest) (*tag.GetTagResponse, error) {
+	t, ok := r.tags[req.Id]
+	if !ok {
+		return nil, &storage.NotFoundError{Resource: "tag"}
+	}
+	return &tag.GetTagResponse{Tag: t}, nil
+}
+
+type fakeReports struct {
+	storage.ReportRepo
+	reports []*report.Report
+}
+
+func (r *fakeReports) Create(_ context.Context, req *report.ReportContentRequest) (*report.ReportContentResponse, error) {
+	rep := &report.Report{Id: fmt.Sprintf("r%d", len(r.reports)+1), TargetType: req.TargetType, TargetId: req.TargetId, Reason: req.Reason}
+	r.reports = append(r.reports, rep)
+	return &report.ReportContentResponse{Report: rep}, nil
+}
+
+type fakeOutbox struct {
+	storage.OutboxRepo
+	events []*events.Event
+}
+
+func (r *fakeOutbox) Add(_ context.Context, e *events.Event) error {
+	r.events = append(r.events, e)
+	return nil
+}
+
+// types returns the types of the recorded events.
+func (r *fakeOutbox) types() []string {
+	var types []string
+	for _, e := range r.events {
+		types = append(types, e.Type)
+	}
+	return types
+}
+
+type fakeAutomod struct {
+	storage.AutomodRepo
+	rules []*automod.AutomodRule
+	hits  map[string]int
+}
+
+func (r *fakeAutomod) GetActive(context.Context, string) ([]*automod.AutomodRule, error) {
+	return r.rules, nil
+}
+
+func (r *fakeAutomod) RecordHits(_ context.Context, ruleIDs []string) error {
+	for _, id := range ruleIDs {
+		r.hits[id]++
+	}
+	return nil
+}
@@ -2,7 +2,9 @@ package service
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/Forum-service/Forum-Service/events"
 	"github.com/Forum-service/Forum-Service/genproto/tag"
 	"github.com/Forum-service/Forum-Service/storage"
 	"github.com/rs/zerolog/log"
@@ -23,7 +25,15 @@ func NewTagService(stg storage.StorageI) *TagService {
 func (s *TagService) CreateTag(ctx context.Context, req *tag.CreateTagRequest) (*tag.CreateTagResponse, error) {
 	log.Info().Msg("TagService: CreateTag called")
 
-	resp, err := s.stg.Tag().Create(ctx, req)
+	var resp *tag.CreateTagResponse
+	err := s.stg.Tx(ctx, func(tx storage.StorageI) error {
+		var err error
+		resp, err = tx.Tag().Create(ctx, req)
+		if err != nil {
+			return err
+		}
+		return recordEvent(ctx, tx, events.TagCreated, events.AggregateTag, resp.Tag.Id, resp.Tag)
+	})
 	if err != nil {
 		log.Error().Err(err).Msg("TagService: Error creating tag")
 		return nil, err
@@ -46,7 +56,15 @@ func (s *TagService) GetTag(ctx context.Context, req *tag.GetTagRequest) (*tag.G
 func (s *TagService) UpdateTag(ctx context.Context, req *tag.UpdateTagRequest) (*tag.UpdateTagResponse, error) {
 	log.Info().Msg("TagService: UpdateTag called")
 
-	resp, err := s.stg.Tag().Update(ctx, req)
+	var resp *tag.UpdateTagResponse
+	err := s.stg.Tx(ctx, func(tx storage.StorageI) error {
+		var err error
+		resp, err = tx.Tag().Update(ctx, req)
+		if err != nil {
+			return err
+		}
+		return recordEvent(ctx, tx, events.TagUpdated, events.AggregateTag, resp.Tag.Id, resp.Tag)
+	})
 	if err != nil {
 		log.Error().Err(err).Msg("TagService: Error updating tag")
 		return nil, err
@@ -58,7 +76,15 @@ func (s *TagService) UpdateTag(ctx context.Context, req *tag.UpdateTagRequest) (
 func (s *TagService) DeleteTag(ctx context.Context, req *tag.DeleteTagRequest) (*tag.DeleteTagResponse, error) {
 	log.Info().Msg("TagService: DeleteTag called")
 
-	resp, err := s.stg.Tag().Delete(ctx, req)
+	var resp *tag.DeleteTagResponse
+	err := s.stg.Tx(ctx, func(tx storage.StorageI) error {
+		var err error
+		resp, err = tx.Tag().Delete(ctx, req)
+		if err != nil {
+			return err
+		}
+		return recordEvent(ctx, tx, events.TagDeleted, events.AggregateTag, req.Id, req)
+	})
 	if err != nil {
 		log.Error().Err(err).Msg("TagService: Error deleting tag")
 		return nil, err
@@ -89,3 +115,81 @@ func (s *TagService) GetFamousTags(ctx context.Context, req *tag.GetFamousTagsRe
 	}
 	return resp, nil
 }
+
+// GetTagByName retrieves a tag by its name or one of its synonyms.
+func (s *TagService) GetTagByName(ctx context.Context, req *tag.GetTagByNameRequest) (*tag.GetTagResponse, error) {
+	log.Info().Msg("TagService: GetTagByName called")
+
+	resp, err := s.stg.Tag().GetByName(ctx, req)
+	if err != nil {
+		log.Error().Err(err).Msg("TagService: Error getting tag by name")
+		return nil, err
+	}
+	return resp, nil
+}
+
+// MergeTags folds the source tags into the target and keeps their names as synonyms.
+func (s *TagService) MergeTags(ctx context.Context, req *tag.MergeTagsRequest) (*tag.MergeTagsResponse, error) {
+	log.Info().Msg("TagService: MergeTags called")
+
+	if len(req.SourceIds) == 0 {
+		return nil, invalidArgument("source_ids", "source_ids is required")
+	}
+	if err := required("target_id", req.TargetId); err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{req.TargetId: true}
+	for _, id := range req.SourceIds {
+		if seen[id] {
+			return nil, invalidArgument("source_ids", fmt.Sprintf("tag %s is listed more than once or is the target", id))
+		}
+		seen[id] = true
+	}
+
+	var resp *tag.MergeTagsResponse
+	err := s.stg.Tx(ctx, func(tx storage.StorageI) error {
+		var err error
+		resp, err = tx.Tag().Merge(ctx, req)
+		if err != nil {
+			return err
+		}
+		return recordEvent(ctx, tx, events.TagMerged, events.AggregateTag, req.TargetId, req)
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("TagService: Error merging tags")
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListTagSynonyms lists the synonyms that resolve to canonical tags.
+func (s *TagService) ListTagSynonyms(ctx context.Context, req *tag.ListTagSynonymsRequest) (*tag.ListTagSynonymsResponse, error) {
+	log.Info().Msg("TagService: ListTagSynonyms called")
+
+	resp, err := s.stg.Tag().ListSynonyms(ctx, req)
+	if err != nil {
+		log.Error().Err(err).Msg("TagService: Error listing tag synonyms")
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetRelatedTags returns the tags most often used on the same posts as a tag.
+func (s *TagService) GetRelatedTags(ctx context.Context, req *tag.GetRelatedTagsRequest) (*tag.GetRelatedTagsResponse, error) {
+	log.Info().Msg("TagService: GetRelatedTags called")
+
+	// Resolve merged tags to their canonical tag first
+	tagResp, err := s.stg.Tag().GetById(ctx, &tag.GetTagRequest{Id: req.TagId})
+	if err != nil {
+		log.Error().Err(err).Msg("TagService: Error getting tag")
+		return nil, err
+	}
+	req.TagId = tagResp.Tag.Id
+
+	resp, err := s.stg.Tag().GetRelated(ctx, req)
+	if err != nil {
+		log.Error().Err(err).Msg("TagService: Error getting related tags")
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,156 @@
+package service
+
+import "github.com/Forum-service/Forum-Service/middleware"
+
+// Length limits of the VARCHAR columns requests are stored in.
+const (
+	maxNameLen   = 100
+	maxTitleLen  = 100
+	maxReasonLen = 64
+)
+
+// Length limits of bodies, which keep revision diffs and filters cheap.
+const (
+	maxPostBodyLen    = 50000
+	maxCommentBodyLen = 10000
+)
+
+var (
+	requiredField = middleware.FieldRules{Required: true}
+	actingUserID  = middleware.FieldRules{UUID: true} // Filled in from the token when authenticated
+)
+
+// Validation declares the rules requests are checked against before they
+// reach a service. IDs, required id fields and pagination are covered by the
+// conventions of middleware.Rules and only listed here when they go further.
+var Validation = middleware.Rules{
+	"forum.CreateCategoryRequest": {
+		"name": {Required: true, MaxLen: maxNameLen},
+	},
+	"forum.UpdateCategoryRequest": {
+		"name": {MaxLen: maxNameLen},
+	},
+	"forum.GetCategoryStatsRequest": {
+		"category_id": requiredField,
+	},
+	"forum.MergeCategoriesRequest": {
+		"source_ids": requiredField,
+		"target_id":  requiredField,
+	},
+	"forum.AddCategoryModeratorRequest": {
+		"category_id": requiredField,
+		"user_id":     requiredField,
+		"assigned_by": actingUserID,
+	},
+	"forum.RemoveCategoryModeratorRequest": {
+		"category_id": requiredField,
+		"user_id":     requiredField,
+	},
+	"forum.ListCategoryModeratorsRequest": {
+		"category_id": requiredField,
+	},
+
+	"forum.CreateTagRequest": {
+		"name": {Required: true, MaxLen: maxNameLen},
+	},
+	"forum.UpdateTagRequest": {
+		"name": {MaxLen: maxNameLen},
+	},
+	"forum.MergeTagsRequest": {
+		"source_ids": requiredField,
+		"target_id":  requiredField,
+	},
+	"forum.GetRelatedTagsRequest": {
+		"tag_id": requiredField,
+	},
+
+	"forum.CreatePostRequest": {
+		"title":       {Required: true, MaxLen: maxTitleLen},
+		"body":        {Required: true, MaxLen: maxPostBodyLen},
+		"category_id": requiredField,
+	},
+	"forum.UpdatePostRequest": {
+		"title": {MaxLen: maxTitleLen},
+		"body":  {MaxLen: maxPostBodyLen},
+	},
+	"forum.GetPostRevisionsRequest": {
+		"post_id": requiredField,
+	},
+	"forum.GetPostRevisionDiffRequest": {
+		"post_id": requiredField,
+	},
+	"forum.RevertPostRequest": {
+		"post_id": requiredField,
+	},
+	"forum.PinPostRequest": {
+		"post_id": requiredField,
+	},
+	"forum.LockPostRequest": {
+		"post_id": requiredField,
+	},
+	"forum.ClosePostRequest": {
+		"post_id": requiredField,
+	},
+
+	"forum.CreateCommentRequest": {
+		"post_id": requiredField,
+		"body":    {Required: true, MaxLen: maxCommentBodyLen},
+	},
+	"forum.UpdateCommentRequest": {
+		"body": {Required: true, MaxLen: maxCommentBodyLen},
+	},
+	"forum.GetCommentRevisionsRequest": {
+		"comment_id": requiredField,
+	},
+	"forum.WatchPostCommentsRequest": {
+		"post_id": requiredField,
+	},
+
+	"forum.CreatePostTagRequest": {
+		"post_id": requiredField,
+	},
+	"forum.DeletePostTagRequest": {
+		"post_id": requiredField,
+		"tag_id":  requiredField,
+	},
+	"forum.GetPostsByTagRequest": {
+		"tag_id": requiredField,
+	},
+
+	"forum.FollowCategoryRequest": {
+		"category_id": requiredField,
+	},
+	"forum.UnfollowCategoryRequest": {
+		"category_id": requiredField,
+	},
+
+	"forum.ReportContentRequest": {
+		"target_type": {Required: true, In: []string{reportTargetPost, reportTargetComment}},
+		"target_id":   requiredField,
+		"reason":      {Required: true, MaxLen: maxReasonLen},
+	},
+	"forum.GetModerationQueueRequest": {
+		"target_type": {In: []string{reportTargetPost, reportTargetComment}},
+	},
+	"forum.ResolveReportRequest": {
+		"report_id": requiredField,
+		"action":    {Required: true, In: []string{reportActionDismiss, reportActionHide, reportActionDelete}},
+	},
+
+	"forum.CreateAutomodRuleRequest": {
+		"name":       {Required: true, MaxLen: maxNameLen},
+		"pattern":    requiredField,
+		"field":      {In: []string{automodFieldAny, automodFieldTitle, automodFieldBody}},
+		"action":     {Required: true, In: []string{automodActionHold, automodActionReject, automodActionTag}},
+		"created_by": actingUserID,
+	},
+	"forum.UpdateAutomodRuleRequest": {
+		"name":   {MaxLen: maxNameLen},
+		"field":  {In: []string{automodFieldAny, automodFieldTitle, automodFieldBody}},
+		"action": {In: []string{automodActionHold, automodActionReject, automodActionTag}},
+	},
+	"forum.DryRunAutomodRuleRequest": {
+		"field": {In: []string{automodFieldAny, automodFieldTitle, automodFieldBody}},
+		"limit": {Max: maxDryRunPosts},
+	},
+}
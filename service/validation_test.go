@@ -0,0 +1,30 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Forum-service/Forum-Service/genproto/automod"
+	"github.com/Forum-service/Forum-Service/genproto/post"
+	"github.com/Forum-service/Forum-Service/middleware"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestValidationRules(t *testing.T) {
+	v, err := middleware.NewValidator(Validation, 100)
+	if err != nil {
+		t.Fatalf("Error creating validator: %v", err)
+	}
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(v.Validate(&post.CreatePostRequest{Title: "No body"})))
+
+	longBody := &post.CreatePostRequest{Title: "Long", CategoryId: uuid.New().String(), Body: strings.Repeat("x", maxPostBodyLen+1)}
+	assert.Equal(t, codes.InvalidArgument, status.Code(v.Validate(longBody)))
+
+	// Dry runs may scan more posts than a page holds
+	assert.NoError(t, v.Validate(&automod.DryRunAutomodRuleRequest{Pattern: "x", Limit: maxDryRunPosts}))
+	assert.Equal(t, codes.InvalidArgument, status.Code(v.Validate(&automod.DryRunAutomodRuleRequest{Limit: maxDryRunPosts + 1})))
+}
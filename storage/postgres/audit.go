@@ -0,0 +1,47 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/Forum-service/Forum-Service/audit"
+	"github.com/rs/zerolog/log"
+)
+
+// AuditDb provides database operations for the audit log.
+type AuditDb struct {
+	Db DB
+}
+
+// NewAudit creates a new instance of AuditDb.
+func NewAudit(db DB) *AuditDb {
+	return &AuditDb{Db: db}
+}
+
+// Record adds an entry to the audit log.
+func (aDb *AuditDb) Record(ctx context.Context, e *audit.Entry) error {
+	roles := e.Roles
+	if roles == nil {
+		roles = []string{}
+	}
+
+	query := `
+		INSERT INTO
+			audit_log (
+				user_id,
+				roles,
+				method,
+				required_role
+			)
+		VALUES (
+				$1,
+				$2,
+				$3,
+				$4
+			)
+	`
+	if _, err := aDb.Db.Exec(ctx, query, e.UserID, roles, e.Method, e.Required); err != nil {
+		log.Error().Err(err).Msg("Error recording audit entry")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,360 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Forum-service/Forum-Service/genproto/automod"
+	"github.com/Forum-service/Forum-Service/storage"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog/log"
+)
+
+// ErrAutomodRuleNotFound is returned when an automod rule is not found.
+var ErrAutomodRuleNotFound = &storage.NotFoundError{Resource: "automod rule"}
+
+// automodRuleColumns is the column list scanned by scanAutomodRule.
+const automodRuleColumns = `
+			id,
+			name,
+			pattern,
+			field,
+			COALESCE(category_id::text, ''),
+			action,
+			COALESCE(tag_id::text, ''),
+			enabled,
+			hit_count,
+			last_hit_at,
+			COALESCE(created_by::text, ''),
+			created_at,
+			updated_at`
+
+// scanAutomodRule scans a row selected with automodRuleColumns.
+func scanAutomodRule(row pgx.Row) (*automod.AutomodRule, error) {
+	var (
+		rule      automod.AutomodRule
+		lastHitAt *time.Time
+		createdAt time.Time
+		updatedAt time.Time
+	)
+
+	err := row.Scan(
+		&rule.Id,
+		&rule.Name,
+		&rule.Pattern,
+		&rule.Field,
+		&rule.CategoryId,
+		&rule.Action,
+		&rule.TagId,
+		&rule.Enabled,
+		&rule.HitCount,
+		&lastHitAt,
+		&rule.CreatedBy,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if lastHitAt != nil {
+		rule.LastHitAt = lastHitAt.Format(time.RFC3339)
+	}
+	rule.CreatedAt = createdAt.Format(time.RFC3339)
+	rule.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+	return &rule, nil
+}
+
+// AutomodDb provides database operations for automod rules.
+type AutomodDb struct {
+	Db DB
+}
+
+// NewAutomod creates a new instance of AutomodDb.
+func NewAutomod(db DB) *AutomodDb {
+	return &AutomodDb{Db: db}
+}
+
+// Create creates a new automod rule.
+func (aDb *AutomodDb) Create(ctx context.Context, req *automod.CreateAutomodRuleRequest) (*automod.CreateAutomodRuleResponse, error) {
+	ruleID := uuid.New().String()
+	query := `
+		INSERT INTO
+			automod_rules (
+				id,
+				name,
+				pattern,
+				field,
+				category_id,
+				action,
+				tag_id,
+				enabled,
+				created_by
+			)
+		VALUES (
+				$1,
+				$2,
+				$3,
+				COALESCE(NULLIF($4, ''), 'any'),
+				NULLIF($5, '')::uuid,
+				$6,
+				NULLIF($7, '')::uuid,
+				$8,
+				NULLIF($9, '')::uuid
+			)
+		RETURNING ` + automodRuleColumns
+
+	rule, err := scanAutomodRule(aDb.Db.QueryRow(ctx, query,
+		ruleID,
+		req.Name,
+		req.Pattern,
+		req.Field,
+		req.CategoryId,
+		req.Action,
+		req.TagId,
+		req.Enabled,
+		req.CreatedBy,
+	))
+	if err != nil {
+		log.Error().Err(err).Msg("Error creating automod rule")
+		return nil, err
+	}
+
+	return &automod.CreateAutomodRuleResponse{Rule: rule}, nil
+}
+
+// GetById gets an automod rule by its ID.
+func (aDb *AutomodDb) GetById(ctx context.Context, req *automod.GetAutomodRuleRequest) (*automod.GetAutomodRuleResponse, error) {
+	query := `
+		SELECT` + automodRuleColumns + `
+		FROM
+			automod_rules
+		WHERE
+			id = $1
+		AND
+			deleted_at = 0
+	`
+	rule, err := scanAutomodRule(aDb.Db.QueryRow(ctx, query, req.Id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			log.Error().Err(err).Msg("Automod rule not found")
+			return nil, ErrAutomodRuleNotFound
+		}
+		log.Error().Err(err).Msg("Error getting automod rule by ID")
+		return nil, err
+	}
+
+	return &automod.GetAutomodRuleResponse{Rule: rule}, nil
+}
+
+// Update updates an existing automod rule.
+func (aDb *AutomodDb) Update(ctx context.Context, req *automod.UpdateAutomodRuleRequest) (*automod.UpdateAutomodRuleResponse, error) {
+	var args []interface{}
+	count := 1
+	query := `
+		UPDATE
+			automod_rules
+		SET `
+	filter := ``
+
+	if len(req.Name) > 0 {
+		filter += fmt.Sprintf(" name = $%d, ", count)
+		args = append(args, req.Name)
+		count++
+	}
+
+	if len(req.Pattern) > 0 {
+		filter += fmt.Sprintf(" pattern = $%d, ", count)
+		args = append(args, req.Pattern)
+		count++
+	}
+
+	if len(req.Field) > 0 {
+		filter += fmt.Sprintf(" field = $%d, ", count)
+		args = append(args, req.Field)
+		count++
+	}
+
+	if req.CategoryId != nil {
+		filter += fmt.Sprintf(" category_id = NULLIF($%d, '')::uuid, ", count)
+		args = append(args, *req.CategoryId)
+		count++
+	}
+
+	if len(req.Action) > 0 {
+		filter += fmt.Sprintf(" action = $%d, ", count)
+		args = append(args, req.Action)
+		count++
+	}
+
+	if len(req.TagId) > 0 {
+		filter += fmt.Sprintf(" tag_id = $%d, ", count)
+		args = append(args, req.TagId)
+		count++
+	}
+
+	if req.Enabled != nil {
+		filter += fmt.Sprintf(" enabled = $%d, ", count)
+		args = append(args, *req.Enabled)
+		count++
+	}
+
+	if filter == "" {
+		log.Error().Msg("No fields provided for update.")
+		return nil, storage.ErrNoFieldsToUpdate
+	}
+
+	filter += fmt.Sprintf(`
+			updated_at = NOW()
+		WHERE
+			id = $%d
+		AND
+			deleted_at = 0
+		RETURNING `, count) + automodRuleColumns
+
+	args = append(args, req.Id)
+	query += filter
+
+	rule, err := scanAutomodRule(aDb.Db.QueryRow(ctx, query, args...))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			log.Error().Err(err).Msg("Automod rule not found")
+			return nil, ErrAutomodRuleNotFound
+		}
+		log.Error().Err(err).Msg("Error updating automod rule")
+		return nil, err
+	}
+
+	return &automod.UpdateAutomodRuleResponse{Rule: rule}, nil
+}
+
+// Delete soft deletes an automod rule.
+func (aDb *AutomodDb) Delete(ctx context.Context, req *automod.DeleteAutomodRuleRequest) (*automod.DeleteAutomodRuleResponse, error) {
+	query := `
+		UPDATE
+			automod_rules
+		SET
+			deleted_at = $1
+		WHERE
+			id = $2
+		AND
+			deleted_at = 0
+	`
+	result, err := aDb.Db.Exec(ctx, query, time.Now().Unix(), req.Id)
+	if err != nil {
+		log.Error().Err(err).Msg("Error soft deleting automod rule")
+		return nil, err
+	}
+	if result.RowsAffected() == 0 {
+		return nil, ErrAutomodRuleNotFound
+	}
+	return &automod.DeleteAutomodRuleResponse{Message: "Automod rule soft deleted successfully"}, nil
+}
+
+// GetAllRules lists automod rules, oldest first.
+func (aDb *AutomodDb) GetAllRules(ctx context.Context, req *automod.ListAutomodRulesRequest) (*automod.ListAutomodRulesResponse, error) {
+	var args []interface{}
+	count := 1
+	query := `
+		SELECT` + automodRuleColumns + `
+		FROM
+			automod_rules
+		WHERE
+			deleted_at = 0
+	`
+	if req.CategoryId != "" {
+		query += fmt.Sprintf(" AND category_id = $%d ", count)
+		args = append(args, req.CategoryId)
+		count++
+	}
+	if req.EnabledOnly {
+		query += " AND enabled "
+	}
+	query += " ORDER BY created_at "
+
+	// Apply pagination
+	if req.Limit <= 0 {
+		req.Limit = DefaultPageSize
+	}
+	if req.Page <= 0 {
+		req.Page = 1 // Default page
+	}
+	offset := (req.Page - 1) * req.Limit
+	query += fmt.Sprintf(" OFFSET %d LIMIT %d", offset, req.Limit)
+
+	rules, err := aDb.queryRules(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return &automod.ListAutomodRulesResponse{Rules: rules}, nil
+}
+
+// GetActive returns the enabled rules that apply to content in categoryID,
+// that is the rules of that category and the rules without a category.
+func (aDb *AutomodDb) GetActive(ctx context.Context, categoryID string) ([]*automod.AutomodRule, error) {
+	query := `
+		SELECT` + automodRuleColumns + `
+		FROM
+			automod_rules
+		WHERE
+			deleted_at = 0
+		AND
+			enabled
+		AND
+			(category_id IS NULL OR category_id::text = $1)
+		ORDER BY
+			created_at
+	`
+	return aDb.queryRules(ctx, query, categoryID)
+}
+
+// RecordHits bumps the hit counter of every rule in ruleIDs.
+func (aDb *AutomodDb) RecordHits(ctx context.Context, ruleIDs []string) error {
+	if len(ruleIDs) == 0 {
+		return nil
+	}
+
+	query := `
+		UPDATE
+			automod_rules
+		SET
+			hit_count = hit_count + 1,
+			last_hit_at = NOW()
+		WHERE
+			id = ANY($1::uuid[])
+	`
+	if _, err := aDb.Db.Exec(ctx, query, ruleIDs); err != nil {
+		log.Error().Err(err).Msg("Error recording automod rule hits")
+		return err
+	}
+	return nil
+}
+
+func (aDb *AutomodDb) queryRules(ctx context.Context, query string, args ...any) ([]*automod.AutomodRule, error) {
+	rows, err := aDb.Db.Query(ctx, query, args...)
+	if err != nil {
+		log.Error().Err(err).Msg("Error listing automod rules")
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rules []*automod.AutomodRule
+	for rows.Next() {
+		rule, err := scanAutomodRule(rows)
+		if err != nil {
+			log.Error().Err(err).Msg("Error scanning automod rule row")
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	if err = rows.Err(); err != nil {
+		log.Error().Err(err).Msg("Error iterating over automod rule rows")
+		return nil, err
+	}
+
+	return rules, nil
+}
@@ -2,89 +2,148 @@ package postgres
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"sort"
 	"time"
 
 	// Update with your actual package path
 	"github.com/Forum-service/Forum-Service/genproto/category"
+	"github.com/Forum-service/Forum-Service/storage"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/rs/zerolog/log"
 )
 
 // ErrCategoryNotFound is returned when a category is not found.
-var ErrCategoryNotFound = errors.New("category not found")
+var ErrCategoryNotFound = &storage.NotFoundError{Resource: "category"}
+
+// ErrParentCategoryNotFound is returned when the requested parent category does not exist.
+var ErrParentCategoryNotFound = &storage.NotFoundError{Resource: "parent category"}
+
+// categoryColumns is the column list scanned by scanCategory.
+const categoryColumns = `
+			id,
+			name,
+			version,
+			COALESCE(parent_id::text, ''),
+			slug,
+			description,
+			icon,
+			color,
+			position,
+			created_at,
+			updated_at`
+
+// scanCategory scans a row selected with categoryColumns followed by any extra columns.
+func scanCategory(row pgx.Row, extra ...any) (*category.Category, error) {
+	var (
+		dbCategory category.Category
+		createdAt  time.Time
+		updatedAt  time.Time
+	)
+
+	dest := []any{
+		&dbCategory.Id,
+		&dbCategory.Name,
+		&dbCategory.Version,
+		&dbCategory.ParentId,
+		&dbCategory.Slug,
+		&dbCategory.Description,
+		&dbCategory.Icon,
+		&dbCategory.Color,
+		&dbCategory.Position,
+		&createdAt,
+		&updatedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+
+	dbCategory.CreatedAt = createdAt.Format(time.RFC3339)
+	dbCategory.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+	return &dbCategory, nil
+}
 
 // CategoryDb provides database operations for categories.
 type CategoryDb struct {
-	Db *pgx.Conn
+	Db DB
 }
 
 // NewCategory creates a new instance of CategoryDb.
-func NewCategory(db *pgx.Conn) *CategoryDb {
+func NewCategory(db DB) *CategoryDb {
 	return &CategoryDb{Db: db}
 }
 
 // Create creates a new category in the database.
 func (cDb *CategoryDb) Create(ctx context.Context, req *category.CreateCategoryRequest) (*category.CreateCategoryResponse, error) {
 	categoryID := uuid.New().String()
+	if req.ParentId != "" {
+		if err := cDb.checkParent(ctx, categoryID, req.ParentId); err != nil {
+			return nil, err
+		}
+	}
+
+	// Without an explicit position the category goes after its last sibling
 	query := `
 		INSERT INTO 
 			categories (
 				id,
-				name
+				name,
+				parent_id,
+				slug,
+				description,
+				icon,
+				color,
+				position
 			) 
 		VALUES (
 				$1, 
-				$2
+				$2,
+				NULLIF($3, '')::uuid,
+				COALESCE(NULLIF($4, ''), $1::text),
+				$5,
+				$6,
+				$7,
+				COALESCE(NULLIF($8, 0), (
+					SELECT 
+						COALESCE(MAX(position), 0) + 1
+					FROM 
+						categories
+					WHERE 
+						parent_id IS NOT DISTINCT FROM NULLIF($3, '')::uuid
+					AND 
+						deleted_at = 0
+				))
 			)
-		RETURNING 
-			id,
-			name,
-			created_at,
-			updated_at
-	`
-	var (
-		dbCategory category.Category
-		createdAt  time.Time
-		updatedAt  time.Time
-	)
+		RETURNING ` + categoryColumns
 
-	err := cDb.Db.QueryRow(ctx, query,
+	dbCategory, err := scanCategory(cDb.Db.QueryRow(ctx, query,
 		categoryID,
 		req.Name,
-	).Scan(
-		&dbCategory.Id,
-		&dbCategory.Name,
-		&createdAt,
-		&updatedAt,
-	)
+		req.ParentId,
+		req.Slug,
+		req.Description,
+		req.Icon,
+		req.Color,
+		req.Position,
+	))
 	if err != nil {
+		if isUniqueViolation(err, "uq_categories_slug") {
+			return nil, storage.ErrCategorySlugTaken
+		}
 		log.Error().Err(err).Msg("Error creating category")
 		return nil, err
 	}
 
-	dbCategory.CreatedAt = createdAt.Format(time.RFC3339)
-	dbCategory.UpdatedAt = updatedAt.Format(time.RFC3339)
-
-	return &category.CreateCategoryResponse{Category: &dbCategory}, nil
+	return &category.CreateCategoryResponse{Category: dbCategory}, nil
 }
 
-// GetById gets a category by its ID.
+// GetById gets a category by its ID. The ID of a category that was merged
+// into another one resolves to the merge target.
 func (cDb *CategoryDb) GetById(ctx context.Context, req *category.GetCategoryRequest) (*category.GetCategoryResponse, error) {
-	var (
-		dbCategory category.Category
-		createdAt  time.Time
-		updatedAt  time.Time
-	)
-
 	query := `
-		SELECT
-			id,
-			name,
-			created_at,
-			updated_at
+		SELECT` + categoryColumns + `
 		FROM 
 			categories 
 		WHERE 
@@ -92,25 +151,64 @@ func (cDb *CategoryDb) GetById(ctx context.Context, req *category.GetCategoryReq
 		AND
 			deleted_at = 0
 	`
-	err := cDb.Db.QueryRow(ctx, query, req.Id).Scan(
-		&dbCategory.Id,
-		&dbCategory.Name,
-		&createdAt,
-		&updatedAt,
-	)
+	dbCategory, err := scanCategory(cDb.Db.QueryRow(ctx, query, req.Id))
+	if err == pgx.ErrNoRows {
+		return cDb.getRedirect(ctx, req.Id)
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Error getting category by ID")
+		return nil, err
+	}
+
+	return &category.GetCategoryResponse{Category: dbCategory}, nil
+}
+
+// getRedirect gets the category that oldID was merged into.
+func (cDb *CategoryDb) getRedirect(ctx context.Context, oldID string) (*category.GetCategoryResponse, error) {
+	query := `
+		SELECT` + categoryColumns + `
+		FROM 
+			categories 
+		WHERE 
+			id = (SELECT target_category_id FROM category_redirects WHERE old_category_id = $1)
+		AND
+			deleted_at = 0
+	`
+	dbCategory, err := scanCategory(cDb.Db.QueryRow(ctx, query, oldID))
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			log.Error().Err(err).Msg("Category not found")
 			return nil, ErrCategoryNotFound
 		}
-		log.Error().Err(err).Msg("Error getting category by ID")
+		log.Error().Err(err).Msg("Error getting category redirect")
 		return nil, err
+	}
 
+	return &category.GetCategoryResponse{Category: dbCategory, RedirectedFrom: oldID}, nil
+}
+
+// GetBySlug gets a category by its slug.
+func (cDb *CategoryDb) GetBySlug(ctx context.Context, req *category.GetCategoryBySlugRequest) (*category.GetCategoryResponse, error) {
+	query := `
+		SELECT` + categoryColumns + `
+		FROM 
+			categories 
+		WHERE 
+			slug = $1
+		AND
+			deleted_at = 0
+	`
+	dbCategory, err := scanCategory(cDb.Db.QueryRow(ctx, query, req.Slug))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			log.Error().Err(err).Msg("Category not found")
+			return nil, ErrCategoryNotFound
+		}
+		log.Error().Err(err).Msg("Error getting category by slug")
+		return nil, err
 	}
-	dbCategory.CreatedAt = createdAt.Format(time.RFC3339)
-	dbCategory.UpdatedAt = updatedAt.Format(time.RFC3339)
 
-	return &category.GetCategoryResponse{Category: &dbCategory}, nil
+	return &category.GetCategoryResponse{Category: dbCategory}, nil
 }
 
 // Update updates an existing category in the database.
@@ -129,39 +227,80 @@ func (cDb *CategoryDb) Update(ctx context.Context, req *category.UpdateCategoryR
 		count++
 	}
 
+	if len(req.Slug) > 0 {
+		filter += fmt.Sprintf(" slug = $%d, ", count)
+		args = append(args, req.Slug)
+		count++
+	}
+
+	if len(req.Description) > 0 {
+		filter += fmt.Sprintf(" description = $%d, ", count)
+		args = append(args, req.Description)
+		count++
+	}
+
+	if len(req.Icon) > 0 {
+		filter += fmt.Sprintf(" icon = $%d, ", count)
+		args = append(args, req.Icon)
+		count++
+	}
+
+	if len(req.Color) > 0 {
+		filter += fmt.Sprintf(" color = $%d, ", count)
+		args = append(args, req.Color)
+		count++
+	}
+
+	if req.Position > 0 {
+		filter += fmt.Sprintf(" position = $%d, ", count)
+		args = append(args, req.Position)
+		count++
+	}
+
+	if req.ClearParent {
+		filter += " parent_id = NULL, "
+	} else if len(req.ParentId) > 0 {
+		if err := cDb.checkParent(ctx, req.Id, req.ParentId); err != nil {
+			return nil, err
+		}
+		filter += fmt.Sprintf(" parent_id = $%d, ", count)
+		args = append(args, req.ParentId)
+		count++
+	}
+
 	if filter == "" {
 		log.Error().Msg("No fields provided for update.")
-		return nil, errors.New("no fields provided for update")
+		return nil, storage.ErrNoFieldsToUpdate
 	}
 
 	filter += fmt.Sprintf(`
+			version = version + 1,
 			updated_at = NOW()
 		WHERE
 			id = $%d
 		AND 
 			deleted_at = 0
-			`, count)
+		AND 
+			($%d = 0 OR version = $%d)
+		RETURNING `, count, count+1, count+1) + categoryColumns
 
-	args = append(args, req.Id)
+	args = append(args, req.Id, req.ExpectedVersion)
 	query += filter
 
-	_, err := cDb.Db.Exec(ctx, query, args...)
+	updatedCategory, err := scanCategory(cDb.Db.QueryRow(ctx, query, args...))
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			log.Error().Err(err).Msg("Category not found")
-			return nil, ErrCategoryNotFound
+			log.Error().Err(err).Msg("Category not found or version mismatch")
+			return nil, missedUpdateError(ctx, cDb.Db, "categories", req.Id, req.ExpectedVersion, ErrCategoryNotFound)
+		}
+		if isUniqueViolation(err, "uq_categories_slug") {
+			return nil, storage.ErrCategorySlugTaken
 		}
 		log.Error().Err(err).Msg("Error updating category")
 		return nil, err
 	}
 
-	// After successful update, get the updated category from the database
-	updatedCategory, err := cDb.GetById(ctx, &category.GetCategoryRequest{Id: req.Id})
-	if err != nil {
-		return nil, err
-	}
-
-	return &category.UpdateCategoryResponse{Category: updatedCategory.Category}, nil
+	return &category.UpdateCategoryResponse{Category: updatedCategory}, nil
 }
 
 // Delete soft deletes a category by setting its deleted_at field to the current time.
@@ -173,16 +312,17 @@ func (cDb *CategoryDb) Delete(ctx context.Context, req *category.DeleteCategoryR
 			deleted_at = $1
 		WHERE 
 			id = $2
+		AND 
+			deleted_at = 0
 	`
-	_, err := cDb.Db.Exec(ctx, query, time.Now().Unix(), req.Id)
+	result, err := cDb.Db.Exec(ctx, query, time.Now().Unix(), req.Id)
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			log.Error().Err(err).Msg("Category not found")
-			return nil, ErrCategoryNotFound
-		}
 		log.Error().Err(err).Msg("Error soft deleting category")
 		return nil, err
 	}
+	if result.RowsAffected() == 0 {
+		return nil, ErrCategoryNotFound
+	}
 	return &category.DeleteCategoryResponse{Message: "Category soft deleted successfully"}, nil
 }
 
@@ -190,20 +330,19 @@ func (cDb *CategoryDb) Delete(ctx context.Context, req *category.DeleteCategoryR
 func (cDb *CategoryDb) GetAllCategories(ctx context.Context, req *category.GetAllCategoriesRequest) (*category.GetAllCategoriesResponse, error) {
 	var args []interface{}
 	query := `
-		SELECT
-			id,
-			name,
-			created_at,
-			updated_at
+		SELECT` + categoryColumns + `
 		FROM 
 			categories
 		WHERE 
 			deleted_at = 0
+		ORDER BY 
+			position,
+			name
 	`
 
 	// Apply pagination
 	if req.Limit <= 0 {
-		req.Limit = 10 // Default limit
+		req.Limit = DefaultPageSize
 	}
 	if req.Page <= 0 {
 		req.Page = 1 // Default page
@@ -219,32 +358,642 @@ func (cDb *CategoryDb) GetAllCategories(ctx context.Context, req *category.GetAl
 	defer rows.Close()
 
 	var categories []*category.Category
+	for rows.Next() {
+		dbCategory, err := scanCategory(rows)
+		if err != nil {
+			log.Error().Err(err).Msg("Error scanning category row")
+			return nil, err
+		}
+		categories = append(categories, dbCategory)
+	}
+
+	if err = rows.Err(); err != nil {
+		log.Error().Err(err).Msg("Error iterating over category rows")
+		return nil, err
+	}
+
+	return &category.GetAllCategoriesResponse{Categories: categories}, nil
+}
+
+// Reorder sets the position of every listed category to its index in
+// req.Ids, starting at 1. It fails with ErrCategoryNotFound, changing nothing
+// when run in a transaction, if any of the categories does not exist.
+func (cDb *CategoryDb) Reorder(ctx context.Context, req *category.ReorderCategoriesRequest) (*category.ReorderCategoriesResponse, error) {
+	query := `
+		UPDATE 
+			categories 
+		SET 
+			position = o.ord,
+			version = version + 1,
+			updated_at = NOW()
+		FROM 
+			unnest($1::uuid[]) WITH ORDINALITY AS o(category_id, ord)
+		WHERE 
+			id = o.category_id
+		AND 
+			deleted_at = 0
+		RETURNING ` + categoryColumns
+
+	rows, err := cDb.Db.Query(ctx, query, req.Ids)
+	if err != nil {
+		log.Error().Err(err).Msg("Error reordering categories")
+		return nil, err
+	}
+	defer rows.Close()
+
+	var categories []*category.Category
+	for rows.Next() {
+		dbCategory, err := scanCategory(rows)
+		if err != nil {
+			log.Error().Err(err).Msg("Error scanning reordered category row")
+			return nil, err
+		}
+		categories = append(categories, dbCategory)
+	}
+
+	if err = rows.Err(); err != nil {
+		log.Error().Err(err).Msg("Error iterating over reordered category rows")
+		return nil, err
+	}
+
+	if len(categories) != len(req.Ids) {
+		log.Error().Msg("Some categories to reorder were not found")
+		return nil, ErrCategoryNotFound
+	}
+
+	sort.Slice(categories, func(i, j int) bool {
+		return categories[i].Position < categories[j].Position
+	})
+
+	return &category.ReorderCategoriesResponse{Categories: categories}, nil
+}
+
+// Merge moves the live posts and sub-categories of the source categories
+// into the target, soft-deletes the sources and redirects their IDs to the
+// target. All changes are made in one transaction, or a savepoint when Db is
+// already a transaction.
+func (cDb *CategoryDb) Merge(ctx context.Context, req *category.MergeCategoriesRequest) (*category.MergeCategoriesResponse, error) {
+	tx, err := cDb.Db.Begin(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Error starting category merge")
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var liveSources int
+	err = tx.QueryRow(ctx, `
+		SELECT 
+			COUNT(*)
+		FROM 
+			categories
+		WHERE 
+			id = ANY($1::uuid[])
+		AND 
+			deleted_at = 0
+	`, req.SourceIds).Scan(&liveSources)
+	if err != nil {
+		log.Error().Err(err).Msg("Error checking merge source categories")
+		return nil, err
+	}
+	if liveSources != len(req.SourceIds) {
+		return nil, ErrCategoryNotFound
+	}
+
+	// The target must not sit below one of the sources, which are about to be deleted
+	var targetExists, targetBelowSource bool
+	err = tx.QueryRow(ctx, `
+		WITH RECURSIVE ancestors AS (
+			SELECT 
+				id,
+				parent_id
+			FROM 
+				categories
+			WHERE 
+				id = $1
+			AND 
+				deleted_at = 0
+			UNION
+			SELECT 
+				c.id,
+				c.parent_id
+			FROM 
+				categories c
+			JOIN 
+				ancestors a ON c.id = a.parent_id
+		)
+		SELECT
+			EXISTS (SELECT 1 FROM ancestors),
+			EXISTS (SELECT 1 FROM ancestors WHERE id = ANY($2::uuid[]))
+	`, req.TargetId, req.SourceIds).Scan(&targetExists, &targetBelowSource)
+	if err != nil {
+		log.Error().Err(err).Msg("Error checking merge target category")
+		return nil, err
+	}
+	if !targetExists {
+		return nil, ErrCategoryNotFound
+	}
+	if targetBelowSource {
+		return nil, storage.ErrCategoryCycle
+	}
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE 
+			posts 
+		SET 
+			category_id = $1,
+			version = version + 1,
+			updated_at = NOW()
+		WHERE 
+			category_id = ANY($2::uuid[])
+		AND 
+			deleted_at = 0
+	`, req.TargetId, req.SourceIds)
+	if err != nil {
+		log.Error().Err(err).Msg("Error moving posts to merge target")
+		return nil, err
+	}
+	movedPosts := tag.RowsAffected()
+
+	statements := []struct {
+		query string
+		msg   string
+	}{
+		{`
+		UPDATE 
+			categories 
+		SET 
+			parent_id = $1,
+			version = version + 1,
+			updated_at = NOW()
+		WHERE 
+			parent_id = ANY($2::uuid[])
+		AND 
+			deleted_at = 0
+		`, "Error moving sub-categories to merge target"},
+		{`
+		INSERT INTO 
+			category_follows (
+				user_id,
+				category_id
+			)
+		SELECT 
+			DISTINCT user_id,
+			$1::uuid
+		FROM 
+			category_follows
+		WHERE 
+			category_id = ANY($2::uuid[])
+		ON CONFLICT DO NOTHING
+		`, "Error moving category followers to merge target"},
+		{`
+		UPDATE 
+			category_redirects 
+		SET 
+			target_category_id = $1
+		WHERE 
+			target_category_id = ANY($2::uuid[])
+		`, "Error updating existing category redirects"},
+		{`
+		INSERT INTO 
+			category_redirects (
+				old_category_id,
+				target_category_id
+			)
+		SELECT 
+			unnest($2::uuid[]),
+			$1
+		ON CONFLICT (old_category_id) DO UPDATE SET 
+			target_category_id = EXCLUDED.target_category_id
+		`, "Error adding category redirects"},
+	}
+	for _, stmt := range statements {
+		if _, err := tx.Exec(ctx, stmt.query, req.TargetId, req.SourceIds); err != nil {
+			log.Error().Err(err).Msg(stmt.msg)
+			return nil, err
+		}
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE 
+			categories 
+		SET 
+			deleted_at = $1
+		WHERE 
+			id = ANY($2::uuid[])
+	`, time.Now().Unix(), req.SourceIds)
+	if err != nil {
+		log.Error().Err(err).Msg("Error deleting merged categories")
+		return nil, err
+	}
+
+	target, err := NewCategory(tx).GetById(ctx, &category.GetCategoryRequest{Id: req.TargetId})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Error().Err(err).Msg("Error committing category merge")
+		return nil, err
+	}
+
+	return &category.MergeCategoriesResponse{Target: target.Category, MovedPosts: int32(movedPosts)}, nil
+}
+
+// checkParent makes sure parentID is a live category that can hold
+// categoryID, i.e. that categoryID is not parentID or one of its ancestors.
+func (cDb *CategoryDb) checkParent(ctx context.Context, categoryID, parentID string) error {
+	query := `
+		WITH RECURSIVE ancestors AS (
+			SELECT 
+				id,
+				parent_id
+			FROM 
+				categories
+			WHERE 
+				id = $1
+			AND 
+				deleted_at = 0
+			UNION
+			SELECT 
+				c.id,
+				c.parent_id
+			FROM 
+				categories c
+			JOIN 
+				ancestors a ON c.id = a.parent_id
+		)
+		SELECT
+			EXISTS (SELECT 1 FROM ancestors),
+			EXISTS (SELECT 1 FROM ancestors WHERE id = $2)
+	`
+	var parentExists, cycle bool
+	err := cDb.Db.QueryRow(ctx, query, parentID, categoryID).Scan(&parentExists, &cycle)
+	if err != nil {
+		log.Error().Err(err).Msg("Error checking parent category")
+		return err
+	}
+	if !parentExists {
+		return ErrParentCategoryNotFound
+	}
+	if cycle {
+		return storage.ErrCategoryCycle
+	}
+	return nil
+}
+
+// GetTree returns the live categories as a hierarchy with post counts. A
+// category whose parent has been deleted is listed at the top level.
+func (cDb *CategoryDb) GetTree(ctx context.Context, req *category.GetCategoryTreeRequest) (*category.GetCategoryTreeResponse, error) {
+	query := `
+		SELECT` + categoryColumns + `,
+			COALESCE(pc.post_count, 0)
+		FROM 
+			categories
+		LEFT JOIN (
+			SELECT 
+				category_id,
+				COUNT(*) AS post_count
+			FROM 
+				posts
+			WHERE 
+				deleted_at = 0
+			GROUP BY 
+				category_id
+		) pc ON pc.category_id = categories.id
+		WHERE 
+			deleted_at = 0
+		ORDER BY 
+			position,
+			name
+	`
+	rows, err := cDb.Db.Query(ctx, query)
+	if err != nil {
+		log.Error().Err(err).Msg("Error getting category tree")
+		return nil, err
+	}
+	defer rows.Close()
+
+	var nodes []*category.CategoryNode
+	byID := make(map[string]*category.CategoryNode)
+	for rows.Next() {
+		node := &category.CategoryNode{}
+		node.Category, err = scanCategory(rows, &node.PostCount)
+		if err != nil {
+			log.Error().Err(err).Msg("Error scanning category tree row")
+			return nil, err
+		}
+		nodes = append(nodes, node)
+		byID[node.Category.Id] = node
+	}
+
+	if err = rows.Err(); err != nil {
+		log.Error().Err(err).Msg("Error iterating over category tree rows")
+		return nil, err
+	}
+
+	var roots []*category.CategoryNode
+	for _, node := range nodes {
+		if parent, ok := byID[node.Category.ParentId]; ok {
+			parent.Children = append(parent.Children, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+	for _, root := range roots {
+		sumPostCounts(root)
+	}
+
+	if req.RootId != "" {
+		root, ok := byID[req.RootId]
+		if !ok {
+			return nil, ErrCategoryNotFound
+		}
+		roots = []*category.CategoryNode{root}
+	}
+
+	return &category.GetCategoryTreeResponse{Roots: roots}, nil
+}
+
+// sumPostCounts fills in TotalPostCount for node and its descendants.
+func sumPostCounts(node *category.CategoryNode) int32 {
+	node.TotalPostCount = node.PostCount
+	for _, child := range node.Children {
+		node.TotalPostCount += sumPostCounts(child)
+	}
+	return node.TotalPostCount
+}
+
+// GetBreadcrumb returns the chain of categories from the top level down to the requested one.
+func (cDb *CategoryDb) GetBreadcrumb(ctx context.Context, req *category.GetCategoryBreadcrumbRequest) (*category.GetCategoryBreadcrumbResponse, error) {
+	// The depth limit only guards against a cycle slipping in through concurrent moves
+	query := `
+		WITH RECURSIVE breadcrumb AS (
+			SELECT 
+				id AS crumb_id,
+				parent_id AS crumb_parent_id,
+				0 AS depth
+			FROM 
+				categories
+			WHERE 
+				id = $1
+			AND 
+				deleted_at = 0
+			UNION ALL
+			SELECT 
+				c.id,
+				c.parent_id,
+				b.depth + 1
+			FROM 
+				categories c
+			JOIN 
+				breadcrumb b ON c.id = b.crumb_parent_id
+			WHERE 
+				b.depth < 100
+		)
+		SELECT` + categoryColumns + `
+		FROM 
+			categories
+		JOIN 
+			breadcrumb ON breadcrumb.crumb_id = categories.id
+		ORDER BY 
+			breadcrumb.depth DESC
+	`
+	rows, err := cDb.Db.Query(ctx, query, req.Id)
+	if err != nil {
+		log.Error().Err(err).Msg("Error getting category breadcrumb")
+		return nil, err
+	}
+	defer rows.Close()
+
+	var categories []*category.Category
+	for rows.Next() {
+		dbCategory, err := scanCategory(rows)
+		if err != nil {
+			log.Error().Err(err).Msg("Error scanning breadcrumb row")
+			return nil, err
+		}
+		categories = append(categories, dbCategory)
+	}
+
+	if err = rows.Err(); err != nil {
+		log.Error().Err(err).Msg("Error iterating over breadcrumb rows")
+		return nil, err
+	}
+
+	if len(categories) == 0 {
+		return nil, ErrCategoryNotFound
+	}
+
+	return &category.GetCategoryBreadcrumbResponse{Categories: categories}, nil
+}
+
+// GetStats computes activity statistics for the requested categories, or for
+// every live category when none are requested. Soft-deleted posts, comments
+// and tags are ignored.
+func (cDb *CategoryDb) GetStats(ctx context.Context, req *category.GetCategoriesStatsRequest) (*category.GetCategoriesStatsResponse, error) {
+	if req.TopTags <= 0 {
+		req.TopTags = 5 // Default number of top tags
+	}
+
+	query := `
+		WITH cats AS (
+			SELECT 
+				id
+			FROM 
+				categories
+			WHERE 
+				deleted_at = 0
+			AND 
+				(COALESCE(cardinality($1::uuid[]), 0) = 0 OR id = ANY($1::uuid[]))
+		),
+		live_posts AS (
+			SELECT 
+				id,
+				user_id,
+				category_id,
+				created_at
+			FROM 
+				posts
+			WHERE 
+				deleted_at = 0
+			AND 
+				category_id IN (SELECT id FROM cats)
+		),
+		live_comments AS (
+			SELECT 
+				c.user_id,
+				c.created_at,
+				p.category_id
+			FROM 
+				comments c
+			JOIN 
+				live_posts p ON p.id = c.post_id
+			WHERE 
+				c.deleted_at = 0
+		),
+		post_stats AS (
+			SELECT 
+				category_id,
+				COUNT(*) AS post_count,
+				MAX(created_at) AS last_post_at
+			FROM 
+				live_posts
+			GROUP BY 
+				category_id
+		),
+		comment_stats AS (
+			SELECT 
+				category_id,
+				COUNT(*) AS comment_count,
+				MAX(created_at) AS last_comment_at
+			FROM 
+				live_comments
+			GROUP BY 
+				category_id
+		),
+		author_stats AS (
+			SELECT 
+				category_id,
+				COUNT(DISTINCT user_id) AS author_count
+			FROM (
+				SELECT category_id, user_id FROM live_posts
+				UNION ALL
+				SELECT category_id, user_id FROM live_comments
+			) authors
+			GROUP BY 
+				category_id
+		)
+		SELECT
+			cats.id,
+			COALESCE(ps.post_count, 0),
+			COALESCE(cs.comment_count, 0),
+			COALESCE(a.author_count, 0),
+			ps.last_post_at,
+			cs.last_comment_at
+		FROM 
+			cats
+		LEFT JOIN 
+			post_stats ps ON ps.category_id = cats.id
+		LEFT JOIN 
+			comment_stats cs ON cs.category_id = cats.id
+		LEFT JOIN 
+			author_stats a ON a.category_id = cats.id
+	`
+	rows, err := cDb.Db.Query(ctx, query, req.CategoryIds)
+	if err != nil {
+		log.Error().Err(err).Msg("Error getting category stats")
+		return nil, err
+	}
+	defer rows.Close()
+
+	var (
+		stats      []*category.CategoryStats
+		byCategory = make(map[string]*category.CategoryStats)
+	)
 	for rows.Next() {
 		var (
-			createdAt time.Time
-			updatedAt time.Time
+			s             category.CategoryStats
+			lastPostAt    *time.Time
+			lastCommentAt *time.Time
 		)
-		dbCategory := &category.Category{}
 		err := rows.Scan(
-			&dbCategory.Id,
-			&dbCategory.Name,
-			&createdAt,
-			&updatedAt,
+			&s.CategoryId,
+			&s.PostCount,
+			&s.CommentCount,
+			&s.AuthorCount,
+			&lastPostAt,
+			&lastCommentAt,
 		)
 		if err != nil {
-			log.Error().Err(err).Msg("Error scanning category row")
+			log.Error().Err(err).Msg("Error scanning category stats row")
 			return nil, err
 		}
-		dbCategory.CreatedAt = createdAt.Format(time.RFC3339)
-		dbCategory.UpdatedAt = updatedAt.Format(time.RFC3339)
+		if lastPostAt != nil {
+			s.LastPostAt = lastPostAt.Format(time.RFC3339)
+		}
+		if lastCommentAt != nil {
+			s.LastCommentAt = lastCommentAt.Format(time.RFC3339)
+		}
 
-		categories = append(categories, dbCategory)
+		stats = append(stats, &s)
+		byCategory[s.CategoryId] = &s
 	}
 
 	if err = rows.Err(); err != nil {
-		log.Error().Err(err).Msg("Error iterating over category rows")
+		log.Error().Err(err).Msg("Error iterating over category stats rows")
 		return nil, err
 	}
 
-	return &category.GetAllCategoriesResponse{Categories: categories}, nil
+	if len(stats) == 0 {
+		return &category.GetCategoriesStatsResponse{}, nil
+	}
+
+	categoryIDs := make([]string, 0, len(stats))
+	for _, s := range stats {
+		categoryIDs = append(categoryIDs, s.CategoryId)
+	}
+
+	tagQuery := `
+		SELECT 
+			category_id,
+			tag_id,
+			name,
+			usage_count
+		FROM (
+			SELECT
+				p.category_id,
+				t.id AS tag_id,
+				t.name,
+				COUNT(*) AS usage_count,
+				ROW_NUMBER() OVER (PARTITION BY p.category_id ORDER BY COUNT(*) DESC, t.name) AS rank
+			FROM 
+				post_tags pt
+			JOIN 
+				posts p ON p.id = pt.post_id
+			JOIN 
+				tags t ON t.id = pt.tag_id
+			WHERE 
+				p.deleted_at = 0
+			AND 
+				t.deleted_at = 0
+			AND 
+				p.category_id = ANY($1::uuid[])
+			GROUP BY 
+				p.category_id,
+				t.id,
+				t.name
+		) ranked
+		WHERE 
+			rank <= $2
+		ORDER BY 
+			category_id,
+			rank
+	`
+	tagRows, err := cDb.Db.Query(ctx, tagQuery, categoryIDs, req.TopTags)
+	if err != nil {
+		log.Error().Err(err).Msg("Error getting category top tags")
+		return nil, err
+	}
+	defer tagRows.Close()
+
+	for tagRows.Next() {
+		var (
+			categoryID string
+			usage      category.TagUsage
+		)
+		if err := tagRows.Scan(&categoryID, &usage.TagId, &usage.Name, &usage.Count); err != nil {
+			log.Error().Err(err).Msg("Error scanning category top tag row")
+			return nil, err
+		}
+		if s, ok := byCategory[categoryID]; ok {
+			s.TopTags = append(s.TopTags, &usage)
+		}
+	}
+
+	if err = tagRows.Err(); err != nil {
+		log.Error().Err(err).Msg("Error iterating over category top tag rows")
+		return nil, err
+	}
+
+	return &category.GetCategoriesStatsResponse{Stats: stats}, nil
 }
@@ -0,0 +1,197 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Forum-service/Forum-Service/genproto/category"
+	"github.com/Forum-service/Forum-Service/storage"
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog/log"
+)
+
+// ErrCategoryModeratorNotFound is returned when removing a user who does not moderate the category.
+var ErrCategoryModeratorNotFound = &storage.NotFoundError{Resource: "category moderator"}
+
+// moderatedAncestors lists category $1 and its ancestors, nearest first. The
+// depth limit only guards against a cycle slipping in through concurrent moves.
+const moderatedAncestors = `
+		WITH RECURSIVE ancestors AS (
+			SELECT
+				id,
+				parent_id,
+				0 AS depth
+			FROM
+				categories
+			WHERE
+				id = $1
+			UNION ALL
+			SELECT
+				c.id,
+				c.parent_id,
+				a.depth + 1
+			FROM
+				categories c
+			JOIN
+				ancestors a ON c.id = a.parent_id
+			WHERE
+				a.depth < 100
+		)`
+
+// AddModerator assigns a moderator to a category. It fails with
+// storage.ErrAlreadyModerator if the user already moderates it directly.
+func (cDb *CategoryDb) AddModerator(ctx context.Context, req *category.AddCategoryModeratorRequest) (*category.AddCategoryModeratorResponse, error) {
+	query := `
+		INSERT INTO
+			category_moderators (
+				category_id,
+				user_id,
+				assigned_by
+			)
+		VALUES (
+				$1,
+				$2,
+				NULLIF($3, '')::uuid
+			)
+		ON CONFLICT (category_id, user_id) DO NOTHING
+		RETURNING
+			category_id,
+			user_id,
+			COALESCE(assigned_by::text, ''),
+			created_at
+	`
+	moderator, err := scanCategoryModerator(cDb.Db.QueryRow(ctx, query, req.CategoryId, req.UserId, req.AssignedBy))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrAlreadyModerator
+		}
+		log.Error().Err(err).Msg("Error adding category moderator")
+		return nil, err
+	}
+	return &category.AddCategoryModeratorResponse{Moderator: moderator}, nil
+}
+
+// RemoveModerator removes a moderator from a category. Moderators inherited
+// from a parent category have to be removed there.
+func (cDb *CategoryDb) RemoveModerator(ctx context.Context, req *category.RemoveCategoryModeratorRequest) (*category.RemoveCategoryModeratorResponse, error) {
+	query := `
+		DELETE FROM
+			category_moderators
+		WHERE
+			category_id = $1
+		AND
+			user_id = $2
+	`
+	tag, err := cDb.Db.Exec(ctx, query, req.CategoryId, req.UserId)
+	if err != nil {
+		log.Error().Err(err).Msg("Error removing category moderator")
+		return nil, err
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, ErrCategoryModeratorNotFound
+	}
+	return &category.RemoveCategoryModeratorResponse{Message: "Category moderator removed successfully"}, nil
+}
+
+// GetModerators lists the moderators of a category, including those assigned
+// to its ancestors unless req.DirectOnly is set. Moderators of nearer
+// categories come first.
+func (cDb *CategoryDb) GetModerators(ctx context.Context, req *category.ListCategoryModeratorsRequest) (*category.ListCategoryModeratorsResponse, error) {
+	query := moderatedAncestors + `
+		SELECT
+			m.category_id,
+			m.user_id,
+			COALESCE(m.assigned_by::text, ''),
+			m.created_at
+		FROM
+			category_moderators m
+		JOIN
+			ancestors a ON a.id = m.category_id
+		WHERE
+			NOT $2 OR a.depth = 0
+		ORDER BY
+			a.depth,
+			m.created_at
+	`
+	rows, err := cDb.Db.Query(ctx, query, req.CategoryId, req.DirectOnly)
+	if err != nil {
+		log.Error().Err(err).Msg("Error getting category moderators")
+		return nil, err
+	}
+	defer rows.Close()
+
+	var moderators []*category.CategoryModerator
+	for rows.Next() {
+		moderator, err := scanCategoryModerator(rows)
+		if err != nil {
+			log.Error().Err(err).Msg("Error scanning category moderator row")
+			return nil, err
+		}
+		moderators = append(moderators, moderator)
+	}
+
+	if err = rows.Err(); err != nil {
+		log.Error().Err(err).Msg("Error iterating over category moderator rows")
+		return nil, err
+	}
+
+	return &category.ListCategoryModeratorsResponse{Moderators: moderators}, nil
+}
+
+// moderatedCategories lists the categories a user moderates, directly or
+// through an ancestor. It is formatted with the number of the user parameter.
+const moderatedCategories = `
+		WITH RECURSIVE moderated AS (
+			SELECT
+				category_id AS id,
+				0 AS depth
+			FROM
+				category_moderators
+			WHERE
+				user_id = $%d
+			UNION ALL
+			SELECT
+				c.id,
+				m.depth + 1
+			FROM
+				categories c
+			JOIN
+				moderated m ON c.parent_id = m.id
+			WHERE
+				m.depth < 100
+		)`
+
+// IsModerator reports whether userID moderates categoryID or one of its ancestors.
+func (cDb *CategoryDb) IsModerator(ctx context.Context, categoryID, userID string) (bool, error) {
+	query := moderatedAncestors + `
+		SELECT EXISTS (
+			SELECT
+				1
+			FROM
+				category_moderators m
+			JOIN
+				ancestors a ON a.id = m.category_id
+			WHERE
+				m.user_id = $2
+		)
+	`
+	var moderates bool
+	if err := cDb.Db.QueryRow(ctx, query, categoryID, userID).Scan(&moderates); err != nil {
+		log.Error().Err(err).Msg("Error checking category moderator")
+		return false, err
+	}
+	return moderates, nil
+}
+
+func scanCategoryModerator(row pgx.Row) (*category.CategoryModerator, error) {
+	var (
+		moderator category.CategoryModerator
+		createdAt time.Time
+	)
+	if err := row.Scan(&moderator.CategoryId, &moderator.UserId, &moderator.AssignedBy, &createdAt); err != nil {
+		return nil, err
+	}
+	moderator.CreatedAt = createdAt.Format(time.RFC3339)
+	return &moderator, nil
+}
@@ -2,26 +2,73 @@ package postgres
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"time"
 
 	"github.com/Forum-service/Forum-Service/genproto/comment" // Your comment proto package
+	"github.com/Forum-service/Forum-Service/storage"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/rs/zerolog/log"
 )
 
 // ErrCommentNotFound is returned when a comment is not found.
-var ErrCommentNotFound = errors.New("comment not found")
+var ErrCommentNotFound = &storage.NotFoundError{Resource: "comment"}
+
+// commentColumns lists the columns read by scanComment. Queries alias comments as c.
+const commentColumns = `
+			c.id,
+			c.post_id,
+			c.user_id,
+			c.body,
+			COALESCE(c.parent_id::text, ''),
+			c.edited_at,
+			c.version,
+			c.hidden,
+			c.created_at,
+			c.updated_at`
+
+// scanComment scans a row selected with commentColumns followed by any extra columns.
+func scanComment(row pgx.Row, extra ...any) (*comment.Comment, error) {
+	var (
+		dbComment comment.Comment
+		editedAt  *time.Time
+		createdAt time.Time
+		updatedAt time.Time
+	)
+
+	dest := []any{
+		&dbComment.Id,
+		&dbComment.PostId,
+		&dbComment.UserId,
+		&dbComment.Body,
+		&dbComment.ParentId,
+		&editedAt,
+		&dbComment.Version,
+		&dbComment.Hidden,
+		&createdAt,
+		&updatedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+
+	if editedAt != nil {
+		dbComment.EditedAt = editedAt.Format(time.RFC3339)
+	}
+	dbComment.CreatedAt = createdAt.Format(time.RFC3339)
+	dbComment.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+	return &dbComment, nil
+}
 
 // CommentDb provides database operations for comments.
 type CommentDb struct {
-	Db *pgx.Conn
+	Db DB
 }
 
 // NewComment creates a new instance of CommentDb.
-func NewComment(db *pgx.Conn) *CommentDb {
+func NewComment(db DB) *CommentDb {
 	return &CommentDb{Db: db}
 }
 
@@ -30,82 +77,43 @@ func (cDb *CommentDb) Create(ctx context.Context, req *comment.CreateCommentRequ
 	commentID := uuid.New().String()
 	query := `
 		INSERT INTO 
-			comments (
+			comments AS c (
 				id,
 				post_id,
 				user_id,
-				body
+				body,
+				parent_id
 			) 
 		VALUES (
 				$1, 
 				$2, 
 				$3,
-				$4
+				$4,
+				NULLIF($5, '')::uuid
 			)
-		RETURNING 
-			id,
-			post_id,
-			user_id,
-			body,
-			created_at,
-			updated_at
-	`
-	var (
-		dbComment comment.Comment
-		createdAt time.Time
-		updatedAt time.Time
-	)
+		RETURNING ` + commentColumns
 
-	err := cDb.Db.QueryRow(ctx, query, commentID, req.PostId, req.UserId, req.Body).Scan(
-		&dbComment.Id,
-		&dbComment.PostId,
-		&dbComment.UserId,
-		&dbComment.Body,
-		&createdAt,
-		&updatedAt,
-	)
+	dbComment, err := scanComment(cDb.Db.QueryRow(ctx, query, commentID, req.PostId, req.UserId, req.Body, req.ParentId))
 	if err != nil {
 		log.Error().Err(err).Msg("Error creating comment")
 		return nil, err
 	}
 
-	dbComment.CreatedAt = createdAt.Format(time.RFC3339)
-	dbComment.UpdatedAt = updatedAt.Format(time.RFC3339)
-
-	return &comment.CreateCommentResponse{Comment: &dbComment}, nil
+	return &comment.CreateCommentResponse{Comment: dbComment}, nil
 }
 
 // GetById gets a comment by its ID.
 func (cDb *CommentDb) GetById(ctx context.Context, req *comment.GetCommentRequest) (*comment.GetCommentResponse, error) {
-	var (
-		dbComment comment.Comment
-		createdAt time.Time
-		updatedAt time.Time
-	)
-
 	query := `
-		SELECT
-			id,
-			post_id,
-			user_id,
-			body,
-			created_at,
-			updated_at
+		SELECT` + commentColumns + `
 		FROM 
-			comments 
+			comments c
 		WHERE 
-			id = $1 
+			c.id = $1 
 		AND 
-			deleted_at = 0
+			c.deleted_at = 0
 	`
-	err := cDb.Db.QueryRow(ctx, query, req.Id).Scan(
-		&dbComment.Id,
-		&dbComment.PostId,
-		&dbComment.UserId,
-		&dbComment.Body,
-		&createdAt,
-		&updatedAt,
-	)
+	dbComment, err := scanComment(cDb.Db.QueryRow(ctx, query, req.Id))
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			log.Error().Err(err).Msg("Comment not found")
@@ -115,19 +123,18 @@ func (cDb *CommentDb) GetById(ctx context.Context, req *comment.GetCommentReques
 		return nil, err
 	}
 
-	dbComment.CreatedAt = createdAt.Format(time.RFC3339)
-	dbComment.UpdatedAt = updatedAt.Format(time.RFC3339)
-
-	return &comment.GetCommentResponse{Comment: &dbComment}, nil
+	return &comment.GetCommentResponse{Comment: dbComment}, nil
 }
 
-// Update updates an existing comment in the database.
+// Update updates an existing comment in the database. The comment is locked
+// first and the body it had before the update is stored as a revision in the
+// same transaction, or a savepoint when Db is already a transaction.
 func (cDb *CommentDb) Update(ctx context.Context, req *comment.UpdateCommentRequest) (*comment.UpdateCommentResponse, error) {
-	var args []interface{}
-	count := 1
+	args := []interface{}{req.Id}
+	count := 2
 	query := `
 		UPDATE 
-			comments 
+			comments c
 		SET `
 	filter := ``
 
@@ -139,37 +146,67 @@ func (cDb *CommentDb) Update(ctx context.Context, req *comment.UpdateCommentRequ
 
 	if filter == "" {
 		log.Error().Msg("No fields provided for update.")
-		return nil, errors.New("no fields provided for update")
+		return nil, storage.ErrNoFieldsToUpdate
 	}
 
-	filter += fmt.Sprintf(`
+	filter += `
+			revision_count = c.revision_count + 1,
+			version = c.version + 1,
+			edited_at = NOW(),
 			updated_at = NOW()
 		WHERE
-			id = $%d
-		AND 
-			deleted_at = 0
-			`, count)
+			c.id = $1
+		RETURNING ` + commentColumns
 
-	args = append(args, req.Id)
 	query += filter
 
-	_, err := cDb.Db.Exec(ctx, query, args...)
+	tx, err := cDb.Db.Begin(ctx)
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			log.Error().Err(err).Msg("Comment not found")
-			return nil, ErrCommentNotFound
-		}
-		log.Error().Err(err).Msg("Error updating comment")
+		log.Error().Err(err).Msg("Error starting comment update")
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockVersion(ctx, tx, "comments", req.Id, req.ExpectedVersion, ErrCommentNotFound); err != nil {
+		log.Error().Err(err).Msg("Comment not found or version mismatch")
 		return nil, err
 	}
 
-	// After successful update, get the updated comment from the database
-	updatedComment, err := cDb.GetById(ctx, &comment.GetCommentRequest{Id: req.Id})
+	_, err = tx.Exec(ctx, `
+		INSERT INTO 
+			comment_revisions (
+				comment_id,
+				revision,
+				editor_id,
+				body
+			)
+		SELECT
+			id,
+			revision_count + 1,
+			NULLIF($2, '')::uuid,
+			body
+		FROM 
+			comments
+		WHERE 
+			id = $1
+	`, req.Id, req.EditorId)
+	if err != nil {
+		log.Error().Err(err).Msg("Error storing comment revision")
+		return nil, err
+	}
+
+	updatedComment, err := scanComment(tx.QueryRow(ctx, query, args...))
 	if err != nil {
+		log.Error().Err(err).Msg("Error updating comment")
 		return nil, err
 	}
 
-	return &comment.UpdateCommentResponse{Comment: updatedComment.Comment}, nil
+	if err := tx.Commit(ctx); err != nil {
+		log.Error().Err(err).Msg("Error committing comment update")
+		return nil, err
+	}
+
+	return &comment.UpdateCommentResponse{Comment: updatedComment}, nil
 }
 
 // Delete soft deletes a comment by setting its deleted_at field to the current Unix timestamp.
@@ -178,19 +215,21 @@ func (cDb *CommentDb) Delete(ctx context.Context, req *comment.DeleteCommentRequ
 		UPDATE 
 			comments 
 		SET 
-			deleted_at = $1
+			deleted_at = $1,
+			updated_at = NOW()
 		WHERE 
 			id = $2
+		AND 
+			deleted_at = 0
 	`
-	_, err := cDb.Db.Exec(ctx, query, time.Now().Unix(), req.Id)
+	result, err := cDb.Db.Exec(ctx, query, time.Now().Unix(), req.Id)
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			log.Error().Err(err).Msg("Comment not found")
-			return nil, ErrCommentNotFound
-		}
 		log.Error().Err(err).Msg("Error soft deleting comment")
 		return nil, err
 	}
+	if result.RowsAffected() == 0 {
+		return nil, ErrCommentNotFound
+	}
 	return &comment.DeleteCommentResponse{Message: "Comment soft deleted successfully"}, nil
 }
 
@@ -201,27 +240,22 @@ func (cDb *CommentDb) GetAllComments(ctx context.Context, req *comment.GetAllCom
 		count int = 1
 	)
 	query := `
-		SELECT
-			id,
-			post_id,
-			user_id,
-			body,
-			created_at,
-			updated_at
+		SELECT` + commentColumns + `
 		FROM 
-			comments
-		WHERE deleted_at = 0
+			comments c
+		WHERE c.deleted_at = 0
+		AND NOT c.hidden
 	`
 	filter := ""
 
 	if req.PostId != "" {
-		filter += fmt.Sprintf(" AND post_id = $%d", count)
+		filter += fmt.Sprintf(" AND c.post_id = $%d", count)
 		args = append(args, req.PostId)
 		count++
 	}
 
 	if req.UserId != "" {
-		filter += fmt.Sprintf(" AND user_id = $%d", count)
+		filter += fmt.Sprintf(" AND c.user_id = $%d", count)
 		args = append(args, req.UserId)
 		count++
 	}
@@ -230,7 +264,7 @@ func (cDb *CommentDb) GetAllComments(ctx context.Context, req *comment.GetAllCom
 
 	// Apply pagination
 	if req.Limit <= 0 {
-		req.Limit = 10 // Default limit
+		req.Limit = DefaultPageSize
 	}
 	if req.Page <= 0 {
 		req.Page = 1 // Default page
@@ -247,33 +281,202 @@ func (cDb *CommentDb) GetAllComments(ctx context.Context, req *comment.GetAllCom
 
 	var comments []*comment.Comment
 	for rows.Next() {
+		dbComment, err := scanComment(rows)
+		if err != nil {
+			log.Error().Err(err).Msg("Error scanning comment row")
+			return nil, err
+		}
+
+		comments = append(comments, dbComment)
+	}
+
+	if err = rows.Err(); err != nil {
+		log.Error().Err(err).Msg("Error iterating over comment rows")
+		return nil, err
+	}
+
+	return &comment.GetAllCommentsResponse{Comments: comments}, nil
+}
+
+// GetChanges retrieves every comment of a post created, updated or deleted at or after
+// since, oldest change first. Changes at since itself are repeated, since comments
+// committed later can share its timestamp. Deleted and hidden comments are included,
+// stripped to their IDs, so watchers can drop them.
+func (cDb *CommentDb) GetChanges(ctx context.Context, postID string, since time.Time) ([]*comment.CommentEvent, error) {
+	query := `
+		SELECT` + commentColumns + `,
+			c.deleted_at,
+			c.created_at,
+			c.updated_at
+		FROM 
+			comments c
+		WHERE 
+			c.post_id = $1
+		AND 
+			c.updated_at >= $2
+		ORDER BY 
+			c.updated_at
+	`
+	rows, err := cDb.Db.Query(ctx, query, postID, since)
+	if err != nil {
+		log.Error().Err(err).Msg("Error listing comment changes")
+		return nil, err
+	}
+	defer rows.Close()
+
+	var changes []*comment.CommentEvent
+	for rows.Next() {
+		// scanComment formats timestamps to the second, the cursor needs full precision
 		var (
+			deletedAt int64
 			createdAt time.Time
 			updatedAt time.Time
 		)
-		dbComment := &comment.Comment{}
+		dbComment, err := scanComment(rows, &deletedAt, &createdAt, &updatedAt)
+		if err != nil {
+			log.Error().Err(err).Msg("Error scanning comment change row")
+			return nil, err
+		}
+
+		// Deleted and hidden comments are announced without their content
+		eventType := "updated"
+		switch {
+		case deletedAt != 0:
+			eventType = "deleted"
+			dbComment = &comment.Comment{
+				Id:        dbComment.Id,
+				PostId:    dbComment.PostId,
+				DeletedAt: time.Unix(deletedAt, 0).UTC().Format(time.RFC3339),
+			}
+		case dbComment.Hidden:
+			eventType = "hidden"
+			dbComment = &comment.Comment{Id: dbComment.Id, PostId: dbComment.PostId, Hidden: true}
+		case createdAt.Equal(updatedAt):
+			eventType = "created"
+		}
+
+		changes = append(changes, &comment.CommentEvent{
+			Type:    eventType,
+			Comment: dbComment,
+			Cursor:  updatedAt.Format(time.RFC3339Nano),
+		})
+	}
+
+	if err = rows.Err(); err != nil {
+		log.Error().Err(err).Msg("Error iterating over comment change rows")
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// GetRevisions retrieves the stored revisions of a comment, newest first.
+func (cDb *CommentDb) GetRevisions(ctx context.Context, req *comment.GetCommentRevisionsRequest) (*comment.GetCommentRevisionsResponse, error) {
+	query := `
+		SELECT
+			id,
+			comment_id,
+			revision,
+			COALESCE(editor_id::text, ''),
+			body,
+			created_at
+		FROM 
+			comment_revisions
+		WHERE 
+			comment_id = $1
+		ORDER BY 
+			revision DESC
+	`
+
+	// Apply pagination
+	if req.Limit <= 0 {
+		req.Limit = DefaultPageSize
+	}
+	if req.Page <= 0 {
+		req.Page = 1 // Default page
+	}
+	offset := (req.Page - 1) * req.Limit
+	query += fmt.Sprintf(" OFFSET %d LIMIT %d", offset, req.Limit)
+
+	rows, err := cDb.Db.Query(ctx, query, req.CommentId)
+	if err != nil {
+		log.Error().Err(err).Msg("Error listing comment revisions")
+		return nil, err
+	}
+	defer rows.Close()
+
+	var revisions []*comment.CommentRevision
+	for rows.Next() {
+		var createdAt time.Time
+		dbRevision := &comment.CommentRevision{}
 		err := rows.Scan(
-			&dbComment.Id,
-			&dbComment.PostId,
-			&dbComment.UserId,
-			&dbComment.Body,
+			&dbRevision.Id,
+			&dbRevision.CommentId,
+			&dbRevision.Revision,
+			&dbRevision.EditorId,
+			&dbRevision.Body,
 			&createdAt,
-			&updatedAt,
 		)
 		if err != nil {
-			log.Error().Err(err).Msg("Error scanning comment row")
+			log.Error().Err(err).Msg("Error scanning comment revision row")
 			return nil, err
 		}
-		dbComment.CreatedAt = createdAt.Format(time.RFC3339)
-		dbComment.UpdatedAt = updatedAt.Format(time.RFC3339)
+		dbRevision.CreatedAt = createdAt.Format(time.RFC3339)
 
-		comments = append(comments, dbComment)
+		revisions = append(revisions, dbRevision)
 	}
 
 	if err = rows.Err(); err != nil {
-		log.Error().Err(err).Msg("Error iterating over comment rows")
+		log.Error().Err(err).Msg("Error iterating over comment revision rows")
 		return nil, err
 	}
 
-	return &comment.GetAllCommentsResponse{Comments: comments}, nil
+	return &comment.GetCommentRevisionsResponse{Revisions: revisions}, nil
+}
+
+// SetHidden hides a comment from listings or makes it visible again.
+func (cDb *CommentDb) SetHidden(ctx context.Context, commentID string, hidden bool) error {
+	query := `
+		UPDATE 
+			comments 
+		SET 
+			hidden = $2,
+			updated_at = NOW()
+		WHERE 
+			id = $1
+		AND 
+			deleted_at = 0
+	`
+	tag, err := cDb.Db.Exec(ctx, query, commentID, hidden)
+	if err != nil {
+		log.Error().Err(err).Msg("Error hiding comment")
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrCommentNotFound
+	}
+	return nil
+}
+
+// CreatedAt returns when a comment was posted.
+func (cDb *CommentDb) CreatedAt(ctx context.Context, commentID string) (time.Time, error) {
+	query := `
+		SELECT 
+			created_at 
+		FROM 
+			comments 
+		WHERE 
+			id = $1 
+		AND 
+			deleted_at = 0
+	`
+	var createdAt time.Time
+	if err := cDb.Db.QueryRow(ctx, query, commentID).Scan(&createdAt); err != nil {
+		if err == pgx.ErrNoRows {
+			return time.Time{}, ErrCommentNotFound
+		}
+		log.Error().Err(err).Msg("Error getting comment creation time")
+		return time.Time{}, err
+	}
+	return createdAt, nil
 }
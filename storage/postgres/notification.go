@@ -0,0 +1,362 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Forum-service/Forum-Service/genproto/notification"
+	"github.com/Forum-service/Forum-Service/storage"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// ErrCategoryFollowNotFound is returned when a user does not follow the given category.
+var ErrCategoryFollowNotFound = &storage.NotFoundError{Resource: "category follow"}
+
+// NotificationDb provides database operations for notifications, preferences and category follows.
+type NotificationDb struct {
+	Db DB
+}
+
+// NewNotification creates a new instance of NotificationDb.
+func NewNotification(db DB) *NotificationDb {
+	return &NotificationDb{Db: db}
+}
+
+// Notify stores a notification. If the recipient already has an unread notification
+// of the same type for the same target, that row is bumped instead so bursts coalesce.
+func (nDb *NotificationDb) Notify(ctx context.Context, n *notification.Notification) error {
+	notificationID := uuid.New().String()
+	query := `
+		INSERT INTO
+			notifications (
+				id,
+				user_id,
+				type,
+				target_id,
+				post_id,
+				comment_id,
+				category_id,
+				actor_id
+			)
+		VALUES (
+				$1,
+				$2,
+				$3,
+				$4,
+				NULLIF($5, '')::uuid,
+				NULLIF($6, '')::uuid,
+				NULLIF($7, '')::uuid,
+				$8
+			)
+		ON CONFLICT (user_id, type, target_id) WHERE is_read = FALSE
+		DO UPDATE SET
+			count = notifications.count + 1,
+			comment_id = EXCLUDED.comment_id,
+			actor_id = EXCLUDED.actor_id,
+			updated_at = NOW()
+	`
+	_, err := nDb.Db.Exec(ctx, query,
+		notificationID,
+		n.UserId,
+		n.Type,
+		n.TargetId,
+		n.PostId,
+		n.CommentId,
+		n.CategoryId,
+		n.ActorId,
+	)
+	if err != nil {
+		log.Error().Err(err).Msg("Error creating notification")
+		return err
+	}
+	return nil
+}
+
+// GetAllNotifications retrieves notifications of a user, most recently updated first.
+func (nDb *NotificationDb) GetAllNotifications(ctx context.Context, req *notification.ListNotificationsRequest) (*notification.ListNotificationsResponse, error) {
+	args := []interface{}{req.UserId}
+	query := `
+		SELECT
+			n.id,
+			n.user_id,
+			n.type,
+			n.target_id,
+			COALESCE(n.post_id::text, ''),
+			COALESCE(n.comment_id::text, ''),
+			COALESCE(n.category_id::text, ''),
+			n.actor_id,
+			n.count,
+			n.is_read,
+			COALESCE(c.name, p.title, ''),
+			n.created_at,
+			n.updated_at
+		FROM
+			notifications n
+		LEFT JOIN posts p ON p.id = n.post_id
+		LEFT JOIN categories c ON c.id = n.category_id
+		WHERE
+			n.user_id = $1
+	`
+
+	if req.UnreadOnly {
+		query += " AND n.is_read = FALSE"
+	}
+
+	query += " ORDER BY n.updated_at DESC"
+
+	// Apply pagination
+	if req.Limit <= 0 {
+		req.Limit = DefaultPageSize
+	}
+	if req.Page <= 0 {
+		req.Page = 1 // Default page
+	}
+	offset := (req.Page - 1) * req.Limit
+	query += fmt.Sprintf(" OFFSET %d LIMIT %d", offset, req.Limit)
+
+	rows, err := nDb.Db.Query(ctx, query, args...)
+	if err != nil {
+		log.Error().Err(err).Msg("Error listing notifications")
+		return nil, err
+	}
+	defer rows.Close()
+
+	var notifications []*notification.Notification
+	for rows.Next() {
+		var (
+			createdAt time.Time
+			updatedAt time.Time
+		)
+		dbNotification := &notification.Notification{}
+		err := rows.Scan(
+			&dbNotification.Id,
+			&dbNotification.UserId,
+			&dbNotification.Type,
+			&dbNotification.TargetId,
+			&dbNotification.PostId,
+			&dbNotification.CommentId,
+			&dbNotification.CategoryId,
+			&dbNotification.ActorId,
+			&dbNotification.Count,
+			&dbNotification.IsRead,
+			&dbNotification.TargetTitle,
+			&createdAt,
+			&updatedAt,
+		)
+		if err != nil {
+			log.Error().Err(err).Msg("Error scanning notification row")
+			return nil, err
+		}
+		dbNotification.CreatedAt = createdAt.Format(time.RFC3339)
+		dbNotification.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+		notifications = append(notifications, dbNotification)
+	}
+
+	if err = rows.Err(); err != nil {
+		log.Error().Err(err).Msg("Error iterating over notification rows")
+		return nil, err
+	}
+
+	return &notification.ListNotificationsResponse{Notifications: notifications}, nil
+}
+
+// MarkRead marks the given notifications, or all of them, as read for a user.
+func (nDb *NotificationDb) MarkRead(ctx context.Context, req *notification.MarkNotificationsReadRequest) (*notification.MarkNotificationsReadResponse, error) {
+	args := []interface{}{req.UserId}
+	query := `
+		UPDATE
+			notifications
+		SET
+			is_read = TRUE,
+			updated_at = NOW()
+		WHERE
+			user_id = $1
+		AND
+			is_read = FALSE
+	`
+	if !req.All {
+		if len(req.Ids) == 0 {
+			return &notification.MarkNotificationsReadResponse{}, nil
+		}
+		query += " AND id = ANY($2::uuid[])"
+		args = append(args, req.Ids)
+	}
+
+	tag, err := nDb.Db.Exec(ctx, query, args...)
+	if err != nil {
+		log.Error().Err(err).Msg("Error marking notifications as read")
+		return nil, err
+	}
+	return &notification.MarkNotificationsReadResponse{Updated: int32(tag.RowsAffected())}, nil
+}
+
+// GetUnreadCount counts unread notifications of a user.
+func (nDb *NotificationDb) GetUnreadCount(ctx context.Context, req *notification.GetUnreadCountRequest) (*notification.GetUnreadCountResponse, error) {
+	query := `
+		SELECT
+			COUNT(*)
+		FROM
+			notifications
+		WHERE
+			user_id = $1
+		AND
+			is_read = FALSE
+	`
+	var count int32
+	if err := nDb.Db.QueryRow(ctx, query, req.UserId).Scan(&count); err != nil {
+		log.Error().Err(err).Msg("Error counting unread notifications")
+		return nil, err
+	}
+	return &notification.GetUnreadCountResponse{Count: count}, nil
+}
+
+// GetPreferences retrieves the preferences a user has explicitly stored.
+func (nDb *NotificationDb) GetPreferences(ctx context.Context, req *notification.GetNotificationPreferencesRequest) (*notification.GetNotificationPreferencesResponse, error) {
+	query := `
+		SELECT
+			type,
+			enabled
+		FROM
+			notification_preferences
+		WHERE
+			user_id = $1
+	`
+	rows, err := nDb.Db.Query(ctx, query, req.UserId)
+	if err != nil {
+		log.Error().Err(err).Msg("Error listing notification preferences")
+		return nil, err
+	}
+	defer rows.Close()
+
+	var preferences []*notification.NotificationPreference
+	for rows.Next() {
+		preference := &notification.NotificationPreference{}
+		if err := rows.Scan(&preference.Type, &preference.Enabled); err != nil {
+			log.Error().Err(err).Msg("Error scanning notification preference row")
+			return nil, err
+		}
+		preferences = append(preferences, preference)
+	}
+
+	if err = rows.Err(); err != nil {
+		log.Error().Err(err).Msg("Error iterating over notification preference rows")
+		return nil, err
+	}
+
+	return &notification.GetNotificationPreferencesResponse{Preferences: preferences}, nil
+}
+
+// UpdatePreferences upserts the given preferences and returns everything stored for the user.
+func (nDb *NotificationDb) UpdatePreferences(ctx context.Context, req *notification.UpdateNotificationPreferencesRequest) (*notification.UpdateNotificationPreferencesResponse, error) {
+	query := `
+		INSERT INTO
+			notification_preferences (
+				user_id,
+				type,
+				enabled
+			)
+		VALUES (
+				$1,
+				$2,
+				$3
+			)
+		ON CONFLICT (user_id, type)
+		DO UPDATE SET
+			enabled = EXCLUDED.enabled,
+			updated_at = NOW()
+	`
+	for _, preference := range req.Preferences {
+		if _, err := nDb.Db.Exec(ctx, query, req.UserId, preference.Type, preference.Enabled); err != nil {
+			log.Error().Err(err).Msg("Error updating notification preference")
+			return nil, err
+		}
+	}
+
+	stored, err := nDb.GetPreferences(ctx, &notification.GetNotificationPreferencesRequest{UserId: req.UserId})
+	if err != nil {
+		return nil, err
+	}
+	return &notification.UpdateNotificationPreferencesResponse{Preferences: stored.Preferences}, nil
+}
+
+// FollowCategory subscribes a user to new posts in a category.
+func (nDb *NotificationDb) FollowCategory(ctx context.Context, req *notification.FollowCategoryRequest) (*notification.FollowCategoryResponse, error) {
+	query := `
+		INSERT INTO
+			category_follows (
+				user_id,
+				category_id
+			)
+		VALUES (
+				$1,
+				$2
+			)
+		ON CONFLICT DO NOTHING
+	`
+	_, err := nDb.Db.Exec(ctx, query, req.UserId, req.CategoryId)
+	if err != nil {
+		log.Error().Err(err).Msg("Error following category")
+		return nil, err
+	}
+	return &notification.FollowCategoryResponse{Message: "Category followed successfully"}, nil
+}
+
+// UnfollowCategory removes a user's subscription to a category.
+func (nDb *NotificationDb) UnfollowCategory(ctx context.Context, req *notification.UnfollowCategoryRequest) (*notification.UnfollowCategoryResponse, error) {
+	query := `
+		DELETE FROM
+			category_follows
+		WHERE
+			user_id = $1
+		AND
+			category_id = $2
+	`
+	tag, err := nDb.Db.Exec(ctx, query, req.UserId, req.CategoryId)
+	if err != nil {
+		log.Error().Err(err).Msg("Error unfollowing category")
+		return nil, err
+	}
+	if tag.RowsAffected() == 0 {
+		log.Error().Msg("Category follow not found")
+		return nil, ErrCategoryFollowNotFound
+	}
+	return &notification.UnfollowCategoryResponse{Message: "Category unfollowed successfully"}, nil
+}
+
+// GetCategoryFollowers returns the IDs of all users following a category.
+func (nDb *NotificationDb) GetCategoryFollowers(ctx context.Context, categoryID string) ([]string, error) {
+	query := `
+		SELECT
+			user_id
+		FROM
+			category_follows
+		WHERE
+			category_id = $1
+	`
+	rows, err := nDb.Db.Query(ctx, query, categoryID)
+	if err != nil {
+		log.Error().Err(err).Msg("Error listing category followers")
+		return nil, err
+	}
+	defer rows.Close()
+
+	var followers []string
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			log.Error().Err(err).Msg("Error scanning category follower row")
+			return nil, err
+		}
+		followers = append(followers, userID)
+	}
+
+	if err = rows.Err(); err != nil {
+		log.Error().Err(err).Msg("Error iterating over category follower rows")
+		return nil, err
+	}
+
+	return followers, nil
+}
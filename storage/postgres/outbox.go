@@ -0,0 +1,215 @@
+package postgres
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/Forum-service/Forum-Service/events"
+	"github.com/rs/zerolog/log"
+)
+
+// OutboxDb provides database operations for the event outbox.
+type OutboxDb struct {
+	Db DB
+}
+
+// NewOutbox creates a new instance of OutboxDb.
+func NewOutbox(db DB) *OutboxDb {
+	return &OutboxDb{Db: db}
+}
+
+// Add stores an event in the outbox.
+func (oDb *OutboxDb) Add(ctx context.Context, e *events.Event) error {
+	query := `
+		INSERT INTO
+			outbox_events (
+				id,
+				type,
+				aggregate_type,
+				aggregate_id,
+				payload,
+				created_at
+			)
+		VALUES (
+				$1,
+				$2,
+				$3,
+				$4,
+				$5,
+				$6
+			)
+	`
+	_, err := oDb.Db.Exec(ctx, query, e.ID, e.Type, e.AggregateType, e.AggregateID, e.Payload, e.CreatedAt)
+	if err != nil {
+		log.Error().Err(err).Msg("Error adding outbox event")
+		return err
+	}
+	return nil
+}
+
+// claimLock is the advisory lock serializing ClaimPending across relays.
+const claimLock = `hashtext('outbox_events_claim')`
+
+// ClaimPending leases up to limit due, unpublished events, oldest first. An
+// event is held back while an earlier event of the same aggregate is
+// unpublished, so a failed event is retried before anything written after it
+// is published. Claims take an advisory lock so each one sees the leases of
+// the last: otherwise a relay could skip an event another relay is claiming
+// and publish a later event of its aggregate alongside it. Relays still
+// publish their batches concurrently.
+func (oDb *OutboxDb) ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]*events.Event, error) {
+	tx, err := oDb.Db.Begin(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Error starting outbox claim")
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(`+claimLock+`)`); err != nil {
+		log.Error().Err(err).Msg("Error locking outbox claims")
+		return nil, err
+	}
+
+	query := `
+		UPDATE
+			outbox_events
+		SET
+			locked_until = NOW() + make_interval(secs => $2)
+		WHERE
+			id IN (
+				SELECT
+					e.id
+				FROM
+					outbox_events e
+				WHERE
+					e.published_at IS NULL
+				AND
+					e.next_attempt_at <= NOW()
+				AND
+					(e.locked_until IS NULL OR e.locked_until < NOW())
+				AND NOT EXISTS (
+					SELECT
+						1
+					FROM
+						outbox_events earlier
+					WHERE
+						earlier.aggregate_type = e.aggregate_type
+					AND
+						earlier.aggregate_id = e.aggregate_id
+					AND
+						earlier.published_at IS NULL
+					AND
+						earlier.seq < e.seq
+					-- Unless it is claimed in this same batch, ahead of e
+					AND NOT (
+						earlier.next_attempt_at <= NOW()
+						AND
+						(earlier.locked_until IS NULL OR earlier.locked_until < NOW())
+					)
+				)
+				ORDER BY
+					e.seq
+				LIMIT $1
+				FOR UPDATE SKIP LOCKED
+			)
+		RETURNING
+			id,
+			type,
+			aggregate_type,
+			aggregate_id,
+			payload,
+			created_at,
+			attempts,
+			seq
+	`
+	rows, err := tx.Query(ctx, query, limit, lease.Seconds())
+	if err != nil {
+		log.Error().Err(err).Msg("Error claiming outbox events")
+		return nil, err
+	}
+	defer rows.Close()
+
+	var (
+		pending []*events.Event
+		seqs    = map[string]int64{}
+	)
+	for rows.Next() {
+		var seq int64
+		e := &events.Event{}
+		err := rows.Scan(
+			&e.ID,
+			&e.Type,
+			&e.AggregateType,
+			&e.AggregateID,
+			&e.Payload,
+			&e.CreatedAt,
+			&e.Attempts,
+			&seq,
+		)
+		if err != nil {
+			log.Error().Err(err).Msg("Error scanning outbox event row")
+			return nil, err
+		}
+		seqs[e.ID] = seq
+		pending = append(pending, e)
+	}
+
+	if err = rows.Err(); err != nil {
+		log.Error().Err(err).Msg("Error iterating over outbox event rows")
+		return nil, err
+	}
+	rows.Close()
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Error().Err(err).Msg("Error committing outbox claim")
+		return nil, err
+	}
+
+	// RETURNING does not preserve the subquery order
+	sort.Slice(pending, func(i, j int) bool {
+		return seqs[pending[i].ID] < seqs[pending[j].ID]
+	})
+
+	return pending, nil
+}
+
+// MarkPublished records that an event was delivered.
+func (oDb *OutboxDb) MarkPublished(ctx context.Context, id string) error {
+	query := `
+		UPDATE
+			outbox_events
+		SET
+			published_at = NOW(),
+			locked_until = NULL
+		WHERE
+			id = $1
+	`
+	_, err := oDb.Db.Exec(ctx, query, id)
+	if err != nil {
+		log.Error().Err(err).Msg("Error marking outbox event as published")
+		return err
+	}
+	return nil
+}
+
+// MarkFailed records a failed delivery and schedules the next attempt.
+func (oDb *OutboxDb) MarkFailed(ctx context.Context, id string, nextAttemptAt time.Time, lastErr string) error {
+	query := `
+		UPDATE
+			outbox_events
+		SET
+			attempts = attempts + 1,
+			next_attempt_at = $2,
+			last_error = $3,
+			locked_until = NULL
+		WHERE
+			id = $1
+	`
+	_, err := oDb.Db.Exec(ctx, query, id, nextAttemptAt, lastErr)
+	if err != nil {
+		log.Error().Err(err).Msg("Error marking outbox event as failed")
+		return err
+	}
+	return nil
+}
@@ -2,26 +2,83 @@ package postgres
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"time"
 
 	"github.com/Forum-service/Forum-Service/genproto/post" // Your post proto package
+	"github.com/Forum-service/Forum-Service/storage"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/rs/zerolog/log"
 )
 
 // ErrPostNotFound is returned when a post is not found.
-var ErrPostNotFound = errors.New("post not found")
+var ErrPostNotFound = &storage.NotFoundError{Resource: "post"}
+
+// ErrPostRevisionNotFound is returned when a post has no revision with the requested number.
+var ErrPostRevisionNotFound = &storage.NotFoundError{Resource: "post revision"}
+
+// postColumns lists the columns read by scanPost. Queries alias posts as p.
+const postColumns = `
+			p.id,
+			p.user_id,
+			p.title,
+			p.body,
+			p.category_id,
+			p.revision_count,
+			p.version,
+			p.hidden,
+			p.pinned,
+			p.locked,
+			p.closed,
+			p.close_reason,
+			COALESCE(p.duplicate_of_id::text, ''),
+			p.created_at,
+			p.updated_at`
+
+// scanPost scans a row selected with postColumns.
+func scanPost(row pgx.Row) (*post.Post, error) {
+	var (
+		dbPost    post.Post
+		createdAt time.Time
+		updatedAt time.Time
+	)
+
+	err := row.Scan(
+		&dbPost.Id,
+		&dbPost.UserId,
+		&dbPost.Title,
+		&dbPost.Body,
+		&dbPost.CategoryId,
+		&dbPost.RevisionCount,
+		&dbPost.Version,
+		&dbPost.Hidden,
+		&dbPost.Pinned,
+		&dbPost.Locked,
+		&dbPost.Closed,
+		&dbPost.CloseReason,
+		&dbPost.DuplicateOfId,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	dbPost.Edited = dbPost.RevisionCount > 0
+	dbPost.CreatedAt = createdAt.Format(time.RFC3339)
+	dbPost.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+	return &dbPost, nil
+}
 
 // PostDb provides database operations for posts.
 type PostDb struct {
-	Db *pgx.Conn
+	Db DB
 }
 
 // NewPost creates a new instance of PostDb.
-func NewPost(db *pgx.Conn) *PostDb {
+func NewPost(db DB) *PostDb {
 	return &PostDb{Db: db}
 }
 
@@ -30,7 +87,7 @@ func (pDb *PostDb) Create(ctx context.Context, req *post.CreatePostRequest) (*po
 	postID := uuid.New().String()
 	query := `
 		INSERT INTO 
-			posts (
+			posts AS p (
 				id,
 				user_id,
 				title,
@@ -44,74 +101,29 @@ func (pDb *PostDb) Create(ctx context.Context, req *post.CreatePostRequest) (*po
 				$4,
 				$5
 			)
-		RETURNING 
-			id,
-			user_id,
-			title,
-			body,
-			category_id,
-			created_at,
-			updated_at
-	`
-	var (
-		dbPost    post.Post
-		createdAt time.Time
-		updatedAt time.Time
-	)
+		RETURNING ` + postColumns
 
-	err := pDb.Db.QueryRow(ctx, query, postID, req.UserId, req.Title, req.Body, req.CategoryId).Scan(
-		&dbPost.Id,
-		&dbPost.UserId,
-		&dbPost.Title,
-		&dbPost.Body,
-		&dbPost.CategoryId,
-		&createdAt,
-		&updatedAt,
-	)
+	dbPost, err := scanPost(pDb.Db.QueryRow(ctx, query, postID, req.UserId, req.Title, req.Body, req.CategoryId))
 	if err != nil {
 		log.Error().Err(err).Msg("Error creating post")
 		return nil, err
 	}
 
-	dbPost.CreatedAt = createdAt.Format(time.RFC3339)
-	dbPost.UpdatedAt = updatedAt.Format(time.RFC3339)
-
-	return &post.CreatePostResponse{Post: &dbPost}, nil
+	return &post.CreatePostResponse{Post: dbPost}, nil
 }
 
 // GetById gets a post by its ID.
 func (pDb *PostDb) GetById(ctx context.Context, req *post.GetPostRequest) (*post.GetPostResponse, error) {
-	var (
-		dbPost    post.Post
-		createdAt time.Time
-		updatedAt time.Time
-	)
-
 	query := `
-		SELECT
-			id,
-			user_id,
-			title,
-			body,
-			category_id,
-			created_at,
-			updated_at
+		SELECT` + postColumns + `
 		FROM 
-			posts 
+			posts p
 		WHERE 
-			id = $1 
+			p.id = $1 
 		AND 
-			deleted_at = 0
+			p.deleted_at = 0
 	`
-	err := pDb.Db.QueryRow(ctx, query, req.Id).Scan(
-		&dbPost.Id,
-		&dbPost.UserId,
-		&dbPost.Title,
-		&dbPost.Body,
-		&dbPost.CategoryId,
-		&createdAt,
-		&updatedAt,
-	)
+	dbPost, err := scanPost(pDb.Db.QueryRow(ctx, query, req.Id))
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			log.Error().Err(err).Msg("Post not found")
@@ -121,19 +133,18 @@ func (pDb *PostDb) GetById(ctx context.Context, req *post.GetPostRequest) (*post
 		return nil, err
 	}
 
-	dbPost.CreatedAt = createdAt.Format(time.RFC3339)
-	dbPost.UpdatedAt = updatedAt.Format(time.RFC3339)
-
-	return &post.GetPostResponse{Post: &dbPost}, nil
+	return &post.GetPostResponse{Post: dbPost}, nil
 }
 
-// Update updates an existing post in the database.
+// Update updates an existing post in the database. The post is locked first
+// and the content it had before the update is stored as a revision in the
+// same transaction, or a savepoint when Db is already a transaction.
 func (pDb *PostDb) Update(ctx context.Context, req *post.UpdatePostRequest) (*post.UpdatePostResponse, error) {
-	var args []interface{}
-	count := 1
+	args := []interface{}{req.Id}
+	count := 2
 	query := `
 		UPDATE 
-			posts 
+			posts p
 		SET `
 	filter := ``
 
@@ -157,37 +168,70 @@ func (pDb *PostDb) Update(ctx context.Context, req *post.UpdatePostRequest) (*po
 
 	if filter == "" {
 		log.Error().Msg("No fields provided for update.")
-		return nil, errors.New("no fields provided for update")
+		return nil, storage.ErrNoFieldsToUpdate
 	}
 
-	filter += fmt.Sprintf(`
+	filter += `
+			revision_count = p.revision_count + 1,
+			version = p.version + 1,
 			updated_at = NOW()
 		WHERE
-			id = $%d
-		AND 
-			deleted_at = 0
-			`, count)
+			p.id = $1
+		RETURNING ` + postColumns
 
-	args = append(args, req.Id)
 	query += filter
 
-	_, err := pDb.Db.Exec(ctx, query, args...)
+	tx, err := pDb.Db.Begin(ctx)
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			log.Error().Err(err).Msg("Post not found")
-			return nil, ErrPostNotFound
-		}
-		log.Error().Err(err).Msg("Error updating post")
+		log.Error().Err(err).Msg("Error starting post update")
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockVersion(ctx, tx, "posts", req.Id, req.ExpectedVersion, ErrPostNotFound); err != nil {
+		log.Error().Err(err).Msg("Post not found or version mismatch")
 		return nil, err
 	}
 
-	// After successful update, get the updated post from the database
-	updatedPost, err := pDb.GetById(ctx, &post.GetPostRequest{Id: req.Id})
+	_, err = tx.Exec(ctx, `
+		INSERT INTO 
+			post_revisions (
+				post_id,
+				revision,
+				editor_id,
+				title,
+				body,
+				category_id
+			)
+		SELECT
+			id,
+			revision_count + 1,
+			NULLIF($2, '')::uuid,
+			title,
+			body,
+			category_id
+		FROM 
+			posts
+		WHERE 
+			id = $1
+	`, req.Id, req.EditorId)
+	if err != nil {
+		log.Error().Err(err).Msg("Error storing post revision")
+		return nil, err
+	}
+
+	updatedPost, err := scanPost(tx.QueryRow(ctx, query, args...))
 	if err != nil {
+		log.Error().Err(err).Msg("Error updating post")
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Error().Err(err).Msg("Error committing post update")
 		return nil, err
 	}
 
-	return &post.UpdatePostResponse{Post: updatedPost.Post}, nil
+	return &post.UpdatePostResponse{Post: updatedPost}, nil
 }
 
 // Delete soft deletes a post by setting its deleted_at field to the current Unix timestamp.
@@ -199,16 +243,17 @@ func (pDb *PostDb) Delete(ctx context.Context, req *post.DeletePostRequest) (*po
 			deleted_at = $1
 		WHERE 
 			id = $2
+		AND 
+			deleted_at = 0
 	`
-	_, err := pDb.Db.Exec(ctx, query, time.Now().Unix(), req.Id)
+	result, err := pDb.Db.Exec(ctx, query, time.Now().Unix(), req.Id)
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			log.Error().Err(err).Msg("Post not found")
-			return nil, ErrPostNotFound
-		}
 		log.Error().Err(err).Msg("Error soft deleting post")
 		return nil, err
 	}
+	if result.RowsAffected() == 0 {
+		return nil, ErrPostNotFound
+	}
 	return &post.DeletePostResponse{Message: "Post soft deleted successfully"}, nil
 }
 
@@ -219,49 +264,62 @@ func (pDb *PostDb) GetAllPosts(ctx context.Context, req *post.GetAllPostsRequest
 		count int = 1
 	)
 	query := `
-		SELECT
-			id,
-			user_id,
-			title,
-			body,
-			category_id,
-			created_at,
-			updated_at
+		SELECT` + postColumns + `
 		FROM 
-			posts
-		WHERE deleted_at = 0
+			posts p
+		WHERE p.deleted_at = 0
+		AND NOT p.hidden
 	`
 	filter := ""
 
 	if req.UserId != "" {
-		filter += fmt.Sprintf(" AND user_id = $%d", count)
+		filter += fmt.Sprintf(" AND p.user_id = $%d", count)
 		args = append(args, req.UserId)
 		count++
 	}
 
 	if req.Title != "" {
-		filter += fmt.Sprintf(" AND title ILIKE $%d", count)
+		filter += fmt.Sprintf(" AND p.title ILIKE $%d", count)
 		args = append(args, "%"+req.Title+"%")
 		count++
 	}
 
 	if req.Body != "" {
-		filter += fmt.Sprintf(" AND body ILIKE $%d", count)
+		filter += fmt.Sprintf(" AND p.body ILIKE $%d", count)
 		args = append(args, "%"+req.Body+"%")
 		count++
 	}
 
-	if req.CategoryId != "" {
-		filter += fmt.Sprintf(" AND category_id = $%d", count)
+	if req.CategoryId != "" && req.IncludeDescendants {
+		filter += fmt.Sprintf(`
+		AND p.category_id IN (
+			WITH RECURSIVE subtree AS (
+				SELECT id FROM categories WHERE id = $%d
+				UNION
+				SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id WHERE c.deleted_at = 0
+			)
+			SELECT id FROM subtree
+		)`, count)
+		args = append(args, req.CategoryId)
+		count++
+	} else if req.CategoryId != "" {
+		filter += fmt.Sprintf(" AND p.category_id = $%d", count)
 		args = append(args, req.CategoryId)
 		count++
 	}
 
 	query += filter
 
+	// Pinned posts lead the listing of their category
+	if req.CategoryId != "" {
+		query += " ORDER BY p.pinned DESC, p.created_at DESC"
+	} else {
+		query += " ORDER BY p.created_at DESC"
+	}
+
 	// Apply pagination
 	if req.Limit <= 0 {
-		req.Limit = 10 // Default limit
+		req.Limit = DefaultPageSize
 	}
 	if req.Page <= 0 {
 		req.Page = 1 // Default page
@@ -278,26 +336,11 @@ func (pDb *PostDb) GetAllPosts(ctx context.Context, req *post.GetAllPostsRequest
 
 	var posts []*post.Post
 	for rows.Next() {
-		var (
-			createdAt time.Time
-			updatedAt time.Time
-		)
-		dbPost := &post.Post{}
-		err := rows.Scan(
-			&dbPost.Id,
-			&dbPost.UserId,
-			&dbPost.Title,
-			&dbPost.Body,
-			&dbPost.CategoryId,
-			&createdAt,
-			&updatedAt,
-		)
+		dbPost, err := scanPost(rows)
 		if err != nil {
 			log.Error().Err(err).Msg("Error scanning post row")
 			return nil, err
 		}
-		dbPost.CreatedAt = createdAt.Format(time.RFC3339)
-		dbPost.UpdatedAt = updatedAt.Format(time.RFC3339)
 
 		posts = append(posts, dbPost)
 	}
@@ -309,3 +352,187 @@ func (pDb *PostDb) GetAllPosts(ctx context.Context, req *post.GetAllPostsRequest
 
 	return &post.GetAllPostsResponse{Posts: posts}, nil
 }
+
+// GetRevisions retrieves the stored revisions of a post, newest first.
+func (pDb *PostDb) GetRevisions(ctx context.Context, req *post.GetPostRevisionsRequest) (*post.GetPostRevisionsResponse, error) {
+	query := `
+		SELECT
+			id,
+			post_id,
+			revision,
+			COALESCE(editor_id::text, ''),
+			title,
+			body,
+			category_id,
+			created_at
+		FROM 
+			post_revisions
+		WHERE 
+			post_id = $1
+		ORDER BY 
+			revision DESC
+	`
+
+	// Apply pagination
+	if req.Limit <= 0 {
+		req.Limit = DefaultPageSize
+	}
+	if req.Page <= 0 {
+		req.Page = 1 // Default page
+	}
+	offset := (req.Page - 1) * req.Limit
+	query += fmt.Sprintf(" OFFSET %d LIMIT %d", offset, req.Limit)
+
+	rows, err := pDb.Db.Query(ctx, query, req.PostId)
+	if err != nil {
+		log.Error().Err(err).Msg("Error listing post revisions")
+		return nil, err
+	}
+	defer rows.Close()
+
+	var revisions []*post.PostRevision
+	for rows.Next() {
+		revision, err := scanPostRevision(rows)
+		if err != nil {
+			log.Error().Err(err).Msg("Error scanning post revision row")
+			return nil, err
+		}
+		revisions = append(revisions, revision)
+	}
+
+	if err = rows.Err(); err != nil {
+		log.Error().Err(err).Msg("Error iterating over post revision rows")
+		return nil, err
+	}
+
+	return &post.GetPostRevisionsResponse{Revisions: revisions}, nil
+}
+
+// GetRevision gets a single revision of a post by its number.
+func (pDb *PostDb) GetRevision(ctx context.Context, postID string, revision int32) (*post.PostRevision, error) {
+	query := `
+		SELECT
+			id,
+			post_id,
+			revision,
+			COALESCE(editor_id::text, ''),
+			title,
+			body,
+			category_id,
+			created_at
+		FROM 
+			post_revisions
+		WHERE 
+			post_id = $1
+		AND 
+			revision = $2
+	`
+	dbRevision, err := scanPostRevision(pDb.Db.QueryRow(ctx, query, postID, revision))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			log.Error().Err(err).Msg("Post revision not found")
+			return nil, ErrPostRevisionNotFound
+		}
+		log.Error().Err(err).Msg("Error getting post revision")
+		return nil, err
+	}
+	return dbRevision, nil
+}
+
+// scanPostRevision scans a post_revisions row.
+func scanPostRevision(row pgx.Row) (*post.PostRevision, error) {
+	var (
+		dbRevision post.PostRevision
+		createdAt  time.Time
+	)
+
+	err := row.Scan(
+		&dbRevision.Id,
+		&dbRevision.PostId,
+		&dbRevision.Revision,
+		&dbRevision.EditorId,
+		&dbRevision.Title,
+		&dbRevision.Body,
+		&dbRevision.CategoryId,
+		&createdAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	dbRevision.CreatedAt = createdAt.Format(time.RFC3339)
+	return &dbRevision, nil
+}
+
+// SetHidden hides a post from listings or makes it visible again.
+func (pDb *PostDb) SetHidden(ctx context.Context, postID string, hidden bool) error {
+	query := `
+		UPDATE 
+			posts 
+		SET 
+			hidden = $2,
+			updated_at = NOW()
+		WHERE 
+			id = $1
+		AND 
+			deleted_at = 0
+	`
+	tag, err := pDb.Db.Exec(ctx, query, postID, hidden)
+	if err != nil {
+		log.Error().Err(err).Msg("Error hiding post")
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrPostNotFound
+	}
+	return nil
+}
+
+// Pin pins a post to the top of its category or unpins it.
+func (pDb *PostDb) Pin(ctx context.Context, req *post.PinPostRequest) (*post.ModeratePostResponse, error) {
+	return pDb.setStatus(ctx, req.PostId, "pinned = $2", req.Pinned)
+}
+
+// Lock stops or resumes accepting comments on a post.
+func (pDb *PostDb) Lock(ctx context.Context, req *post.LockPostRequest) (*post.ModeratePostResponse, error) {
+	return pDb.setStatus(ctx, req.PostId, "locked = $2", req.Locked)
+}
+
+// Close closes a post with a reason, or reopens it and clears the reason.
+func (pDb *PostDb) Close(ctx context.Context, req *post.ClosePostRequest) (*post.ModeratePostResponse, error) {
+	if !req.Closed {
+		return pDb.setStatus(ctx, req.PostId, "closed = $2, close_reason = '', duplicate_of_id = NULL", false)
+	}
+	return pDb.setStatus(ctx, req.PostId, "closed = $2, close_reason = $3, duplicate_of_id = NULLIF($4, '')::uuid",
+		true, req.Reason, req.DuplicateOfId)
+}
+
+// setStatus applies the assignments in set, whose arguments start at $2, to a
+// live post. Like edits it bumps the version, so editors holding the post from
+// before a status change get a conflict.
+func (pDb *PostDb) setStatus(ctx context.Context, postID, set string, args ...any) (*post.ModeratePostResponse, error) {
+	query := `
+		UPDATE 
+			posts p
+		SET 
+			` + set + `,
+			version = p.version + 1,
+			updated_at = NOW()
+		WHERE 
+			p.id = $1
+		AND 
+			p.deleted_at = 0
+		RETURNING ` + postColumns
+
+	updatedPost, err := scanPost(pDb.Db.QueryRow(ctx, query, append([]any{postID}, args...)...))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			log.Error().Err(err).Msg("Post not found")
+			return nil, ErrPostNotFound
+		}
+		log.Error().Err(err).Msg("Error updating post status")
+		return nil, err
+	}
+
+	return &post.ModeratePostResponse{Post: updatedPost}, nil
+}
@@ -2,27 +2,57 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/Forum-service/Forum-Service/config"
 	"github.com/Forum-service/Forum-Service/storage"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// DB is the part of the pgx API the repositories need. It is satisfied by
+// *pgx.Conn, *pgxpool.Pool and pgx.Tx, so the same repository code runs
+// standalone or inside a transaction.
+type DB interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
 // Storage struct holds the database connection and interfaces for each table.
 type Storage struct {
-	db *pgx.Conn
+	pool *pgxpool.Pool
+	db   DB
 
 	categoryRepo storage.CategoryRepo
 	tagRepo      storage.TagRepo
 	postRepo     storage.PostRepo
 	commentRepo  storage.CommentRepo
 	postTagRepo  storage.PostTagRepo
+
+	notificationRepo storage.NotificationRepo
+	outboxRepo       storage.OutboxRepo
+	reportRepo       storage.ReportRepo
+	automodRepo      storage.AutomodRepo
+	rateLimitRepo    storage.RateLimitRepo
+	auditRepo        storage.AuditRepo
 }
 
-// NewStorage establishes a connection to the Postgres database and returns a Storage struct.
-func NewStorage(cfg *config.Config) (*Storage, error) {
+// DefaultPageSize is the limit of list queries that do not set one.
+var DefaultPageSize int32 = 10
+
+// maxConnectBackoff caps the delay between connection attempts.
+const maxConnectBackoff = 30 * time.Second
+
+// NewStorage establishes a connection pool to the Postgres database and returns a Storage struct.
+// While Postgres is still coming up it retries up to cfg.PostgresConnectAttempts times, doubling
+// cfg.PostgresConnectBackoff after every failure, and gives up early when ctx is cancelled.
+func NewStorage(ctx context.Context, cfg *config.Config) (*Storage, error) {
 	dbURL := fmt.Sprintf("postgresql://%s:%s@%s:%d/%s",
 		cfg.PostgresUser,
 		cfg.PostgresPassword,
@@ -31,36 +61,181 @@ func NewStorage(cfg *config.Config) (*Storage, error) {
 		cfg.PostgresDatabase,
 	)
 
-	conn, err := pgx.Connect(context.Background(), dbURL)
+	poolCfg, err := pgxpool.ParseConfig(dbURL)
 	if err != nil {
-		slog.Error("Unable to connect to database", err)
 		return nil, err
 	}
+	poolCfg.MaxConns = int32(cfg.PostgresMaxConns)
+	poolCfg.MinConns = int32(cfg.PostgresMinConns)
+	poolCfg.MaxConnLifetime = cfg.PostgresMaxConnLifetime
+	poolCfg.MaxConnIdleTime = cfg.PostgresMaxConnIdleTime
+
+	backoff := cfg.PostgresConnectBackoff
+	for attempt := 1; ; attempt++ {
+		pool, err := connect(ctx, poolCfg)
+		if err == nil {
+			slog.Info("Connected to PostgreSQL database")
+			s := newStorage(pool)
+			s.pool = pool
+			return s, nil
+		}
+		if attempt >= cfg.PostgresConnectAttempts {
+			return nil, fmt.Errorf("connecting to postgres after %d attempts: %w", attempt, err)
+		}
 
-	if err := conn.Ping(context.Background()); err != nil {
-		slog.Error("Failed to ping database", err)
+		slog.Warn("Postgres is not available yet, retrying", "attempt", attempt, "retry_in", backoff, "err", err)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+		if backoff = backoff * 2; backoff > maxConnectBackoff {
+			backoff = maxConnectBackoff
+		}
+	}
+}
+
+// connect opens a pool and pings it, so a database that is not up yet fails here
+// rather than on the first query.
+func connect(ctx context.Context, poolCfg *pgxpool.Config) (*pgxpool.Pool, error) {
+	pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
+	if err != nil {
+		slog.Error("Unable to connect to database", "err", err)
 		return nil, err
 	}
 
-	slog.Info("Connected to PostgreSQL database")
+	if err := pool.Ping(ctx); err != nil {
+		slog.Error("Failed to ping database", "err", err)
+		pool.Close()
+		return nil, err
+	}
+	return pool, nil
+}
 
+// newStorage wires every repository to db.
+func newStorage(db DB) *Storage {
 	return &Storage{
-		db:           conn,
-		categoryRepo: NewCategory(conn),
-		tagRepo:      NewTag(conn),
-		postRepo:     NewPost(conn),
-		commentRepo:  NewComment(conn),
-		postTagRepo:  NewPostTag(conn),
-	}, nil
+		db:           db,
+		categoryRepo: NewCategory(db),
+		tagRepo:      NewTag(db),
+		postRepo:     NewPost(db),
+		commentRepo:  NewComment(db),
+		postTagRepo:  NewPostTag(db),
+
+		notificationRepo: NewNotification(db),
+		outboxRepo:       NewOutbox(db),
+		reportRepo:       NewReport(db),
+		automodRepo:      NewAutomod(db),
+		rateLimitRepo:    NewRateLimit(db),
+		auditRepo:        NewAudit(db),
+	}
 }
 
-// Close closes the database connection.
+// Close closes the database connection pool.
 func (s *Storage) Close() {
-	if err := s.db.Close(context.Background()); err != nil {
-		slog.Error("Error closing database connection", err)
-	} else {
-		slog.Info("Database connection closed successfully")
+	if s.pool == nil {
+		return
 	}
+	s.pool.Close()
+	slog.Info("Database connection closed successfully")
+}
+
+// Tx runs fn inside a transaction. Calling Tx on a storage that is already
+// bound to a transaction opens a savepoint.
+func (s *Storage) Tx(ctx context.Context, fn func(tx storage.StorageI) error) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		slog.Error("Error starting transaction", "err", err)
+		return err
+	}
+
+	if err := fn(newStorage(tx)); err != nil {
+		if rbErr := tx.Rollback(ctx); rbErr != nil {
+			slog.Error("Error rolling back transaction", "err", rbErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		slog.Error("Error committing transaction", "err", err)
+		return err
+	}
+	return nil
+}
+
+// Listen holds a dedicated pool connection in LISTEN mode and calls handle for
+// every notification on channel. It blocks until ctx is cancelled or the
+// connection fails.
+func (s *Storage) Listen(ctx context.Context, channel string, handle func(payload string)) error {
+	if s.pool == nil {
+		return errors.New("listen is not available inside a transaction")
+	}
+
+	conn, err := s.pool.Acquire(ctx)
+	if err != nil {
+		slog.Error("Error acquiring listen connection", "err", err)
+		return err
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+		slog.Error("Error listening on channel", "channel", channel, "err", err)
+		return err
+	}
+
+	for {
+		n, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		handle(n.Payload)
+	}
+}
+
+// missedUpdateError explains why an update of id in table matched no row: when
+// a version was expected and the row is still there, someone else changed it
+// first, otherwise notFound is returned.
+func missedUpdateError(ctx context.Context, db DB, table, id string, expectedVersion int32, notFound error) error {
+	if expectedVersion == 0 {
+		return notFound
+	}
+
+	var exists bool
+	query := fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM %s WHERE id = $1 AND deleted_at = 0)`, table)
+	if err := db.QueryRow(ctx, query, id).Scan(&exists); err != nil {
+		slog.Error("Error checking record existence", "table", table, "err", err)
+		return err
+	}
+	if exists {
+		return storage.ErrVersionConflict
+	}
+	return notFound
+}
+
+// lockVersion locks the live row id of table for the rest of the transaction
+// db belongs to and checks it still has expectedVersion, unless that is 0.
+// Concurrent updates of the row wait here, so each sees the version the
+// previous one wrote.
+func lockVersion(ctx context.Context, db DB, table, id string, expectedVersion int32, notFound error) error {
+	var version int32
+	query := fmt.Sprintf(`SELECT version FROM %s WHERE id = $1 AND deleted_at = 0 FOR UPDATE`, table)
+	if err := db.QueryRow(ctx, query, id).Scan(&version); err != nil {
+		if err == pgx.ErrNoRows {
+			return notFound
+		}
+		slog.Error("Error locking record", "table", table, "err", err)
+		return err
+	}
+	if expectedVersion != 0 && version != expectedVersion {
+		return storage.ErrVersionConflict
+	}
+	return nil
+}
+
+// isUniqueViolation reports whether err was raised by the unique constraint or index named constraint.
+func isUniqueViolation(err error, constraint string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == constraint
 }
 
 // Category returns the CategoryRepo.
@@ -87,3 +262,33 @@ func (s *Storage) Comment() storage.CommentRepo {
 func (s *Storage) PostTag() storage.PostTagRepo {
 	return s.postTagRepo
 }
+
+// Notification returns the NotificationRepo.
+func (s *Storage) Notification() storage.NotificationRepo {
+	return s.notificationRepo
+}
+
+// Outbox returns the OutboxRepo.
+func (s *Storage) Outbox() storage.OutboxRepo {
+	return s.outboxRepo
+}
+
+// Report returns the ReportRepo.
+func (s *Storage) Report() storage.ReportRepo {
+	return s.reportRepo
+}
+
+// Automod returns the AutomodRepo.
+func (s *Storage) Automod() storage.AutomodRepo {
+	return s.automodRepo
+}
+
+// RateLimit returns the RateLimitRepo.
+func (s *Storage) RateLimit() storage.RateLimitRepo {
+	return s.rateLimitRepo
+}
+
+// Audit returns the AuditRepo.
+func (s *Storage) Audit() storage.AuditRepo {
+	return s.auditRepo
+}
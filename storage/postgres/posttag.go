@@ -2,26 +2,25 @@ package postgres
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"time"
 
 	"github.com/Forum-service/Forum-Service/genproto/post"
 	"github.com/Forum-service/Forum-Service/genproto/posttag"
-	"github.com/jackc/pgx/v5"
+	"github.com/Forum-service/Forum-Service/storage"
 	"github.com/rs/zerolog/log"
 )
 
 // ErrPostTagNotFound is returned when a post_tag record is not found.
-var ErrPostTagNotFound = errors.New("post_tag record not found")
+var ErrPostTagNotFound = &storage.NotFoundError{Resource: "post tag"}
 
 // PostTagDb provides database operations for post_tags.
 type PostTagDb struct {
-	Db *pgx.Conn
+	Db DB
 }
 
 // NewPostTag creates a new instance of PostTagDb.
-func NewPostTag(db *pgx.Conn) *PostTagDb {
+func NewPostTag(db DB) *PostTagDb {
 	return &PostTagDb{Db: db}
 }
 
@@ -72,15 +71,14 @@ func (ptDb *PostTagDb) Delete(ctx context.Context, req *posttag.DeletePostTagReq
 		AND 
 			tag_id = $2
 	`
-	_, err := ptDb.Db.Exec(ctx, query, req.PostId, req.TagId)
+	result, err := ptDb.Db.Exec(ctx, query, req.PostId, req.TagId)
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			log.Error().Err(err).Msg("Post_tag association not found")
-			return nil, ErrPostTagNotFound
-		}
 		log.Error().Err(err).Msg("Error deleting post_tag association")
 		return nil, err
 	}
+	if result.RowsAffected() == 0 {
+		return nil, ErrPostTagNotFound
+	}
 	return &posttag.DeletePostTagResponse{Message: "Post_tag association deleted successfully"}, nil
 }
 
@@ -121,7 +119,7 @@ func (ptDb *PostTagDb) GetAllPostTags(ctx context.Context, req *posttag.GetAllPo
 
 	// Apply pagination
 	if req.Limit <= 0 {
-		req.Limit = 10 // Default limit
+		req.Limit = DefaultPageSize
 	}
 	if req.Page <= 0 {
 		req.Page = 1 // Default page
@@ -168,26 +166,20 @@ func (ptDb *PostTagDb) GetAllPostTags(ctx context.Context, req *posttag.GetAllPo
 func (ptDb *PostTagDb) GetPostsByTag(ctx context.Context, req *posttag.GetPostsByTagRequest) (*posttag.GetPostsByTagResponse, error) {
 	var args []interface{}
 	query := `
-        SELECT 
-            p.id,
-            p.user_id,
-            p.title,
-            p.body,
-            p.category_id,
-            p.created_at,
-            p.updated_at
+        SELECT` + postColumns + `
         FROM 
             posts p
         INNER JOIN post_tags pt ON p.id = pt.post_id
         WHERE 
             pt.tag_id = $1
         AND p.deleted_at = 0
+        AND NOT p.hidden
     `
 	args = append(args, req.TagId)
 
 	// Apply pagination
 	if req.Limit <= 0 {
-		req.Limit = 10 // Default limit
+		req.Limit = DefaultPageSize
 	}
 	if req.Page <= 0 {
 		req.Page = 1 // Default page
@@ -204,26 +196,11 @@ func (ptDb *PostTagDb) GetPostsByTag(ctx context.Context, req *posttag.GetPostsB
 
 	var posts []*post.Post
 	for rows.Next() {
-		var (
-			createdAt time.Time
-			updatedAt time.Time
-		)
-		dbPost := &post.Post{}
-		err := rows.Scan(
-			&dbPost.Id,
-			&dbPost.UserId,
-			&dbPost.Title,
-			&dbPost.Body,
-			&dbPost.CategoryId,
-			&createdAt,
-			&updatedAt,
-		)
+		dbPost, err := scanPost(rows)
 		if err != nil {
 			log.Error().Err(err).Msg("Error scanning post row")
 			return nil, err
 		}
-		dbPost.CreatedAt = createdAt.Format(time.RFC3339)
-		dbPost.UpdatedAt = updatedAt.Format(time.RFC3339)
 
 		posts = append(posts, dbPost)
 	}
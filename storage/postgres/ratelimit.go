@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/Forum-service/Forum-Service/ratelimit"
+	"github.com/rs/zerolog/log"
+)
+
+// refilledTokens is the content of an existing bucket after refilling it at
+// $3 tokens per second, capped at its capacity $2.
+const refilledTokens = `LEAST($2, b.tokens + EXTRACT(EPOCH FROM NOW() - b.updated_at) * $3)`
+
+// RateLimitDb keeps rate limiting token buckets in Postgres so every replica
+// shares them.
+type RateLimitDb struct {
+	Db DB
+
+	takes atomic.Int64
+}
+
+// NewRateLimit creates a new instance of RateLimitDb.
+func NewRateLimit(db DB) *RateLimitDb {
+	return &RateLimitDb{Db: db}
+}
+
+// Take removes a token from the bucket key in a single statement, so
+// concurrent takes on the same bucket are serialized by the row lock. Every
+// thousandth take also prunes expired buckets.
+func (rDb *RateLimitDb) Take(ctx context.Context, key string, limit ratelimit.Limit) (bool, time.Duration, error) {
+	capacity := float64(limit.Requests)
+	rate := limit.Rate()
+
+	query := `
+		INSERT INTO
+			rate_limit_buckets AS b (
+				key,
+				tokens,
+				allowed,
+				updated_at,
+				expires_at
+			)
+		VALUES (
+				$1,
+				$2 - 1,
+				TRUE,
+				NOW(),
+				NOW() + $4 * INTERVAL '1 second'
+			)
+		ON CONFLICT (key) DO UPDATE SET
+			tokens = CASE WHEN ` + refilledTokens + ` >= 1 THEN ` + refilledTokens + ` - 1 ELSE ` + refilledTokens + ` END,
+			allowed = ` + refilledTokens + ` >= 1,
+			updated_at = NOW(),
+			expires_at = NOW() + $4 * INTERVAL '1 second'
+		RETURNING
+			tokens,
+			allowed
+	`
+
+	var (
+		tokens  float64
+		allowed bool
+	)
+	if err := rDb.Db.QueryRow(ctx, query, key, capacity, rate, limit.Per.Seconds()).Scan(&tokens, &allowed); err != nil {
+		log.Error().Err(err).Msg("Error taking rate limit token")
+		return false, 0, err
+	}
+
+	if rDb.takes.Add(1)%1000 == 0 {
+		// Failing to prune only leaves behind buckets that act like missing ones
+		_, _ = rDb.Prune(ctx)
+	}
+	if allowed {
+		return true, 0, nil
+	}
+	return false, time.Duration((1 - tokens) / rate * float64(time.Second)), nil
+}
+
+// Prune deletes buckets that have had time to refill completely, which
+// behave exactly like missing ones, and returns how many it deleted.
+func (rDb *RateLimitDb) Prune(ctx context.Context) (int64, error) {
+	query := `
+		DELETE FROM
+			rate_limit_buckets
+		WHERE
+			expires_at < NOW()
+	`
+	result, err := rDb.Db.Exec(ctx, query)
+	if err != nil {
+		log.Error().Err(err).Msg("Error pruning rate limit buckets")
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,293 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Forum-service/Forum-Service/genproto/report"
+	"github.com/Forum-service/Forum-Service/storage"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog/log"
+)
+
+// ErrReportNotFound is returned when a report is not found.
+var ErrReportNotFound = &storage.NotFoundError{Resource: "report"}
+
+// reportColumns is the column list scanned by scanReport.
+const reportColumns = `
+			id,
+			target_type,
+			target_id,
+			reporter_id,
+			reason,
+			details,
+			status,
+			COALESCE(resolution, ''),
+			COALESCE(resolved_by::text, ''),
+			resolution_note,
+			created_at,
+			resolved_at`
+
+// scanReport scans a row selected with reportColumns.
+func scanReport(row pgx.Row) (*report.Report, error) {
+	var (
+		dbReport   report.Report
+		createdAt  time.Time
+		resolvedAt *time.Time
+	)
+
+	err := row.Scan(
+		&dbReport.Id,
+		&dbReport.TargetType,
+		&dbReport.TargetId,
+		&dbReport.ReporterId,
+		&dbReport.Reason,
+		&dbReport.Details,
+		&dbReport.Status,
+		&dbReport.Resolution,
+		&dbReport.ResolvedBy,
+		&dbReport.ResolutionNote,
+		&createdAt,
+		&resolvedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	dbReport.CreatedAt = createdAt.Format(time.RFC3339)
+	if resolvedAt != nil {
+		dbReport.ResolvedAt = resolvedAt.Format(time.RFC3339)
+	}
+
+	return &dbReport, nil
+}
+
+// ReportDb provides database operations for content reports.
+type ReportDb struct {
+	Db DB
+}
+
+// NewReport creates a new instance of ReportDb.
+func NewReport(db DB) *ReportDb {
+	return &ReportDb{Db: db}
+}
+
+// Create stores a new open report.
+func (rDb *ReportDb) Create(ctx context.Context, req *report.ReportContentRequest) (*report.ReportContentResponse, error) {
+	reportID := uuid.New().String()
+	query := `
+		INSERT INTO 
+			reports (
+				id,
+				target_type,
+				target_id,
+				reporter_id,
+				reason,
+				details
+			) 
+		VALUES (
+				$1, 
+				$2, 
+				$3, 
+				$4, 
+				$5, 
+				$6
+			)
+		RETURNING ` + reportColumns
+
+	dbReport, err := scanReport(rDb.Db.QueryRow(ctx, query,
+		reportID,
+		req.TargetType,
+		req.TargetId,
+		req.ReporterId,
+		req.Reason,
+		req.Details,
+	))
+	if err != nil {
+		if isUniqueViolation(err, "uq_reports_open_reporter") {
+			return nil, storage.ErrAlreadyReported
+		}
+		log.Error().Err(err).Msg("Error creating report")
+		return nil, err
+	}
+
+	return &report.ReportContentResponse{Report: dbReport}, nil
+}
+
+// GetById gets a report by its ID.
+func (rDb *ReportDb) GetById(ctx context.Context, reportID string) (*report.Report, error) {
+	query := `
+		SELECT` + reportColumns + `
+		FROM 
+			reports 
+		WHERE 
+			id = $1
+	`
+	dbReport, err := scanReport(rDb.Db.QueryRow(ctx, query, reportID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			log.Error().Err(err).Msg("Report not found")
+			return nil, ErrReportNotFound
+		}
+		log.Error().Err(err).Msg("Error getting report by ID")
+		return nil, err
+	}
+
+	return dbReport, nil
+}
+
+// GetQueue lists reported content with open reports, most reported first.
+// With a moderatorID only content in the categories that user moderates,
+// directly or through a parent category, is listed.
+func (rDb *ReportDb) GetQueue(ctx context.Context, req *report.GetModerationQueueRequest, moderatorID string) (*report.GetModerationQueueResponse, error) {
+	var (
+		args  []interface{}
+		query string
+	)
+	if moderatorID != "" {
+		args = append(args, moderatorID)
+		query = fmt.Sprintf(moderatedCategories, len(args))
+	}
+	query += `
+		SELECT
+			target_type,
+			target_id,
+			COUNT(*),
+			array_agg(DISTINCT reason),
+			array_agg(id::text ORDER BY created_at),
+			MIN(created_at),
+			MAX(created_at)
+		FROM 
+			reports
+		WHERE 
+			status = 'open'
+	`
+	if req.TargetType != "" {
+		args = append(args, req.TargetType)
+		query += fmt.Sprintf(" AND target_type = $%d ", len(args))
+	}
+	if moderatorID != "" {
+		query += `
+			AND EXISTS (
+				SELECT
+					1
+				FROM
+					posts p
+				JOIN
+					moderated m ON m.id = p.category_id
+				WHERE
+					p.id = CASE
+						WHEN reports.target_type = 'post' THEN reports.target_id
+						ELSE (SELECT c.post_id FROM comments c WHERE c.id = reports.target_id)
+					END
+			)
+		`
+	}
+	query += `
+		GROUP BY 
+			target_type,
+			target_id
+		ORDER BY 
+			COUNT(*) DESC,
+			MIN(created_at)
+	`
+
+	// Apply pagination
+	if req.Limit <= 0 {
+		req.Limit = DefaultPageSize
+	}
+	if req.Page <= 0 {
+		req.Page = 1 // Default page
+	}
+	offset := (req.Page - 1) * req.Limit
+	query += fmt.Sprintf(" OFFSET %d LIMIT %d", offset, req.Limit)
+
+	rows, err := rDb.Db.Query(ctx, query, args...)
+	if err != nil {
+		log.Error().Err(err).Msg("Error getting moderation queue")
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []*report.ModerationQueueItem
+	for rows.Next() {
+		var (
+			item            report.ModerationQueueItem
+			firstReportedAt time.Time
+			lastReportedAt  time.Time
+		)
+		err := rows.Scan(
+			&item.TargetType,
+			&item.TargetId,
+			&item.ReportCount,
+			&item.Reasons,
+			&item.ReportIds,
+			&firstReportedAt,
+			&lastReportedAt,
+		)
+		if err != nil {
+			log.Error().Err(err).Msg("Error scanning moderation queue row")
+			return nil, err
+		}
+		item.FirstReportedAt = firstReportedAt.Format(time.RFC3339)
+		item.LastReportedAt = lastReportedAt.Format(time.RFC3339)
+
+		items = append(items, &item)
+	}
+
+	if err = rows.Err(); err != nil {
+		log.Error().Err(err).Msg("Error iterating over moderation queue rows")
+		return nil, err
+	}
+
+	return &report.GetModerationQueueResponse{Items: items}, nil
+}
+
+// Resolve closes every open report on the content targeted by req.ReportId
+// with the given action and records the moderator who took it.
+func (rDb *ReportDb) Resolve(ctx context.Context, req *report.ResolveReportRequest) (*report.ResolveReportResponse, error) {
+	query := `
+		UPDATE 
+			reports 
+		SET 
+			status = 'resolved',
+			resolution = $2,
+			resolved_by = NULLIF($3, '')::uuid,
+			resolution_note = $4,
+			resolved_at = NOW()
+		WHERE 
+			status = 'open'
+		AND 
+			(target_type, target_id) = (SELECT target_type, target_id FROM reports WHERE id = $1)
+		RETURNING ` + reportColumns
+
+	rows, err := rDb.Db.Query(ctx, query, req.ReportId, req.Action, req.ModeratorId, req.Note)
+	if err != nil {
+		log.Error().Err(err).Msg("Error resolving reports")
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reports []*report.Report
+	for rows.Next() {
+		dbReport, err := scanReport(rows)
+		if err != nil {
+			log.Error().Err(err).Msg("Error scanning resolved report row")
+			return nil, err
+		}
+		reports = append(reports, dbReport)
+	}
+
+	if err = rows.Err(); err != nil {
+		log.Error().Err(err).Msg("Error iterating over resolved report rows")
+		return nil, err
+	}
+
+	if len(reports) == 0 {
+		return nil, ErrReportNotFound
+	}
+
+	return &report.ResolveReportResponse{Reports: reports}, nil
+}
@@ -2,85 +2,133 @@ package postgres
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"time"
 
 	"github.com/Forum-service/Forum-Service/genproto/tag" // Your tag proto package
+	"github.com/Forum-service/Forum-Service/storage"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/rs/zerolog/log"
 )
 
 // ErrTagNotFound is returned when a tag is not found.
-var ErrTagNotFound = errors.New("tag not found")
+var ErrTagNotFound = &storage.NotFoundError{Resource: "tag"}
+
+// tagColumns is the column list scanned by scanTag.
+const tagColumns = `
+			id,
+			name,
+			version,
+			description,
+			guidance,
+			color,
+			restricted,
+			created_at,
+			updated_at`
+
+// tagUsageColumn counts the live posts carrying the tag selected from tags.
+const tagUsageColumn = `
+			(
+				SELECT 
+					COUNT(*)
+				FROM 
+					post_tags pt
+				JOIN 
+					posts p ON p.id = pt.post_id
+				WHERE 
+					pt.tag_id = tags.id
+				AND 
+					p.deleted_at = 0
+			)`
+
+// scanTag scans a row selected with tagColumns followed by any extra columns.
+func scanTag(row pgx.Row, extra ...any) (*tag.Tag, error) {
+	var (
+		dbTag     tag.Tag
+		createdAt time.Time
+		updatedAt time.Time
+	)
+
+	dest := []any{
+		&dbTag.Id,
+		&dbTag.Name,
+		&dbTag.Version,
+		&dbTag.Description,
+		&dbTag.Guidance,
+		&dbTag.Color,
+		&dbTag.Restricted,
+		&createdAt,
+		&updatedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+
+	dbTag.CreatedAt = createdAt.Format(time.RFC3339)
+	dbTag.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+	return &dbTag, nil
+}
 
 // TagDb provides database operations for tags.
 type TagDb struct {
-	Db *pgx.Conn
+	Db DB
 }
 
 // NewTag creates a new instance of TagDb.
-func NewTag(db *pgx.Conn) *TagDb {
+func NewTag(db DB) *TagDb {
 	return &TagDb{Db: db}
 }
 
 // Create creates a new tag in the database.
 func (tDb *TagDb) Create(ctx context.Context, req *tag.CreateTagRequest) (*tag.CreateTagResponse, error) {
+	if err := checkSynonymFree(ctx, tDb.Db, req.Name, ""); err != nil {
+		return nil, err
+	}
+
 	tagID := uuid.New().String()
 	query := `
 		INSERT INTO 
 			tags (
 				id,
-				name
+				name,
+				description,
+				guidance,
+				color,
+				restricted
 			) 
 		VALUES (
 				$1, 
-				$2
+				$2,
+				$3,
+				$4,
+				$5,
+				$6
 			)
-		RETURNING 
-			id,
-			name,
-			created_at,
-			updated_at
-	`
-	var (
-		dbTag     tag.Tag
-		createdAt time.Time
-		updatedAt time.Time
-	)
-
-	err := tDb.Db.QueryRow(ctx, query, tagID, req.Name).Scan(
-		&dbTag.Id,
-		&dbTag.Name,
-		&createdAt,
-		&updatedAt,
-	)
+		RETURNING ` + tagColumns
+
+	dbTag, err := scanTag(tDb.Db.QueryRow(ctx, query,
+		tagID,
+		req.Name,
+		req.Description,
+		req.Guidance,
+		req.Color,
+		req.Restricted,
+	))
 	if err != nil {
 		log.Error().Err(err).Msg("Error creating tag")
 		return nil, err
 	}
 
-	dbTag.CreatedAt = createdAt.Format(time.RFC3339)
-	dbTag.UpdatedAt = updatedAt.Format(time.RFC3339)
-
-	return &tag.CreateTagResponse{Tag: &dbTag}, nil
+	return &tag.CreateTagResponse{Tag: dbTag}, nil
 }
 
-// GetById gets a tag by its ID.
+// GetById gets a tag by its ID. The ID of a tag that was merged into
+// another one resolves to the merge target.
 func (tDb *TagDb) GetById(ctx context.Context, req *tag.GetTagRequest) (*tag.GetTagResponse, error) {
-	var (
-		dbTag     tag.Tag
-		createdAt time.Time
-		updatedAt time.Time
-	)
-
 	query := `
-		SELECT
-			id,
-			name,
-			created_at,
-			updated_at
+		SELECT` + tagColumns + `,` + tagUsageColumn + `
 		FROM 
 			tags 
 		WHERE 
@@ -88,25 +136,17 @@ func (tDb *TagDb) GetById(ctx context.Context, req *tag.GetTagRequest) (*tag.Get
 		AND 
 			deleted_at = 0
 	`
-	err := tDb.Db.QueryRow(ctx, query, req.Id).Scan(
-		&dbTag.Id,
-		&dbTag.Name,
-		&createdAt,
-		&updatedAt,
-	)
+	var usageCount int32
+	dbTag, err := scanTag(tDb.Db.QueryRow(ctx, query, req.Id), &usageCount)
+	if err == pgx.ErrNoRows {
+		return tDb.getSynonym(ctx, "s.source_tag_id = $1", req.Id)
+	}
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			log.Error().Err(err).Msg("Tag not found")
-			return nil, ErrTagNotFound
-		}
 		log.Error().Err(err).Msg("Error getting tag by ID")
 		return nil, err
 	}
 
-	dbTag.CreatedAt = createdAt.Format(time.RFC3339)
-	dbTag.UpdatedAt = updatedAt.Format(time.RFC3339)
-
-	return &tag.GetTagResponse{Tag: &dbTag}, nil
+	return &tag.GetTagResponse{Tag: dbTag, UsageCount: usageCount}, nil
 }
 
 // Update updates an existing tag in the database.
@@ -120,44 +160,68 @@ func (tDb *TagDb) Update(ctx context.Context, req *tag.UpdateTagRequest) (*tag.U
 	filter := ``
 
 	if len(req.Name) > 0 {
+		if err := checkSynonymFree(ctx, tDb.Db, req.Name, req.Id); err != nil {
+			return nil, err
+		}
 		filter += fmt.Sprintf(" name = $%d, ", count)
 		args = append(args, req.Name)
 		count++
 	}
 
+	if len(req.Description) > 0 {
+		filter += fmt.Sprintf(" description = $%d, ", count)
+		args = append(args, req.Description)
+		count++
+	}
+
+	if len(req.Guidance) > 0 {
+		filter += fmt.Sprintf(" guidance = $%d, ", count)
+		args = append(args, req.Guidance)
+		count++
+	}
+
+	if len(req.Color) > 0 {
+		filter += fmt.Sprintf(" color = $%d, ", count)
+		args = append(args, req.Color)
+		count++
+	}
+
+	if req.Restricted != nil {
+		filter += fmt.Sprintf(" restricted = $%d, ", count)
+		args = append(args, *req.Restricted)
+		count++
+	}
+
 	if filter == "" {
 		log.Error().Msg("No fields provided for update.")
-		return nil, errors.New("no fields provided for update")
+		return nil, storage.ErrNoFieldsToUpdate
 	}
 
 	filter += fmt.Sprintf(`
+			version = version + 1,
 			updated_at = NOW()
 		WHERE
 			id = $%d
 		AND 
 			deleted_at = 0
-			`, count)
+		AND 
+			($%d = 0 OR version = $%d)
+		RETURNING `, count, count+1, count+1) + tagColumns
 
-	args = append(args, req.Id)
+	args = append(args, req.Id, req.ExpectedVersion)
 	query += filter
 
-	_, err := tDb.Db.Exec(ctx, query, args...)
+	updatedTag, err := scanTag(tDb.Db.QueryRow(ctx, query, args...))
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			log.Error().Err(err).Msg("Tag not found")
-			return nil, ErrTagNotFound
+			log.Error().Err(err).Msg("Tag not found or version mismatch")
+			return nil, missedUpdateError(ctx, tDb.Db, "tags", req.Id, req.ExpectedVersion, ErrTagNotFound)
 		}
 		log.Error().Err(err).Msg("Error updating tag")
 		return nil, err
 	}
 
-	// After successful update, get the updated tag from the database
-	updatedTag, err := tDb.GetById(ctx, &tag.GetTagRequest{Id: req.Id})
-	if err != nil {
-		return nil, err
-	}
-
-	return &tag.UpdateTagResponse{Tag: updatedTag.Tag}, nil
+	return &tag.UpdateTagResponse{Tag: updatedTag}, nil
 }
 
 // Delete soft deletes a tag by setting its deleted_at field to the current Unix timestamp.
@@ -169,16 +233,17 @@ func (tDb *TagDb) Delete(ctx context.Context, req *tag.DeleteTagRequest) (*tag.D
 			deleted_at = $1 
 		WHERE 
 			id = $2
+		AND 
+			deleted_at = 0
 	`
-	_, err := tDb.Db.Exec(ctx, query, time.Now().Unix(), req.Id)
+	result, err := tDb.Db.Exec(ctx, query, time.Now().Unix(), req.Id)
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			log.Error().Err(err).Msg("Tag not found")
-			return nil, ErrTagNotFound
-		}
 		log.Error().Err(err).Msg("Error soft deleting tag")
 		return nil, err
 	}
+	if result.RowsAffected() == 0 {
+		return nil, ErrTagNotFound
+	}
 	return &tag.DeleteTagResponse{Message: "Tag soft deleted successfully"}, nil
 }
 
@@ -186,11 +251,7 @@ func (tDb *TagDb) Delete(ctx context.Context, req *tag.DeleteTagRequest) (*tag.D
 func (tDb *TagDb) GetAllTags(ctx context.Context, req *tag.GetAllTagsRequest) (*tag.GetAllTagsResponse, error) {
 	var args []interface{}
 	query := `
-		SELECT
-			id,
-			name,
-			created_at,
-			updated_at
+		SELECT` + tagColumns + `
 		FROM 
 			tags
 		WHERE 
@@ -203,7 +264,7 @@ func (tDb *TagDb) GetAllTags(ctx context.Context, req *tag.GetAllTagsRequest) (*
 
 	// Apply pagination
 	if req.Limit <= 0 {
-		req.Limit = 10 // Default limit
+		req.Limit = DefaultPageSize
 	}
 	if req.Page <= 0 {
 		req.Page = 1 // Default page
@@ -220,24 +281,11 @@ func (tDb *TagDb) GetAllTags(ctx context.Context, req *tag.GetAllTagsRequest) (*
 
 	var tags []*tag.Tag
 	for rows.Next() {
-		var (
-			createdAt time.Time
-			updatedAt time.Time
-		)
-		dbTag := &tag.Tag{}
-		err := rows.Scan(
-			&dbTag.Id,
-			&dbTag.Name,
-			&createdAt,
-			&updatedAt,
-		)
+		dbTag, err := scanTag(rows)
 		if err != nil {
 			log.Error().Err(err).Msg("Error scanning tag row")
 			return nil, err
 		}
-		dbTag.CreatedAt = createdAt.Format(time.RFC3339)
-		dbTag.UpdatedAt = updatedAt.Format(time.RFC3339)
-
 		tags = append(tags, dbTag)
 	}
 
@@ -270,7 +318,7 @@ func (tDb *TagDb) GetFamousTags(ctx context.Context, req *tag.GetFamousTagsReq)
 	}
 	// Apply pagination
 	if req.Limit <= 0 {
-		req.Limit = 10 // Default limit
+		req.Limit = DefaultPageSize
 	}
 	if req.Page <= 0 {
 		req.Page = 1 // Default page
@@ -310,3 +358,383 @@ func (tDb *TagDb) GetFamousTags(ctx context.Context, req *tag.GetFamousTagsReq)
 
 	return &tag.GetFamousTagsRes{Tags: famousTags}, nil
 }
+
+// GetByName gets a live tag by its name, falling back to the tag the name is a synonym of.
+func (tDb *TagDb) GetByName(ctx context.Context, req *tag.GetTagByNameRequest) (*tag.GetTagResponse, error) {
+	query := `
+		SELECT` + tagColumns + `,` + tagUsageColumn + `
+		FROM 
+			tags 
+		WHERE 
+			lower(name) = lower($1)
+		AND 
+			deleted_at = 0
+		ORDER BY 
+			created_at
+		LIMIT 1
+	`
+	var usageCount int32
+	dbTag, err := scanTag(tDb.Db.QueryRow(ctx, query, req.Name), &usageCount)
+	if err == pgx.ErrNoRows {
+		return tDb.getSynonym(ctx, "s.name = lower($1)", req.Name)
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Error getting tag by name")
+		return nil, err
+	}
+
+	return &tag.GetTagResponse{Tag: dbTag, UsageCount: usageCount}, nil
+}
+
+// checkSynonymFree returns ErrTagNameTaken when name is a synonym of a tag
+// other than tagID. Live tags are looked up before synonyms, so such a name
+// would stop resolving to the tag it was merged into.
+func checkSynonymFree(ctx context.Context, db DB, name, tagID string) error {
+	query := `
+		SELECT EXISTS (
+			SELECT 
+				1 
+			FROM 
+				tag_synonyms 
+			WHERE 
+				name = lower($1)
+			AND 
+				tag_id::text <> $2
+		)
+	`
+	var taken bool
+	if err := db.QueryRow(ctx, query, name, tagID).Scan(&taken); err != nil {
+		log.Error().Err(err).Msg("Error checking tag synonyms")
+		return err
+	}
+	if taken {
+		return storage.ErrTagNameTaken
+	}
+	return nil
+}
+
+// getSynonym gets the canonical tag of the synonym matching condition, a
+// filter on tag_synonyms aliased as s with key as its only argument.
+func (tDb *TagDb) getSynonym(ctx context.Context, condition, key string) (*tag.GetTagResponse, error) {
+	query := `
+		SELECT` + tagColumns + `,` + tagUsageColumn + `
+		FROM 
+			tags 
+		WHERE 
+			id = (SELECT s.tag_id FROM tag_synonyms s WHERE ` + condition + ` LIMIT 1)
+		AND 
+			deleted_at = 0
+	`
+	var usageCount int32
+	dbTag, err := scanTag(tDb.Db.QueryRow(ctx, query, key), &usageCount)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			log.Error().Err(err).Msg("Tag not found")
+			return nil, ErrTagNotFound
+		}
+		log.Error().Err(err).Msg("Error getting tag synonym")
+		return nil, err
+	}
+
+	return &tag.GetTagResponse{Tag: dbTag, ResolvedFrom: key, UsageCount: usageCount}, nil
+}
+
+// Merge repoints the post_tags and automod rules of the source tags to the
+// target, dropping duplicate post_tags, soft-deletes the sources and
+// registers their names as synonyms of the target. All changes are made in one transaction, or a savepoint
+// when Db is already a transaction.
+func (tDb *TagDb) Merge(ctx context.Context, req *tag.MergeTagsRequest) (*tag.MergeTagsResponse, error) {
+	tx, err := tDb.Db.Begin(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Error starting tag merge")
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var liveSources int
+	var targetExists bool
+	err = tx.QueryRow(ctx, `
+		SELECT 
+			(SELECT COUNT(*) FROM tags WHERE id = ANY($1::uuid[]) AND deleted_at = 0),
+			EXISTS (SELECT 1 FROM tags WHERE id = $2 AND deleted_at = 0)
+	`, req.SourceIds, req.TargetId).Scan(&liveSources, &targetExists)
+	if err != nil {
+		log.Error().Err(err).Msg("Error checking tags to merge")
+		return nil, err
+	}
+	if liveSources != len(req.SourceIds) || !targetExists {
+		return nil, ErrTagNotFound
+	}
+
+	// Attach the target to every post carrying a source tag that lacks it
+	inserted, err := tx.Exec(ctx, `
+		INSERT INTO 
+			post_tags (
+				post_id,
+				tag_id,
+				created_at
+			)
+		SELECT 
+			post_id,
+			$1,
+			MIN(created_at)
+		FROM 
+			post_tags
+		WHERE 
+			tag_id = ANY($2::uuid[])
+		AND 
+			post_id NOT IN (SELECT post_id FROM post_tags WHERE tag_id = $1)
+		GROUP BY 
+			post_id
+	`, req.TargetId, req.SourceIds)
+	if err != nil {
+		log.Error().Err(err).Msg("Error retagging posts with merge target")
+		return nil, err
+	}
+	retaggedPosts := inserted.RowsAffected()
+
+	statements := []struct {
+		query string
+		msg   string
+	}{
+		{`
+		DELETE FROM 
+			post_tags 
+		WHERE 
+			tag_id = ANY($2::uuid[])
+		`, "Error removing merged tags from posts"},
+		{`
+		UPDATE 
+			tag_synonyms 
+		SET 
+			tag_id = $1
+		WHERE 
+			tag_id = ANY($2::uuid[])
+		`, "Error repointing existing tag synonyms"},
+		{`
+		UPDATE 
+			automod_rules 
+		SET 
+			tag_id = $1,
+			updated_at = NOW()
+		WHERE 
+			tag_id = ANY($2::uuid[])
+		`, "Error repointing automod rules to the merge target"},
+		{`
+		INSERT INTO 
+			tag_synonyms (
+				name,
+				tag_id,
+				source_tag_id
+			)
+		SELECT 
+			lower(source.name),
+			$1,
+			source.id
+		FROM 
+			tags source
+		JOIN 
+			tags target ON target.id = $1
+		WHERE 
+			source.id = ANY($2::uuid[])
+		AND 
+			lower(source.name) <> lower(target.name)
+		ON CONFLICT (name) DO UPDATE SET 
+			tag_id = EXCLUDED.tag_id,
+			source_tag_id = EXCLUDED.source_tag_id
+		`, "Error adding tag synonyms"},
+	}
+	for _, stmt := range statements {
+		if _, err := tx.Exec(ctx, stmt.query, req.TargetId, req.SourceIds); err != nil {
+			log.Error().Err(err).Msg(stmt.msg)
+			return nil, err
+		}
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE 
+			tags 
+		SET 
+			deleted_at = $1
+		WHERE 
+			id = ANY($2::uuid[])
+	`, time.Now().Unix(), req.SourceIds)
+	if err != nil {
+		log.Error().Err(err).Msg("Error deleting merged tags")
+		return nil, err
+	}
+
+	target, err := NewTag(tx).GetById(ctx, &tag.GetTagRequest{Id: req.TargetId})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Error().Err(err).Msg("Error committing tag merge")
+		return nil, err
+	}
+
+	return &tag.MergeTagsResponse{Target: target.Tag, RetaggedPosts: int32(retaggedPosts)}, nil
+}
+
+// ListSynonyms lists tag synonyms with optional filtering by canonical tag and pagination.
+func (tDb *TagDb) ListSynonyms(ctx context.Context, req *tag.ListTagSynonymsRequest) (*tag.ListTagSynonymsResponse, error) {
+	var args []interface{}
+	query := `
+		SELECT
+			s.name,
+			s.tag_id,
+			t.name,
+			COALESCE(s.source_tag_id::text, ''),
+			s.created_at
+		FROM 
+			tag_synonyms s
+		JOIN 
+			tags t ON t.id = s.tag_id
+	`
+	if req.TagId != "" {
+		query += " WHERE s.tag_id = $1 "
+		args = append(args, req.TagId)
+	}
+	query += " ORDER BY s.name "
+
+	// Apply pagination
+	if req.Limit <= 0 {
+		req.Limit = DefaultPageSize
+	}
+	if req.Page <= 0 {
+		req.Page = 1 // Default page
+	}
+	offset := (req.Page - 1) * req.Limit
+	query += fmt.Sprintf(" OFFSET %d LIMIT %d", offset, req.Limit)
+
+	rows, err := tDb.Db.Query(ctx, query, args...)
+	if err != nil {
+		log.Error().Err(err).Msg("Error listing tag synonyms")
+		return nil, err
+	}
+	defer rows.Close()
+
+	var synonyms []*tag.TagSynonym
+	for rows.Next() {
+		var (
+			synonym   tag.TagSynonym
+			createdAt time.Time
+		)
+		err := rows.Scan(
+			&synonym.Name,
+			&synonym.TagId,
+			&synonym.TagName,
+			&synonym.SourceTagId,
+			&createdAt,
+		)
+		if err != nil {
+			log.Error().Err(err).Msg("Error scanning tag synonym row")
+			return nil, err
+		}
+		synonym.CreatedAt = createdAt.Format(time.RFC3339)
+
+		synonyms = append(synonyms, &synonym)
+	}
+
+	if err = rows.Err(); err != nil {
+		log.Error().Err(err).Msg("Error iterating over tag synonym rows")
+		return nil, err
+	}
+
+	return &tag.ListTagSynonymsResponse{Synonyms: synonyms}, nil
+}
+
+// GetRelated returns the tags that share the most live posts with the
+// requested tag, together with their Jaccard affinity.
+func (tDb *TagDb) GetRelated(ctx context.Context, req *tag.GetRelatedTagsRequest) (*tag.GetRelatedTagsResponse, error) {
+	if req.Limit <= 0 {
+		req.Limit = DefaultPageSize
+	}
+
+	query := `
+		WITH tagged_posts AS (
+			SELECT 
+				pt.post_id,
+				pt.tag_id
+			FROM 
+				post_tags pt
+			JOIN 
+				posts p ON p.id = pt.post_id
+			WHERE 
+				p.deleted_at = 0
+		),
+		base AS (
+			SELECT 
+				DISTINCT post_id
+			FROM 
+				tagged_posts
+			WHERE 
+				tag_id = $1
+		),
+		co AS (
+			SELECT 
+				tp.tag_id AS related_id,
+				COUNT(DISTINCT tp.post_id) AS co_occurrences
+			FROM 
+				tagged_posts tp
+			JOIN 
+				base b ON b.post_id = tp.post_id
+			WHERE 
+				tp.tag_id <> $1
+			GROUP BY 
+				tp.tag_id
+		),
+		related_usage AS (
+			SELECT 
+				tag_id AS usage_id,
+				COUNT(DISTINCT post_id) AS usage_count
+			FROM 
+				tagged_posts
+			WHERE 
+				tag_id IN (SELECT related_id FROM co)
+			GROUP BY 
+				tag_id
+		)
+		SELECT` + tagColumns + `,
+			co.co_occurrences,
+			co.co_occurrences::float8 / ((SELECT COUNT(*) FROM base) + u.usage_count - co.co_occurrences) AS affinity
+		FROM 
+			co
+		JOIN 
+			tags ON tags.id = co.related_id
+		JOIN 
+			related_usage u ON u.usage_id = co.related_id
+		WHERE 
+			deleted_at = 0
+		ORDER BY 
+			co.co_occurrences DESC,
+			affinity DESC,
+			name
+		LIMIT $2
+	`
+	rows, err := tDb.Db.Query(ctx, query, req.TagId, req.Limit)
+	if err != nil {
+		log.Error().Err(err).Msg("Error getting related tags")
+		return nil, err
+	}
+	defer rows.Close()
+
+	var related []*tag.RelatedTag
+	for rows.Next() {
+		r := &tag.RelatedTag{}
+		r.Tag, err = scanTag(rows, &r.CoOccurrences, &r.Affinity)
+		if err != nil {
+			log.Error().Err(err).Msg("Error scanning related tag row")
+			return nil, err
+		}
+		related = append(related, r)
+	}
+
+	if err = rows.Err(); err != nil {
+		log.Error().Err(err).Msg("Error iterating over related tag rows")
+		return nil, err
+	}
+
+	return &tag.GetRelatedTagsResponse{Tags: related}, nil
+}
@@ -2,14 +2,63 @@ package storage
 
 import (
 	"context"
+	"errors"
+	"time"
 
+	"github.com/Forum-service/Forum-Service/audit"
+	"github.com/Forum-service/Forum-Service/events"
+	"github.com/Forum-service/Forum-Service/genproto/automod"
 	"github.com/Forum-service/Forum-Service/genproto/category"
 	"github.com/Forum-service/Forum-Service/genproto/comment"
+	"github.com/Forum-service/Forum-Service/genproto/notification"
 	"github.com/Forum-service/Forum-Service/genproto/post"
 	"github.com/Forum-service/Forum-Service/genproto/posttag"
+	"github.com/Forum-service/Forum-Service/genproto/report"
 	"github.com/Forum-service/Forum-Service/genproto/tag"
+	"github.com/Forum-service/Forum-Service/ratelimit"
 )
 
+// ErrNotFound is matched by every error reporting a missing record, so
+// callers can detect them with errors.Is whatever the record.
+var ErrNotFound = errors.New("not found")
+
+// NotFoundError is returned when the requested Resource does not exist.
+type NotFoundError struct {
+	Resource string // Kind of record, such as post or category
+}
+
+func (e *NotFoundError) Error() string {
+	return e.Resource + " not found"
+}
+
+// Is makes every NotFoundError match ErrNotFound.
+func (e *NotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// ErrNoFieldsToUpdate is returned by updates that would change nothing.
+var ErrNoFieldsToUpdate = errors.New("no fields provided for update")
+
+// ErrVersionConflict is returned by updates whose expected version no longer
+// matches the stored row.
+var ErrVersionConflict = errors.New("version conflict: the record was modified by someone else")
+
+// ErrCategoryCycle is returned when moving a category below itself or one of its descendants.
+var ErrCategoryCycle = errors.New("a category cannot be moved below itself or one of its sub-categories")
+
+// ErrCategorySlugTaken is returned when another live category already uses the slug.
+var ErrCategorySlugTaken = errors.New("category slug is already taken")
+
+// ErrTagNameTaken is returned when a tag would take the name of a merged tag,
+// which already resolves to the tag it was merged into.
+var ErrTagNameTaken = errors.New("tag name is a synonym of another tag")
+
+// ErrAlreadyReported is returned when a user reports content they already have an open report on.
+var ErrAlreadyReported = errors.New("content already reported by this user")
+
+// ErrAlreadyModerator is returned when assigning a user who already moderates the category.
+var ErrAlreadyModerator = errors.New("user already moderates this category")
+
 // StorageI defines the interface for interacting with the forum service storage.
 type StorageI interface {
 	Category() CategoryRepo
@@ -17,15 +66,39 @@ type StorageI interface {
 	Post() PostRepo
 	Comment() CommentRepo
 	PostTag() PostTagRepo
+	Notification() NotificationRepo
+	Outbox() OutboxRepo
+	Report() ReportRepo
+	Automod() AutomodRepo
+	RateLimit() RateLimitRepo
+	Audit() AuditRepo
+
+	// Tx runs fn against a storage bound to a single transaction. The
+	// transaction is committed if fn returns nil and rolled back otherwise.
+	Tx(ctx context.Context, fn func(tx StorageI) error) error
+
+	// Listen delivers the payload of every notification sent on channel to
+	// handle until ctx is cancelled or the connection fails.
+	Listen(ctx context.Context, channel string, handle func(payload string)) error
 }
 
 // CategoryRepo defines methods for managing categories.
 type CategoryRepo interface {
 	Create(ctx context.Context, req *category.CreateCategoryRequest) (*category.CreateCategoryResponse, error)
 	GetById(ctx context.Context, req *category.GetCategoryRequest) (*category.GetCategoryResponse, error)
+	GetBySlug(ctx context.Context, req *category.GetCategoryBySlugRequest) (*category.GetCategoryResponse, error)
 	Update(ctx context.Context, req *category.UpdateCategoryRequest) (*category.UpdateCategoryResponse, error)
 	Delete(ctx context.Context, req *category.DeleteCategoryRequest) (*category.DeleteCategoryResponse, error)
 	GetAllCategories(ctx context.Context, req *category.GetAllCategoriesRequest) (*category.GetAllCategoriesResponse, error)
+	Reorder(ctx context.Context, req *category.ReorderCategoriesRequest) (*category.ReorderCategoriesResponse, error)
+	Merge(ctx context.Context, req *category.MergeCategoriesRequest) (*category.MergeCategoriesResponse, error)
+	GetTree(ctx context.Context, req *category.GetCategoryTreeRequest) (*category.GetCategoryTreeResponse, error)
+	GetBreadcrumb(ctx context.Context, req *category.GetCategoryBreadcrumbRequest) (*category.GetCategoryBreadcrumbResponse, error)
+	GetStats(ctx context.Context, req *category.GetCategoriesStatsRequest) (*category.GetCategoriesStatsResponse, error)
+	AddModerator(ctx context.Context, req *category.AddCategoryModeratorRequest) (*category.AddCategoryModeratorResponse, error)
+	RemoveModerator(ctx context.Context, req *category.RemoveCategoryModeratorRequest) (*category.RemoveCategoryModeratorResponse, error)
+	GetModerators(ctx context.Context, req *category.ListCategoryModeratorsRequest) (*category.ListCategoryModeratorsResponse, error)
+	IsModerator(ctx context.Context, categoryID, userID string) (bool, error)
 }
 
 // TagRepo defines methods for managing tags.
@@ -36,6 +109,10 @@ type TagRepo interface {
 	Delete(ctx context.Context, req *tag.DeleteTagRequest) (*tag.DeleteTagResponse, error)
 	GetAllTags(ctx context.Context, req *tag.GetAllTagsRequest) (*tag.GetAllTagsResponse, error)
 	GetFamousTags(ctx context.Context, req *tag.GetFamousTagsReq) (*tag.GetFamousTagsRes, error)
+	GetByName(ctx context.Context, req *tag.GetTagByNameRequest) (*tag.GetTagResponse, error)
+	Merge(ctx context.Context, req *tag.MergeTagsRequest) (*tag.MergeTagsResponse, error)
+	ListSynonyms(ctx context.Context, req *tag.ListTagSynonymsRequest) (*tag.ListTagSynonymsResponse, error)
+	GetRelated(ctx context.Context, req *tag.GetRelatedTagsRequest) (*tag.GetRelatedTagsResponse, error)
 }
 
 // PostRepo defines methods for managing posts.
@@ -45,6 +122,12 @@ type PostRepo interface {
 	Update(ctx context.Context, req *post.UpdatePostRequest) (*post.UpdatePostResponse, error)
 	Delete(ctx context.Context, req *post.DeletePostRequest) (*post.DeletePostResponse, error)
 	GetAllPosts(ctx context.Context, req *post.GetAllPostsRequest) (*post.GetAllPostsResponse, error)
+	GetRevisions(ctx context.Context, req *post.GetPostRevisionsRequest) (*post.GetPostRevisionsResponse, error)
+	GetRevision(ctx context.Context, postID string, revision int32) (*post.PostRevision, error)
+	SetHidden(ctx context.Context, postID string, hidden bool) error
+	Pin(ctx context.Context, req *post.PinPostRequest) (*post.ModeratePostResponse, error)
+	Lock(ctx context.Context, req *post.LockPostRequest) (*post.ModeratePostResponse, error)
+	Close(ctx context.Context, req *post.ClosePostRequest) (*post.ModeratePostResponse, error)
 }
 
 // CommentRepo defines methods for managing comments.
@@ -54,6 +137,10 @@ type CommentRepo interface {
 	Update(ctx context.Context, req *comment.UpdateCommentRequest) (*comment.UpdateCommentResponse, error)
 	Delete(ctx context.Context, req *comment.DeleteCommentRequest) (*comment.DeleteCommentResponse, error)
 	GetAllComments(ctx context.Context, req *comment.GetAllCommentsRequest) (*comment.GetAllCommentsResponse, error)
+	GetChanges(ctx context.Context, postID string, since time.Time) ([]*comment.CommentEvent, error)
+	GetRevisions(ctx context.Context, req *comment.GetCommentRevisionsRequest) (*comment.GetCommentRevisionsResponse, error)
+	SetHidden(ctx context.Context, commentID string, hidden bool) error
+	CreatedAt(ctx context.Context, commentID string) (time.Time, error)
 }
 
 // PostTagRepo defines methods for managing post-tag associations.
@@ -63,3 +150,54 @@ type PostTagRepo interface {
 	GetAllPostTags(ctx context.Context, req *posttag.GetAllPostTagsRequest) (*posttag.GetAllPostTagsResponse, error)
 	GetPostsByTag(ctx context.Context, req *posttag.GetPostsByTagRequest) (*posttag.GetPostsByTagResponse, error)
 }
+
+// NotificationRepo defines methods for managing notifications, preferences and category follows.
+type NotificationRepo interface {
+	Notify(ctx context.Context, n *notification.Notification) error
+	GetAllNotifications(ctx context.Context, req *notification.ListNotificationsRequest) (*notification.ListNotificationsResponse, error)
+	MarkRead(ctx context.Context, req *notification.MarkNotificationsReadRequest) (*notification.MarkNotificationsReadResponse, error)
+	GetUnreadCount(ctx context.Context, req *notification.GetUnreadCountRequest) (*notification.GetUnreadCountResponse, error)
+	GetPreferences(ctx context.Context, req *notification.GetNotificationPreferencesRequest) (*notification.GetNotificationPreferencesResponse, error)
+	UpdatePreferences(ctx context.Context, req *notification.UpdateNotificationPreferencesRequest) (*notification.UpdateNotificationPreferencesResponse, error)
+	FollowCategory(ctx context.Context, req *notification.FollowCategoryRequest) (*notification.FollowCategoryResponse, error)
+	UnfollowCategory(ctx context.Context, req *notification.UnfollowCategoryRequest) (*notification.UnfollowCategoryResponse, error)
+	GetCategoryFollowers(ctx context.Context, categoryID string) ([]string, error)
+}
+
+// ReportRepo defines methods for managing content reports.
+type ReportRepo interface {
+	Create(ctx context.Context, req *report.ReportContentRequest) (*report.ReportContentResponse, error)
+	GetById(ctx context.Context, reportID string) (*report.Report, error)
+	GetQueue(ctx context.Context, req *report.GetModerationQueueRequest, moderatorID string) (*report.GetModerationQueueResponse, error)
+	Resolve(ctx context.Context, req *report.ResolveReportRequest) (*report.ResolveReportResponse, error)
+}
+
+// AutomodRepo defines methods for managing automod rules.
+type AutomodRepo interface {
+	Create(ctx context.Context, req *automod.CreateAutomodRuleRequest) (*automod.CreateAutomodRuleResponse, error)
+	GetById(ctx context.Context, req *automod.GetAutomodRuleRequest) (*automod.GetAutomodRuleResponse, error)
+	Update(ctx context.Context, req *automod.UpdateAutomodRuleRequest) (*automod.UpdateAutomodRuleResponse, error)
+	Delete(ctx context.Context, req *automod.DeleteAutomodRuleRequest) (*automod.DeleteAutomodRuleResponse, error)
+	GetAllRules(ctx context.Context, req *automod.ListAutomodRulesRequest) (*automod.ListAutomodRulesResponse, error)
+	GetActive(ctx context.Context, categoryID string) ([]*automod.AutomodRule, error)
+	RecordHits(ctx context.Context, ruleIDs []string) error
+}
+
+// OutboxRepo defines methods for the transactional event outbox.
+// Add is meant to be called inside Tx together with the change it describes.
+type OutboxRepo interface {
+	Add(ctx context.Context, e *events.Event) error
+	events.Store
+}
+
+// RateLimitRepo keeps rate limiting token buckets shared by all replicas.
+// Prune drops buckets that have had time to refill completely.
+type RateLimitRepo interface {
+	ratelimit.Store
+	Prune(ctx context.Context) (int64, error)
+}
+
+// AuditRepo keeps the audit log of denied calls.
+type AuditRepo interface {
+	audit.Log
+}
@@ -0,0 +1,66 @@
+package test
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"testing"
+
+	"github.com/Forum-service/Forum-Service/audit"
+	"github.com/Forum-service/Forum-Service/config"
+	"github.com/Forum-service/Forum-Service/middleware"
+	"github.com/Forum-service/Forum-Service/storage/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestAudit(t *testing.T) *postgres.AuditDb {
+	cfg, err := config.Load(flag.NewFlagSet("test", flag.ContinueOnError), nil)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	connString := fmt.Sprintf("postgresql://%s:%s@%s:%d/%s",
+		cfg.PostgresUser,
+		cfg.PostgresPassword,
+		"localhost",
+		5432,
+		cfg.PostgresDatabase,
+	)
+
+	db, err := pgx.Connect(context.Background(), connString)
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	return &postgres.AuditDb{Db: db}
+}
+
+func TestAuditRecord(t *testing.T) {
+	aDb := newTestAudit(t)
+	userID := uuid.New().String()
+
+	err := aDb.Record(context.Background(), &audit.Entry{
+		UserID:   userID,
+		Roles:    []string{middleware.RoleMember},
+		Method:   "/forum.CategoryService/CreateCategory",
+		Required: middleware.RoleAdmin,
+	})
+	assert.NoError(t, err)
+
+	// Unauthenticated callers have no user or roles
+	err = aDb.Record(context.Background(), &audit.Entry{
+		Method:   "/forum.TagService/CreateTag",
+		Required: middleware.RoleModerator,
+	})
+	assert.NoError(t, err)
+
+	var (
+		method string
+		roles  []string
+	)
+	err = aDb.Db.QueryRow(context.Background(), `SELECT method, roles FROM audit_log WHERE user_id = $1`, userID).Scan(&method, &roles)
+	assert.NoError(t, err)
+	assert.Equal(t, "/forum.CategoryService/CreateCategory", method)
+	assert.Equal(t, []string{middleware.RoleMember}, roles)
+}
@@ -0,0 +1,100 @@
+package test
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"testing"
+
+	"github.com/Forum-service/Forum-Service/config"
+	"github.com/Forum-service/Forum-Service/genproto/automod"
+	"github.com/Forum-service/Forum-Service/storage/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestAutomod(t *testing.T) *postgres.AutomodDb {
+	cfg, err := config.Load(flag.NewFlagSet("test", flag.ContinueOnError), nil)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	connString := fmt.Sprintf("postgresql://%s:%s@%s:%d/%s",
+		cfg.PostgresUser,
+		cfg.PostgresPassword,
+		"localhost",
+		5432,
+		cfg.PostgresDatabase,
+	)
+
+	db, err := pgx.Connect(context.Background(), connString)
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	return &postgres.AutomodDb{Db: db}
+}
+
+func TestAutomodRules(t *testing.T) {
+	aDb := newTestAutomod(t)
+	testCategoryID := "a5a171a3-e5dd-491e-94a1-94eefc9fb320"
+
+	created, err := aDb.Create(context.Background(), &automod.CreateAutomodRuleRequest{
+		Name:       "No crypto giveaways",
+		Pattern:    `(?i)free\s+bitcoin`,
+		CategoryId: testCategoryID,
+		Action:     "hold",
+		Enabled:    true,
+	})
+	if err != nil {
+		t.Fatalf("Error creating automod rule: %v", err)
+	}
+	assert.Equal(t, "any", created.Rule.Field)
+	assert.Equal(t, testCategoryID, created.Rule.CategoryId)
+	assert.Zero(t, created.Rule.HitCount)
+
+	active, err := aDb.GetActive(context.Background(), testCategoryID)
+	if err != nil {
+		t.Fatalf("Error getting active automod rules: %v", err)
+	}
+	assert.Contains(t, ruleIDs(active), created.Rule.Id)
+
+	err = aDb.RecordHits(context.Background(), []string{created.Rule.Id})
+	if err != nil {
+		t.Fatalf("Error recording automod rule hits: %v", err)
+	}
+	got, err := aDb.GetById(context.Background(), &automod.GetAutomodRuleRequest{Id: created.Rule.Id})
+	if err != nil {
+		t.Fatalf("Error getting automod rule: %v", err)
+	}
+	assert.Equal(t, int32(1), got.Rule.HitCount)
+	assert.NotEmpty(t, got.Rule.LastHitAt)
+
+	t.Run("Disabled rules are not active", func(t *testing.T) {
+		enabled := false
+		_, err := aDb.Update(context.Background(), &automod.UpdateAutomodRuleRequest{Id: created.Rule.Id, Enabled: &enabled})
+		if err != nil {
+			t.Fatalf("Error updating automod rule: %v", err)
+		}
+
+		active, err := aDb.GetActive(context.Background(), testCategoryID)
+		if err != nil {
+			t.Fatalf("Error getting active automod rules: %v", err)
+		}
+		assert.NotContains(t, ruleIDs(active), created.Rule.Id)
+	})
+
+	_, err = aDb.Delete(context.Background(), &automod.DeleteAutomodRuleRequest{Id: created.Rule.Id})
+	if err != nil {
+		t.Fatalf("Error deleting automod rule: %v", err)
+	}
+	_, err = aDb.Delete(context.Background(), &automod.DeleteAutomodRuleRequest{Id: created.Rule.Id})
+	assert.ErrorIs(t, err, postgres.ErrAutomodRuleNotFound)
+}
+
+func ruleIDs(rules []*automod.AutomodRule) []string {
+	ids := make([]string, len(rules))
+	for i, rule := range rules {
+		ids[i] = rule.Id
+	}
+	return ids
+}
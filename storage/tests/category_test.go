@@ -2,18 +2,24 @@ package test
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"testing"
 
 	"github.com/Forum-service/Forum-Service/config"
 	"github.com/Forum-service/Forum-Service/genproto/category"
+	"github.com/Forum-service/Forum-Service/storage"
 	"github.com/Forum-service/Forum-Service/storage/postgres"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/stretchr/testify/assert"
 )
 
 func newTestCategory(t *testing.T) *postgres.CategoryDb {
-	cfg := config.Load()
+	cfg, err := config.Load(flag.NewFlagSet("test", flag.ContinueOnError), nil)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
 	connString := fmt.Sprintf("postgresql://%s:%s@%s:%d/%s",
 		cfg.PostgresUser,
 		cfg.PostgresPassword,
@@ -130,3 +136,220 @@ func TestGetAllCategories(t *testing.T) {
 		assert.GreaterOrEqual(t, len(resp.Categories), len(testCategories))
 	})
 }
+
+func TestUpdateCategoryVersionConflict(t *testing.T) {
+	cDb := newTestCategory(t)
+	createdCategory := createTestCategory(t, cDb)
+
+	updated, err := cDb.Update(context.Background(), &category.UpdateCategoryRequest{
+		Id:              createdCategory.Id,
+		Name:            "First editor",
+		ExpectedVersion: createdCategory.Version,
+	})
+	if err != nil {
+		t.Fatalf("Error updating category: %v", err)
+	}
+	assert.Equal(t, createdCategory.Version+1, updated.Category.Version)
+
+	_, err = cDb.Update(context.Background(), &category.UpdateCategoryRequest{
+		Id:              createdCategory.Id,
+		Name:            "Second editor",
+		ExpectedVersion: createdCategory.Version,
+	})
+	assert.ErrorIs(t, err, storage.ErrVersionConflict)
+}
+
+func TestCategoryHierarchy(t *testing.T) {
+	cDb := newTestCategory(t)
+	root := createTestCategory(t, cDb)
+
+	child, err := cDb.Create(context.Background(), &category.CreateCategoryRequest{Name: "Child Category", ParentId: root.Id})
+	if err != nil {
+		t.Fatalf("Error creating child category: %v", err)
+	}
+	assert.Equal(t, root.Id, child.Category.ParentId)
+
+	t.Run("Breadcrumb starts at the top level", func(t *testing.T) {
+		resp, err := cDb.GetBreadcrumb(context.Background(), &category.GetCategoryBreadcrumbRequest{Id: child.Category.Id})
+		if err != nil {
+			t.Fatalf("Error getting breadcrumb: %v", err)
+		}
+		if assert.Len(t, resp.Categories, 2) {
+			assert.Equal(t, root.Id, resp.Categories[0].Id)
+			assert.Equal(t, child.Category.Id, resp.Categories[1].Id)
+		}
+	})
+
+	t.Run("Subtree contains the child", func(t *testing.T) {
+		resp, err := cDb.GetTree(context.Background(), &category.GetCategoryTreeRequest{RootId: root.Id})
+		if err != nil {
+			t.Fatalf("Error getting category tree: %v", err)
+		}
+		if assert.Len(t, resp.Roots, 1) && assert.Len(t, resp.Roots[0].Children, 1) {
+			assert.Equal(t, child.Category.Id, resp.Roots[0].Children[0].Category.Id)
+		}
+	})
+
+	t.Run("Moving a category below its child is rejected", func(t *testing.T) {
+		_, err := cDb.Update(context.Background(), &category.UpdateCategoryRequest{Id: root.Id, ParentId: child.Category.Id})
+		assert.ErrorIs(t, err, storage.ErrCategoryCycle)
+	})
+}
+
+func TestCategoryMetadataAndOrder(t *testing.T) {
+	cDb := newTestCategory(t)
+	slug := "test-" + uuid.New().String()
+
+	first, err := cDb.Create(context.Background(), &category.CreateCategoryRequest{
+		Name:        "Announcements",
+		Slug:        slug,
+		Description: "News from the team",
+		Icon:        "megaphone",
+		Color:       "#1e90ff",
+	})
+	if err != nil {
+		t.Fatalf("Error creating category: %v", err)
+	}
+	second := createTestCategory(t, cDb)
+
+	t.Run("Get by slug", func(t *testing.T) {
+		resp, err := cDb.GetBySlug(context.Background(), &category.GetCategoryBySlugRequest{Slug: slug})
+		if err != nil {
+			t.Fatalf("Error getting category by slug: %v", err)
+		}
+		assert.Equal(t, first.Category.Id, resp.Category.Id)
+		assert.Equal(t, "#1e90ff", resp.Category.Color)
+	})
+
+	t.Run("Duplicate slug is rejected", func(t *testing.T) {
+		_, err := cDb.Create(context.Background(), &category.CreateCategoryRequest{Name: "Copy", Slug: slug})
+		assert.ErrorIs(t, err, storage.ErrCategorySlugTaken)
+	})
+
+	t.Run("Reorder", func(t *testing.T) {
+		resp, err := cDb.Reorder(context.Background(), &category.ReorderCategoriesRequest{
+			Ids: []string{second.Id, first.Category.Id},
+		})
+		if err != nil {
+			t.Fatalf("Error reordering categories: %v", err)
+		}
+		if assert.Len(t, resp.Categories, 2) {
+			assert.Equal(t, second.Id, resp.Categories[0].Id)
+			assert.Equal(t, int32(1), resp.Categories[0].Position)
+			assert.Equal(t, int32(2), resp.Categories[1].Position)
+		}
+	})
+}
+
+func TestGetCategoryStats(t *testing.T) {
+	cDb := newTestCategory(t)
+	emptyCategory := createTestCategory(t, cDb)
+
+	resp, err := cDb.GetStats(context.Background(), &category.GetCategoriesStatsRequest{
+		CategoryIds: []string{emptyCategory.Id},
+	})
+	if err != nil {
+		t.Fatalf("Error getting category stats: %v", err)
+	}
+	if assert.Len(t, resp.Stats, 1) {
+		stats := resp.Stats[0]
+		assert.Equal(t, emptyCategory.Id, stats.CategoryId)
+		assert.Zero(t, stats.PostCount)
+		assert.Zero(t, stats.CommentCount)
+		assert.Empty(t, stats.LastPostAt)
+		assert.Empty(t, stats.TopTags)
+	}
+
+	t.Run("All categories", func(t *testing.T) {
+		resp, err := cDb.GetStats(context.Background(), &category.GetCategoriesStatsRequest{})
+		if err != nil {
+			t.Fatalf("Error getting stats for all categories: %v", err)
+		}
+		assert.NotEmpty(t, resp.Stats)
+	})
+}
+
+func TestMergeCategories(t *testing.T) {
+	cDb := newTestCategory(t)
+	source := createTestCategory(t, cDb)
+	target := createTestCategory(t, cDb)
+
+	resp, err := cDb.Merge(context.Background(), &category.MergeCategoriesRequest{
+		SourceIds: []string{source.Id},
+		TargetId:  target.Id,
+	})
+	if err != nil {
+		t.Fatalf("Error merging categories: %v", err)
+	}
+	assert.Equal(t, target.Id, resp.Target.Id)
+	assert.Zero(t, resp.MovedPosts)
+
+	// The merged category's ID now resolves to the target
+	getResp, err := cDb.GetById(context.Background(), &category.GetCategoryRequest{Id: source.Id})
+	if err != nil {
+		t.Fatalf("Error getting merged category: %v", err)
+	}
+	assert.Equal(t, target.Id, getResp.Category.Id)
+	assert.Equal(t, source.Id, getResp.RedirectedFrom)
+}
+
+func TestCategoryModerators(t *testing.T) {
+	cDb := newTestCategory(t)
+	root := createTestCategory(t, cDb)
+
+	child, err := cDb.Create(context.Background(), &category.CreateCategoryRequest{Name: "Child Category", ParentId: root.Id})
+	if err != nil {
+		t.Fatalf("Error creating child category: %v", err)
+	}
+	rootModerator := uuid.New().String()
+	childModerator := uuid.New().String()
+
+	for _, m := range []*category.AddCategoryModeratorRequest{
+		{CategoryId: root.Id, UserId: rootModerator},
+		{CategoryId: child.Category.Id, UserId: childModerator},
+	} {
+		added, err := cDb.AddModerator(context.Background(), m)
+		if err != nil {
+			t.Fatalf("Error adding category moderator: %v", err)
+		}
+		assert.Equal(t, m.UserId, added.Moderator.UserId)
+	}
+
+	t.Run("Adding a moderator twice is rejected", func(t *testing.T) {
+		_, err := cDb.AddModerator(context.Background(), &category.AddCategoryModeratorRequest{CategoryId: root.Id, UserId: rootModerator})
+		assert.ErrorIs(t, err, storage.ErrAlreadyModerator)
+	})
+
+	t.Run("Moderators are inherited by sub-categories", func(t *testing.T) {
+		moderates, err := cDb.IsModerator(context.Background(), child.Category.Id, rootModerator)
+		assert.NoError(t, err)
+		assert.True(t, moderates)
+
+		moderates, err = cDb.IsModerator(context.Background(), root.Id, childModerator)
+		assert.NoError(t, err)
+		assert.False(t, moderates)
+
+		list, err := cDb.GetModerators(context.Background(), &category.ListCategoryModeratorsRequest{CategoryId: child.Category.Id})
+		assert.NoError(t, err)
+		if assert.Len(t, list.Moderators, 2) {
+			assert.Equal(t, childModerator, list.Moderators[0].UserId)
+			assert.Equal(t, root.Id, list.Moderators[1].CategoryId)
+		}
+
+		list, err = cDb.GetModerators(context.Background(), &category.ListCategoryModeratorsRequest{CategoryId: child.Category.Id, DirectOnly: true})
+		assert.NoError(t, err)
+		assert.Len(t, list.Moderators, 1)
+	})
+
+	t.Run("Removing a moderator", func(t *testing.T) {
+		_, err := cDb.RemoveModerator(context.Background(), &category.RemoveCategoryModeratorRequest{CategoryId: root.Id, UserId: rootModerator})
+		assert.NoError(t, err)
+
+		moderates, err := cDb.IsModerator(context.Background(), child.Category.Id, rootModerator)
+		assert.NoError(t, err)
+		assert.False(t, moderates)
+
+		_, err = cDb.RemoveModerator(context.Background(), &category.RemoveCategoryModeratorRequest{CategoryId: root.Id, UserId: rootModerator})
+		assert.ErrorIs(t, err, postgres.ErrCategoryModeratorNotFound)
+	})
+}
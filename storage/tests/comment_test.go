@@ -2,11 +2,15 @@ package test
 
 import (
 	"context"
+	"flag"
 	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/Forum-service/Forum-Service/config"
 	"github.com/Forum-service/Forum-Service/genproto/comment"
+	"github.com/Forum-service/Forum-Service/storage"
 	"github.com/Forum-service/Forum-Service/storage/postgres"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -14,7 +18,10 @@ import (
 )
 
 func newTestComment(t *testing.T) *postgres.CommentDb {
-	cfg := config.Load()
+	cfg, err := config.Load(flag.NewFlagSet("test", flag.ContinueOnError), nil)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
 
 	connString := fmt.Sprintf("postgresql://%s:%s@%s:%d/%s",
 		cfg.PostgresUser,
@@ -74,6 +81,15 @@ func TestGetCommentById(t *testing.T) {
 	assert.Equal(t, createdComment.PostId, getComment.Comment.PostId)
 	assert.Equal(t, createdComment.UserId, getComment.Comment.UserId)
 	assert.Equal(t, createdComment.Body, getComment.Comment.Body)
+
+	createdAt, err := cDb.CreatedAt(context.Background(), createdComment.Id)
+	if err != nil {
+		t.Fatalf("Error getting comment creation time: %v", err)
+	}
+	assert.Equal(t, createdComment.CreatedAt, createdAt.Format(time.RFC3339))
+
+	_, err = cDb.CreatedAt(context.Background(), uuid.New().String())
+	assert.ErrorIs(t, err, storage.ErrNotFound)
 }
 
 func TestUpdateComment(t *testing.T) {
@@ -157,3 +173,100 @@ func TestGetAllComments(t *testing.T) {
 	})
 
 }
+
+func TestGetCommentChanges(t *testing.T) {
+	cDb := newTestComment(t)
+	testPostID := "0257605c-b5a7-4480-8571-52c101da352b"
+	since := time.Now().Add(-24 * time.Hour)
+
+	created := createTestComment(t, cDb, testPostID)
+	deleted := createTestComment(t, cDb, testPostID)
+
+	_, err := cDb.Delete(context.Background(), &comment.DeleteCommentRequest{Id: deleted.Id})
+	if err != nil {
+		t.Fatalf("Error deleting comment: %v", err)
+	}
+	hidden := createTestComment(t, cDb, testPostID)
+	if err := cDb.SetHidden(context.Background(), hidden.Id, true); err != nil {
+		t.Fatalf("Error hiding comment: %v", err)
+	}
+
+	changes, err := cDb.GetChanges(context.Background(), testPostID, since)
+	if err != nil {
+		t.Fatalf("Error listing comment changes: %v", err)
+	}
+
+	types := map[string]string{}
+	for _, change := range changes {
+		assert.Equal(t, testPostID, change.Comment.PostId)
+		assert.NotEmpty(t, change.Cursor)
+		types[change.Comment.Id] = change.Type
+		// Only visible comments carry their content
+		if change.Type == "deleted" || change.Type == "hidden" {
+			assert.Empty(t, change.Comment.Body)
+		}
+	}
+	assert.Equal(t, "created", types[created.Id])
+	assert.Equal(t, "deleted", types[deleted.Id])
+	assert.Equal(t, "hidden", types[hidden.Id])
+}
+
+func TestCommentRevisions(t *testing.T) {
+	cDb := newTestComment(t)
+	testPostID := "0257605c-b5a7-4480-8571-52c101da352b"
+	createdComment := createTestComment(t, cDb, testPostID)
+	editorID := uuid.New().String()
+
+	updated, err := cDb.Update(context.Background(), &comment.UpdateCommentRequest{
+		Id:       createdComment.Id,
+		Body:     "Edited comment body.",
+		EditorId: editorID,
+	})
+	if err != nil {
+		t.Fatalf("Error updating comment: %v", err)
+	}
+	assert.NotEmpty(t, updated.Comment.EditedAt)
+
+	resp, err := cDb.GetRevisions(context.Background(), &comment.GetCommentRevisionsRequest{CommentId: createdComment.Id})
+	if err != nil {
+		t.Fatalf("Error listing comment revisions: %v", err)
+	}
+	if assert.Len(t, resp.Revisions, 1) {
+		assert.Equal(t, int32(1), resp.Revisions[0].Revision)
+		assert.Equal(t, editorID, resp.Revisions[0].EditorId)
+		assert.Equal(t, createdComment.Body, resp.Revisions[0].Body)
+	}
+}
+
+func TestUpdateCommentConcurrently(t *testing.T) {
+	const editors = 5
+	testPostID := "0257605c-b5a7-4480-8571-52c101da352b"
+	createdComment := createTestComment(t, newTestComment(t), testPostID)
+
+	// Every editor gets its own connection so the updates really overlap
+	errs := make([]error, editors)
+	var wg sync.WaitGroup
+	for i := range errs {
+		cDb := newTestComment(t)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = cDb.Update(context.Background(), &comment.UpdateCommentRequest{
+				Id:              createdComment.Id,
+				Body:            fmt.Sprintf("Edit %d", i),
+				ExpectedVersion: createdComment.Version,
+			})
+		}()
+	}
+	wg.Wait()
+
+	succeeded := 0
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		assert.ErrorIs(t, err, storage.ErrVersionConflict)
+	}
+	assert.Equal(t, 1, succeeded)
+}
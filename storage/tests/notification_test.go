@@ -0,0 +1,144 @@
+package test
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"testing"
+
+	"github.com/Forum-service/Forum-Service/config"
+	"github.com/Forum-service/Forum-Service/genproto/notification"
+	"github.com/Forum-service/Forum-Service/storage/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestNotification(t *testing.T) *postgres.NotificationDb {
+	cfg, err := config.Load(flag.NewFlagSet("test", flag.ContinueOnError), nil)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	connString := fmt.Sprintf("postgresql://%s:%s@%s:%d/%s",
+		cfg.PostgresUser,
+		cfg.PostgresPassword,
+		"localhost",
+		5432,
+		cfg.PostgresDatabase,
+	)
+
+	db, err := pgx.Connect(context.Background(), connString)
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	return &postgres.NotificationDb{Db: db}
+}
+
+func TestNotifyCoalescesUnread(t *testing.T) {
+	nDb := newTestNotification(t)
+	testUserID := uuid.New().String()
+	testPostID := "0257605c-b5a7-4480-8571-52c101da352b"
+
+	for i := 0; i < 3; i++ {
+		err := nDb.Notify(context.Background(), &notification.Notification{
+			UserId:    testUserID,
+			Type:      "post_comment",
+			TargetId:  testPostID,
+			PostId:    testPostID,
+			CommentId: uuid.New().String(),
+			ActorId:   uuid.New().String(),
+		})
+		if err != nil {
+			t.Fatalf("Error creating notification: %v", err)
+		}
+	}
+
+	resp, err := nDb.GetAllNotifications(context.Background(), &notification.ListNotificationsRequest{UserId: testUserID})
+	if err != nil {
+		t.Fatalf("Error listing notifications: %v", err)
+	}
+
+	assert.Len(t, resp.Notifications, 1)
+	assert.Equal(t, int32(3), resp.Notifications[0].Count)
+	assert.False(t, resp.Notifications[0].IsRead)
+}
+
+func TestMarkNotificationsRead(t *testing.T) {
+	nDb := newTestNotification(t)
+	testUserID := uuid.New().String()
+
+	for i := 0; i < 2; i++ {
+		err := nDb.Notify(context.Background(), &notification.Notification{
+			UserId:   testUserID,
+			Type:     "mention",
+			TargetId: uuid.New().String(),
+			ActorId:  uuid.New().String(),
+		})
+		if err != nil {
+			t.Fatalf("Error creating notification: %v", err)
+		}
+	}
+
+	unread, err := nDb.GetUnreadCount(context.Background(), &notification.GetUnreadCountRequest{UserId: testUserID})
+	if err != nil {
+		t.Fatalf("Error counting unread notifications: %v", err)
+	}
+	assert.Equal(t, int32(2), unread.Count)
+
+	marked, err := nDb.MarkRead(context.Background(), &notification.MarkNotificationsReadRequest{UserId: testUserID, All: true})
+	if err != nil {
+		t.Fatalf("Error marking notifications as read: %v", err)
+	}
+	assert.Equal(t, int32(2), marked.Updated)
+
+	unread, err = nDb.GetUnreadCount(context.Background(), &notification.GetUnreadCountRequest{UserId: testUserID})
+	if err != nil {
+		t.Fatalf("Error counting unread notifications: %v", err)
+	}
+	assert.Equal(t, int32(0), unread.Count)
+}
+
+func TestUpdateNotificationPreferences(t *testing.T) {
+	nDb := newTestNotification(t)
+	testUserID := uuid.New().String()
+
+	resp, err := nDb.UpdatePreferences(context.Background(), &notification.UpdateNotificationPreferencesRequest{
+		UserId: testUserID,
+		Preferences: []*notification.NotificationPreference{
+			{Type: "mention", Enabled: false},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Error updating notification preferences: %v", err)
+	}
+
+	assert.Len(t, resp.Preferences, 1)
+	assert.Equal(t, "mention", resp.Preferences[0].Type)
+	assert.False(t, resp.Preferences[0].Enabled)
+}
+
+func TestFollowCategory(t *testing.T) {
+	nDb := newTestNotification(t)
+	testUserID := uuid.New().String()
+	testCategoryID := "a5a171a3-e5dd-491e-94a1-94eefc9fb320"
+
+	_, err := nDb.FollowCategory(context.Background(), &notification.FollowCategoryRequest{UserId: testUserID, CategoryId: testCategoryID})
+	if err != nil {
+		t.Fatalf("Error following category: %v", err)
+	}
+
+	followers, err := nDb.GetCategoryFollowers(context.Background(), testCategoryID)
+	if err != nil {
+		t.Fatalf("Error listing category followers: %v", err)
+	}
+	assert.Contains(t, followers, testUserID)
+
+	_, err = nDb.UnfollowCategory(context.Background(), &notification.UnfollowCategoryRequest{UserId: testUserID, CategoryId: testCategoryID})
+	if err != nil {
+		t.Fatalf("Error unfollowing category: %v", err)
+	}
+
+	_, err = nDb.UnfollowCategory(context.Background(), &notification.UnfollowCategoryRequest{UserId: testUserID, CategoryId: testCategoryID})
+	assert.Error(t, err, "Unfollowing twice should fail")
+}
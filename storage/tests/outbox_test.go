@@ -0,0 +1,188 @@
+package test
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Forum-service/Forum-Service/config"
+	"github.com/Forum-service/Forum-Service/events"
+	"github.com/Forum-service/Forum-Service/genproto/post"
+	"github.com/Forum-service/Forum-Service/storage/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestOutbox(t *testing.T) *postgres.OutboxDb {
+	cfg, err := config.Load(flag.NewFlagSet("test", flag.ContinueOnError), nil)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	connString := fmt.Sprintf("postgresql://%s:%s@%s:%d/%s",
+		cfg.PostgresUser,
+		cfg.PostgresPassword,
+		"localhost",
+		5432,
+		cfg.PostgresDatabase,
+	)
+
+	db, err := pgx.Connect(context.Background(), connString)
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	return &postgres.OutboxDb{Db: db}
+}
+
+func TestOutboxClaimAndPublish(t *testing.T) {
+	oDb := newTestOutbox(t)
+	postID := uuid.New().String()
+
+	e, err := events.New(events.PostCreated, events.AggregatePost, postID, &post.Post{Id: postID, Title: "Outbox"})
+	if err != nil {
+		t.Fatalf("Error building event: %v", err)
+	}
+	if err := oDb.Add(context.Background(), e); err != nil {
+		t.Fatalf("Error adding outbox event: %v", err)
+	}
+
+	claimed, err := oDb.ClaimPending(context.Background(), 1000, time.Minute)
+	if err != nil {
+		t.Fatalf("Error claiming outbox events: %v", err)
+	}
+
+	var found *events.Event
+	for _, c := range claimed {
+		if c.ID == e.ID {
+			found = c
+		}
+	}
+	if assert.NotNil(t, found, "Added event should be claimable") {
+		assert.Equal(t, events.PostCreated, found.Type)
+		assert.JSONEq(t, string(e.Payload), string(found.Payload))
+	}
+
+	// A leased event must not be handed out twice
+	again, err := oDb.ClaimPending(context.Background(), 1000, time.Minute)
+	if err != nil {
+		t.Fatalf("Error claiming outbox events: %v", err)
+	}
+	for _, c := range again {
+		assert.NotEqual(t, e.ID, c.ID)
+	}
+
+	assert.NoError(t, oDb.MarkPublished(context.Background(), e.ID))
+}
+
+func TestOutboxMarkFailedReschedules(t *testing.T) {
+	oDb := newTestOutbox(t)
+	postID := uuid.New().String()
+
+	e, err := events.New(events.PostDeleted, events.AggregatePost, postID, &post.DeletePostRequest{Id: postID})
+	if err != nil {
+		t.Fatalf("Error building event: %v", err)
+	}
+	if err := oDb.Add(context.Background(), e); err != nil {
+		t.Fatalf("Error adding outbox event: %v", err)
+	}
+
+	err = oDb.MarkFailed(context.Background(), e.ID, time.Now().Add(time.Hour), "broker unavailable")
+	if err != nil {
+		t.Fatalf("Error marking outbox event as failed: %v", err)
+	}
+
+	claimed, err := oDb.ClaimPending(context.Background(), 1000, time.Minute)
+	if err != nil {
+		t.Fatalf("Error claiming outbox events: %v", err)
+	}
+	for _, c := range claimed {
+		assert.NotEqual(t, e.ID, c.ID, "Event scheduled for later should not be claimed")
+	}
+}
+
+func TestOutboxHoldsBackLaterAggregateEvents(t *testing.T) {
+	oDb := newTestOutbox(t)
+	postID := uuid.New().String()
+
+	created, err := events.New(events.PostCreated, events.AggregatePost, postID, &post.Post{Id: postID})
+	if err != nil {
+		t.Fatalf("Error building event: %v", err)
+	}
+	updated, err := events.New(events.PostUpdated, events.AggregatePost, postID, &post.Post{Id: postID, Title: "Updated"})
+	if err != nil {
+		t.Fatalf("Error building event: %v", err)
+	}
+	for _, e := range []*events.Event{created, updated} {
+		if err := oDb.Add(context.Background(), e); err != nil {
+			t.Fatalf("Error adding outbox event: %v", err)
+		}
+	}
+
+	// While the create waits for a retry the update must not overtake it
+	err = oDb.MarkFailed(context.Background(), created.ID, time.Now().Add(time.Hour), "broker unavailable")
+	if err != nil {
+		t.Fatalf("Error marking outbox event as failed: %v", err)
+	}
+	claimed, err := oDb.ClaimPending(context.Background(), 1000, time.Minute)
+	if err != nil {
+		t.Fatalf("Error claiming outbox events: %v", err)
+	}
+	for _, c := range claimed {
+		assert.NotEqual(t, postID, c.AggregateID, "Events after a failed one should be held back")
+	}
+}
+
+func TestOutboxConcurrentRelaysKeepAggregateOrder(t *testing.T) {
+	const relays = 5
+	oDb := newTestOutbox(t)
+	postID := uuid.New().String()
+
+	var added []string
+	for i := 0; i < 10; i++ {
+		e, err := events.New(events.PostUpdated, events.AggregatePost, postID, &post.Post{Id: postID, Title: fmt.Sprintf("Edit %d", i)})
+		if err != nil {
+			t.Fatalf("Error building event: %v", err)
+		}
+		if err := oDb.Add(context.Background(), e); err != nil {
+			t.Fatalf("Error adding outbox event: %v", err)
+		}
+		added = append(added, e.ID)
+	}
+
+	// Every relay gets its own connection so the claims really overlap
+	claims := make([][]string, relays)
+	errs := make([]error, relays)
+	var wg sync.WaitGroup
+	for i := range claims {
+		relay := newTestOutbox(t)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			claimed, err := relay.ClaimPending(context.Background(), 1000, time.Minute)
+			errs[i] = err
+			for _, c := range claimed {
+				if c.AggregateID == postID {
+					claims[i] = append(claims[i], c.ID)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Only one relay may hold events of the aggregate, and it must hold them
+	// from the oldest on
+	holders := 0
+	for i, claimed := range claims {
+		assert.NoError(t, errs[i])
+		if len(claimed) == 0 {
+			continue
+		}
+		holders++
+		assert.Equal(t, added[:len(claimed)], claimed)
+	}
+	assert.Equal(t, 1, holders)
+}
@@ -2,11 +2,14 @@ package test
 
 import (
 	"context"
+	"flag"
 	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/Forum-service/Forum-Service/config"
 	"github.com/Forum-service/Forum-Service/genproto/post"
+	"github.com/Forum-service/Forum-Service/storage"
 	"github.com/Forum-service/Forum-Service/storage/postgres"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -14,7 +17,10 @@ import (
 )
 
 func newTestPost(t *testing.T) *postgres.PostDb {
-	cfg := config.Load()
+	cfg, err := config.Load(flag.NewFlagSet("test", flag.ContinueOnError), nil)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
 
 	connString := fmt.Sprintf("postgresql://%s:%s@%s:%d/%s",
 		cfg.PostgresUser,
@@ -165,3 +171,191 @@ func TestGetAllPosts(t *testing.T) {
 
 	// ... (add more test cases for other filters: UserId, Title, Pagination)
 }
+
+func TestPostRevisions(t *testing.T) {
+	pDb := newTestPost(t)
+	createdPost := createTestPost(t, pDb)
+	editorID := uuid.New().String()
+
+	updatedPost, err := pDb.Update(context.Background(), &post.UpdatePostRequest{
+		Id:       createdPost.Id,
+		Title:    "Edited Post Title",
+		EditorId: editorID,
+	})
+	if err != nil {
+		t.Fatalf("Error updating post: %v", err)
+	}
+
+	assert.True(t, updatedPost.Post.Edited)
+	assert.Equal(t, int32(1), updatedPost.Post.RevisionCount)
+
+	revisions, err := pDb.GetRevisions(context.Background(), &post.GetPostRevisionsRequest{PostId: createdPost.Id})
+	if err != nil {
+		t.Fatalf("Error listing post revisions: %v", err)
+	}
+	if assert.Len(t, revisions.Revisions, 1) {
+		assert.Equal(t, int32(1), revisions.Revisions[0].Revision)
+		assert.Equal(t, createdPost.Title, revisions.Revisions[0].Title)
+		assert.Equal(t, editorID, revisions.Revisions[0].EditorId)
+	}
+
+	revision, err := pDb.GetRevision(context.Background(), createdPost.Id, 1)
+	if err != nil {
+		t.Fatalf("Error getting post revision: %v", err)
+	}
+	assert.Equal(t, createdPost.Body, revision.Body)
+
+	_, err = pDb.GetRevision(context.Background(), createdPost.Id, 2)
+	assert.ErrorIs(t, err, postgres.ErrPostRevisionNotFound)
+}
+
+func TestUpdatePostVersionConflict(t *testing.T) {
+	pDb := newTestPost(t)
+	createdPost := createTestPost(t, pDb)
+
+	updated, err := pDb.Update(context.Background(), &post.UpdatePostRequest{
+		Id:              createdPost.Id,
+		Title:           "First editor",
+		ExpectedVersion: createdPost.Version,
+	})
+	if err != nil {
+		t.Fatalf("Error updating post: %v", err)
+	}
+	assert.Equal(t, createdPost.Version+1, updated.Post.Version)
+
+	// A second editor still holding the original version must be rejected
+	_, err = pDb.Update(context.Background(), &post.UpdatePostRequest{
+		Id:              createdPost.Id,
+		Title:           "Second editor",
+		ExpectedVersion: createdPost.Version,
+	})
+	assert.ErrorIs(t, err, storage.ErrVersionConflict)
+
+	_, err = pDb.Update(context.Background(), &post.UpdatePostRequest{
+		Id:              uuid.New().String(),
+		Title:           "Missing post",
+		ExpectedVersion: 1,
+	})
+	assert.ErrorIs(t, err, postgres.ErrPostNotFound)
+}
+
+func TestPostModerationStatus(t *testing.T) {
+	pDb := newTestPost(t)
+	createdPost := createTestPost(t, pDb)
+	original := createTestPost(t, pDb)
+
+	pinned, err := pDb.Pin(context.Background(), &post.PinPostRequest{PostId: createdPost.Id, Pinned: true})
+	if err != nil {
+		t.Fatalf("Error pinning post: %v", err)
+	}
+	assert.True(t, pinned.Post.Pinned)
+	assert.Equal(t, createdPost.Version+1, pinned.Post.Version)
+
+	locked, err := pDb.Lock(context.Background(), &post.LockPostRequest{PostId: createdPost.Id, Locked: true})
+	if err != nil {
+		t.Fatalf("Error locking post: %v", err)
+	}
+	assert.True(t, locked.Post.Locked)
+
+	closed, err := pDb.Close(context.Background(), &post.ClosePostRequest{
+		PostId:        createdPost.Id,
+		Closed:        true,
+		Reason:        "duplicate",
+		DuplicateOfId: original.Id,
+	})
+	if err != nil {
+		t.Fatalf("Error closing post: %v", err)
+	}
+	assert.True(t, closed.Post.Closed)
+	assert.Equal(t, "duplicate", closed.Post.CloseReason)
+	assert.Equal(t, original.Id, closed.Post.DuplicateOfId)
+
+	reopened, err := pDb.Close(context.Background(), &post.ClosePostRequest{PostId: createdPost.Id})
+	if err != nil {
+		t.Fatalf("Error reopening post: %v", err)
+	}
+	assert.False(t, reopened.Post.Closed)
+	assert.Empty(t, reopened.Post.DuplicateOfId)
+	assert.Equal(t, createdPost.Version+4, reopened.Post.Version)
+
+	// Edits made against the post from before its status changed conflict
+	_, err = pDb.Update(context.Background(), &post.UpdatePostRequest{
+		Id:              createdPost.Id,
+		Title:           "Stale edit",
+		ExpectedVersion: createdPost.Version,
+	})
+	assert.ErrorIs(t, err, storage.ErrVersionConflict)
+
+	t.Run("Pinned posts come first in their category", func(t *testing.T) {
+		resp, err := pDb.GetAllPosts(context.Background(), &post.GetAllPostsRequest{CategoryId: createdPost.CategoryId})
+		if err != nil {
+			t.Fatalf("Error listing posts: %v", err)
+		}
+		if assert.NotEmpty(t, resp.Posts) {
+			assert.True(t, resp.Posts[0].Pinned)
+		}
+	})
+}
+
+func TestDeleteMissingPost(t *testing.T) {
+	pDb := newTestPost(t)
+	createdPost := createTestPost(t, pDb)
+
+	_, err := pDb.Delete(context.Background(), &post.DeletePostRequest{Id: uuid.New().String()})
+	assert.ErrorIs(t, err, postgres.ErrPostNotFound)
+
+	// Deleting twice reports the post as gone
+	_, err = pDb.Delete(context.Background(), &post.DeletePostRequest{Id: createdPost.Id})
+	assert.NoError(t, err)
+	_, err = pDb.Delete(context.Background(), &post.DeletePostRequest{Id: createdPost.Id})
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+}
+
+func TestUpdatePostConcurrently(t *testing.T) {
+	const editors = 5
+	createdPost := createTestPost(t, newTestPost(t))
+
+	// Every editor gets its own connection so the updates really overlap
+	conns := make([]*postgres.PostDb, editors)
+	for i := range conns {
+		conns[i] = newTestPost(t)
+	}
+	update := func(expectedVersion int32) []error {
+		errs := make([]error, editors)
+		var wg sync.WaitGroup
+		for i, pDb := range conns {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, errs[i] = pDb.Update(context.Background(), &post.UpdatePostRequest{
+					Id:              createdPost.Id,
+					Title:           fmt.Sprintf("Editor %d", i),
+					ExpectedVersion: expectedVersion,
+				})
+			}()
+		}
+		wg.Wait()
+		return errs
+	}
+
+	// Editors holding the same version: one wins, the others conflict
+	succeeded := 0
+	for _, err := range update(createdPost.Version) {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		assert.ErrorIs(t, err, storage.ErrVersionConflict)
+	}
+	assert.Equal(t, 1, succeeded)
+
+	// Unversioned edits are last write wins and each stores its own revision
+	for _, err := range update(0) {
+		assert.NoError(t, err)
+	}
+	resp, err := conns[0].GetRevisions(context.Background(), &post.GetPostRevisionsRequest{PostId: createdPost.Id})
+	if err != nil {
+		t.Fatalf("Error listing post revisions: %v", err)
+	}
+	assert.Len(t, resp.Revisions, 1+editors)
+}
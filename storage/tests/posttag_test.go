@@ -2,6 +2,7 @@ package test
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"testing"
 
@@ -17,7 +18,10 @@ import (
 // ... other test setup functions ...
 
 func newTestPostTag(t *testing.T) *postgres.PostTagDb {
-	cfg := config.Load()
+	cfg, err := config.Load(flag.NewFlagSet("test", flag.ContinueOnError), nil)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
 
 	connString := fmt.Sprintf("postgresql://%s:%s@%s:%d/%s",
 		cfg.PostgresUser,
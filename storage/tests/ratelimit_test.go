@@ -0,0 +1,83 @@
+package test
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Forum-service/Forum-Service/config"
+	"github.com/Forum-service/Forum-Service/ratelimit"
+	"github.com/Forum-service/Forum-Service/storage/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRateLimit(t *testing.T) *postgres.RateLimitDb {
+	cfg, err := config.Load(flag.NewFlagSet("test", flag.ContinueOnError), nil)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	connString := fmt.Sprintf("postgresql://%s:%s@%s:%d/%s",
+		cfg.PostgresUser,
+		cfg.PostgresPassword,
+		"localhost",
+		5432,
+		cfg.PostgresDatabase,
+	)
+
+	db, err := pgx.Connect(context.Background(), connString)
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	return &postgres.RateLimitDb{Db: db}
+}
+
+func TestRateLimitTake(t *testing.T) {
+	rDb := newTestRateLimit(t)
+	key := "test:" + uuid.New().String()
+	limit := ratelimit.Limit{Requests: 2, Per: time.Hour}
+
+	for i := 0; i < 2; i++ {
+		allowed, _, err := rDb.Take(context.Background(), key, limit)
+		if err != nil {
+			t.Fatalf("Error taking rate limit token: %v", err)
+		}
+		assert.True(t, allowed)
+	}
+
+	allowed, retryAfter, err := rDb.Take(context.Background(), key, limit)
+	if err != nil {
+		t.Fatalf("Error taking rate limit token: %v", err)
+	}
+	assert.False(t, allowed)
+	assert.Greater(t, retryAfter, 29*time.Minute)
+}
+
+func TestRateLimitPrune(t *testing.T) {
+	rDb := newTestRateLimit(t)
+	expired := "test:" + uuid.New().String()
+	live := "test:" + uuid.New().String()
+
+	if _, _, err := rDb.Take(context.Background(), expired, ratelimit.Limit{Requests: 1, Per: time.Millisecond}); err != nil {
+		t.Fatalf("Error taking rate limit token: %v", err)
+	}
+	if _, _, err := rDb.Take(context.Background(), live, ratelimit.Limit{Requests: 1, Per: time.Hour}); err != nil {
+		t.Fatalf("Error taking rate limit token: %v", err)
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	pruned, err := rDb.Prune(context.Background())
+	if err != nil {
+		t.Fatalf("Error pruning rate limit buckets: %v", err)
+	}
+	assert.GreaterOrEqual(t, pruned, int64(1))
+
+	var keys []string
+	err = rDb.Db.QueryRow(context.Background(), `SELECT ARRAY_AGG(key) FROM rate_limit_buckets WHERE key = ANY($1)`, []string{expired, live}).Scan(&keys)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{live}, keys)
+}
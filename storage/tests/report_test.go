@@ -0,0 +1,169 @@
+package test
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"testing"
+
+	"github.com/Forum-service/Forum-Service/config"
+	"github.com/Forum-service/Forum-Service/genproto/category"
+	"github.com/Forum-service/Forum-Service/genproto/post"
+	"github.com/Forum-service/Forum-Service/genproto/report"
+	"github.com/Forum-service/Forum-Service/storage"
+	"github.com/Forum-service/Forum-Service/storage/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestReport(t *testing.T) *postgres.ReportDb {
+	cfg, err := config.Load(flag.NewFlagSet("test", flag.ContinueOnError), nil)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	connString := fmt.Sprintf("postgresql://%s:%s@%s:%d/%s",
+		cfg.PostgresUser,
+		cfg.PostgresPassword,
+		"localhost",
+		5432,
+		cfg.PostgresDatabase,
+	)
+
+	db, err := pgx.Connect(context.Background(), connString)
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	return &postgres.ReportDb{Db: db}
+}
+
+func createTestReport(t *testing.T, rDb *postgres.ReportDb, targetID, reason string) *report.Report {
+	resp, err := rDb.Create(context.Background(), &report.ReportContentRequest{
+		TargetType: "post",
+		TargetId:   targetID,
+		ReporterId: uuid.New().String(),
+		Reason:     reason,
+		Details:    "Reported from a test",
+	})
+	if err != nil {
+		t.Fatalf("Error creating report: %v", err)
+	}
+	return resp.Report
+}
+
+func TestModerationQueue(t *testing.T) {
+	rDb := newTestReport(t)
+	targetID := uuid.New().String()
+
+	first := createTestReport(t, rDb, targetID, "spam")
+	createTestReport(t, rDb, targetID, "abuse")
+
+	resp, err := rDb.GetQueue(context.Background(), &report.GetModerationQueueRequest{TargetType: "post", Limit: 1000}, "")
+	if err != nil {
+		t.Fatalf("Error getting moderation queue: %v", err)
+	}
+
+	var item *report.ModerationQueueItem
+	for _, i := range resp.Items {
+		if i.TargetId == targetID {
+			item = i
+		}
+	}
+	if assert.NotNil(t, item, "Reported post should be queued") {
+		assert.Equal(t, int32(2), item.ReportCount)
+		assert.ElementsMatch(t, []string{"abuse", "spam"}, item.Reasons)
+		assert.Equal(t, first.Id, item.ReportIds[0])
+	}
+}
+
+func TestModerationQueueOfCategoryModerator(t *testing.T) {
+	rDb := newTestReport(t)
+	cDb := newTestCategory(t)
+	pDb := newTestPost(t)
+
+	root := createTestCategory(t, cDb)
+	child, err := cDb.Create(context.Background(), &category.CreateCategoryRequest{Name: "Child Category", ParentId: root.Id})
+	if err != nil {
+		t.Fatalf("Error creating child category: %v", err)
+	}
+	moderator := uuid.New().String()
+	if _, err := cDb.AddModerator(context.Background(), &category.AddCategoryModeratorRequest{CategoryId: root.Id, UserId: moderator}); err != nil {
+		t.Fatalf("Error adding category moderator: %v", err)
+	}
+	created, err := pDb.Create(context.Background(), &post.CreatePostRequest{
+		UserId:     uuid.New().String(),
+		Title:      "Reported Post",
+		Body:       "Reported post body.",
+		CategoryId: child.Category.Id,
+	})
+	if err != nil {
+		t.Fatalf("Error creating post: %v", err)
+	}
+	createTestReport(t, rDb, created.Post.Id, "spam")
+
+	queued := func(moderatorID string) bool {
+		resp, err := rDb.GetQueue(context.Background(), &report.GetModerationQueueRequest{Limit: 1000}, moderatorID)
+		if err != nil {
+			t.Fatalf("Error getting moderation queue: %v", err)
+		}
+		for _, item := range resp.Items {
+			if item.TargetId == created.Post.Id {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Moderators of a parent category see reports in its sub-categories
+	assert.True(t, queued(moderator))
+	assert.False(t, queued(uuid.New().String()))
+	assert.True(t, queued(""))
+}
+
+func TestDuplicateReport(t *testing.T) {
+	rDb := newTestReport(t)
+	req := &report.ReportContentRequest{
+		TargetType: "comment",
+		TargetId:   uuid.New().String(),
+		ReporterId: uuid.New().String(),
+		Reason:     "spam",
+	}
+
+	_, err := rDb.Create(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Error creating report: %v", err)
+	}
+
+	_, err = rDb.Create(context.Background(), req)
+	assert.ErrorIs(t, err, storage.ErrAlreadyReported)
+}
+
+func TestResolveReport(t *testing.T) {
+	rDb := newTestReport(t)
+	targetID := uuid.New().String()
+	moderatorID := uuid.New().String()
+
+	first := createTestReport(t, rDb, targetID, "spam")
+	createTestReport(t, rDb, targetID, "spam")
+
+	resp, err := rDb.Resolve(context.Background(), &report.ResolveReportRequest{
+		ReportId:    first.Id,
+		Action:      "dismiss",
+		ModeratorId: moderatorID,
+		Note:        "Not spam",
+	})
+	if err != nil {
+		t.Fatalf("Error resolving report: %v", err)
+	}
+
+	// Both open reports on the post are closed together
+	if assert.Len(t, resp.Reports, 2) {
+		for _, r := range resp.Reports {
+			assert.Equal(t, "resolved", r.Status)
+			assert.Equal(t, "dismiss", r.Resolution)
+			assert.Equal(t, moderatorID, r.ResolvedBy)
+			assert.NotEmpty(t, r.ResolvedAt)
+		}
+	}
+}
@@ -2,18 +2,26 @@ package test
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"testing"
 
 	"github.com/Forum-service/Forum-Service/config"
+	"github.com/Forum-service/Forum-Service/genproto/automod"
+	"github.com/Forum-service/Forum-Service/genproto/posttag"
 	"github.com/Forum-service/Forum-Service/genproto/tag"
+	"github.com/Forum-service/Forum-Service/storage"
 	"github.com/Forum-service/Forum-Service/storage/postgres"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/stretchr/testify/assert"
 )
 
 func newTestTag(t *testing.T) *postgres.TagDb {
-	cfg := config.Load()
+	cfg, err := config.Load(flag.NewFlagSet("test", flag.ContinueOnError), nil)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
 
 	connString := fmt.Sprintf("postgresql://%s:%s@%s:%d/%s",
 		cfg.PostgresUser,
@@ -130,3 +138,148 @@ func TestGetAllTags(t *testing.T) {
 		assert.GreaterOrEqual(t, len(resp.Tags), len(testTags))
 	})
 }
+
+func TestMergeTags(t *testing.T) {
+	tDb := newTestTag(t)
+	synonymName := "golang-" + uuid.New().String()
+
+	source, err := tDb.Create(context.Background(), &tag.CreateTagRequest{Name: synonymName})
+	if err != nil {
+		t.Fatalf("Error creating tag: %v", err)
+	}
+	target := createTestTag(t, tDb)
+
+	aDb := newTestAutomod(t)
+	rule, err := aDb.Create(context.Background(), &automod.CreateAutomodRuleRequest{
+		Name:    "Tag Go posts",
+		Pattern: `(?i)\bgolang\b`,
+		Action:  "tag",
+		TagId:   source.Tag.Id,
+		Enabled: true,
+	})
+	if err != nil {
+		t.Fatalf("Error creating automod rule: %v", err)
+	}
+
+	resp, err := tDb.Merge(context.Background(), &tag.MergeTagsRequest{
+		SourceIds: []string{source.Tag.Id},
+		TargetId:  target.Id,
+	})
+	if err != nil {
+		t.Fatalf("Error merging tags: %v", err)
+	}
+	assert.Equal(t, target.Id, resp.Target.Id)
+
+	t.Run("Synonym name resolves to the target", func(t *testing.T) {
+		byName, err := tDb.GetByName(context.Background(), &tag.GetTagByNameRequest{Name: synonymName})
+		if err != nil {
+			t.Fatalf("Error getting tag by synonym: %v", err)
+		}
+		assert.Equal(t, target.Id, byName.Tag.Id)
+		assert.Equal(t, synonymName, byName.ResolvedFrom)
+	})
+
+	t.Run("Merged ID resolves to the target", func(t *testing.T) {
+		byID, err := tDb.GetById(context.Background(), &tag.GetTagRequest{Id: source.Tag.Id})
+		if err != nil {
+			t.Fatalf("Error getting merged tag: %v", err)
+		}
+		assert.Equal(t, target.Id, byID.Tag.Id)
+	})
+
+	t.Run("Synonym is listed", func(t *testing.T) {
+		list, err := tDb.ListSynonyms(context.Background(), &tag.ListTagSynonymsRequest{TagId: target.Id})
+		if err != nil {
+			t.Fatalf("Error listing tag synonyms: %v", err)
+		}
+		if assert.Len(t, list.Synonyms, 1) {
+			assert.Equal(t, synonymName, list.Synonyms[0].Name)
+			assert.Equal(t, source.Tag.Id, list.Synonyms[0].SourceTagId)
+		}
+	})
+
+	t.Run("Synonym names cannot be reused", func(t *testing.T) {
+		_, err := tDb.Create(context.Background(), &tag.CreateTagRequest{Name: synonymName})
+		assert.ErrorIs(t, err, storage.ErrTagNameTaken)
+
+		_, err = tDb.Update(context.Background(), &tag.UpdateTagRequest{Id: createTestTag(t, tDb).Id, Name: synonymName})
+		assert.ErrorIs(t, err, storage.ErrTagNameTaken)
+	})
+
+	t.Run("Automod rules follow the merge", func(t *testing.T) {
+		got, err := aDb.GetById(context.Background(), &automod.GetAutomodRuleRequest{Id: rule.Rule.Id})
+		if err != nil {
+			t.Fatalf("Error getting automod rule: %v", err)
+		}
+		assert.Equal(t, target.Id, got.Rule.TagId)
+	})
+}
+
+func TestTagMetadata(t *testing.T) {
+	tDb := newTestTag(t)
+
+	created, err := tDb.Create(context.Background(), &tag.CreateTagRequest{
+		Name:        "announcement",
+		Description: "Official announcements",
+		Guidance:    "Only staff posts about releases and downtime",
+		Color:       "#ff8800",
+		Restricted:  true,
+	})
+	if err != nil {
+		t.Fatalf("Error creating tag: %v", err)
+	}
+
+	getResp, err := tDb.GetById(context.Background(), &tag.GetTagRequest{Id: created.Tag.Id})
+	if err != nil {
+		t.Fatalf("Error getting tag: %v", err)
+	}
+	assert.True(t, getResp.Tag.Restricted)
+	assert.Equal(t, "#ff8800", getResp.Tag.Color)
+	assert.Zero(t, getResp.UsageCount)
+
+	restricted := false
+	updated, err := tDb.Update(context.Background(), &tag.UpdateTagRequest{
+		Id:         created.Tag.Id,
+		Restricted: &restricted,
+	})
+	if err != nil {
+		t.Fatalf("Error updating tag: %v", err)
+	}
+	assert.False(t, updated.Tag.Restricted)
+	assert.Equal(t, "Official announcements", updated.Tag.Description)
+}
+
+func TestGetRelatedTags(t *testing.T) {
+	tDb := newTestTag(t)
+	pDb := newTestPost(t)
+	ptDb := newTestPostTag(t)
+
+	base := createTestTag(t, tDb)
+	often := createTestTag(t, tDb)
+	rarely := createTestTag(t, tDb)
+
+	// often shares both posts with base, rarely only one
+	for i, tags := range [][]string{{base.Id, often.Id, rarely.Id}, {base.Id, often.Id}} {
+		p := createTestPost(t, pDb)
+		for _, tagID := range tags {
+			_, err := ptDb.Create(context.Background(), &posttag.CreatePostTagRequest{PostId: p.Id, TagId: tagID})
+			if err != nil {
+				t.Fatalf("Error tagging post %d: %v", i, err)
+			}
+		}
+	}
+
+	resp, err := tDb.GetRelated(context.Background(), &tag.GetRelatedTagsRequest{TagId: base.Id})
+	if err != nil {
+		t.Fatalf("Error getting related tags: %v", err)
+	}
+	if assert.Len(t, resp.Tags, 2) {
+		assert.Equal(t, often.Id, resp.Tags[0].Tag.Id)
+		assert.Equal(t, int32(2), resp.Tags[0].CoOccurrences)
+		assert.InDelta(t, 1.0, resp.Tags[0].Affinity, 1e-9)
+
+		assert.Equal(t, rarely.Id, resp.Tags[1].Tag.Id)
+		assert.Equal(t, int32(1), resp.Tags[1].CoOccurrences)
+		assert.InDelta(t, 0.5, resp.Tags[1].Affinity, 1e-9)
+	}
+}